@@ -1,19 +1,28 @@
 package main
 
 import (
+	"context"
 	"log"
+	"log/slog"
 	"os"
-	"time"
+	"os/signal"
+	"strconv"
+	"syscall"
 
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/feedbackImport"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/sentimentAnalysis"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/sourceSync"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/trashPurge"
 
 	swagger "github.com/arsmn/fiber-swagger/v2"
 	"github.com/gofiber/fiber/v2"
-	"github.com/tot0p/env"
 	_ "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/docs/feed-pulse"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/api"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/config"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
-	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/migrations"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/logging"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/metrics"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/sessionManager"
 )
 
@@ -26,55 +35,109 @@ import (
 // @BasePath /
 // @schemes http
 func main() {
+	// Load the configuration, every missing or invalid setting is reported at once
+	cfg, err := config.Load(config.KeySecretKey, config.KeyMistralAPIKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := logging.Setup(cfg.LogLevel, cfg.LogFormat); err != nil {
+		log.Fatal(err)
+	}
+	slog.Info("Configuration loaded", "config", cfg.String())
+
 	app := fiber.New(fiber.Config{
 		ErrorHandler: customErrorHandler,
+		// The request bodies are buffered in memory, uploaded files included, the limit bounds a file
+		// to a few tens of thousands of rows whose parsing is then streamed by the upload handler
+		BodyLimit: 16 * 1024 * 1024,
+		// The rate limits by IP need the client IP behind the load balancer
+		ProxyHeader: cfg.ProxyHeader,
 	})
-	err := env.Load()
-	if err != nil {
-		if !os.IsNotExist(err) {
-			log.Fatalf("Failed to load environment variables: %v", err)
-		}
-	}
 
 	// Initialize the database connection
-	err = database.InitDatabase(env.Get("DB_USER"), env.Get("DB_PASSWORD"), env.Get("DB_NAME"), env.Get("DB_HOST"), env.Get("DB_PORT"), env.Get("DB_SSLMODE"))
+	err = database.InitDatabase(cfg.Database.User, cfg.Database.Password, cfg.Database.Name, cfg.Database.Host, strconv.Itoa(cfg.Database.Port), cfg.Database.SSLMode)
 	if err != nil {
-		log.Fatalf("Failed to initialize database: %v", err)
+		fatal("Failed to initialize database", err)
+	}
+	// Expose the connection pool stats on /metrics
+	sqlDB, err := database.DB.DB()
+	if err == nil {
+		err = metrics.RegisterDB(sqlDB)
+	}
+	if err != nil {
+		fatal("Failed to register the database metrics", err)
 	}
 
 	// Initialisation de Redis
-	database.InitRedis(
-		env.Get("REDIS_URL"),
-	)
+	if cfg.RedisURL != "" {
+		if err := database.InitRedis(cfg.RedisURL); err != nil {
+			fatal("Failed to initialize redis", err)
+		}
+	}
 
-	// Initialize and automigrate the models
-	err = models.InitModels()
+	// Apply the pending schema migrations
+	applied, err := migrations.Up(database.DB)
 	if err != nil {
-		log.Fatalf("Failed to initialize models: %v", err)
+		fatal("Failed to migrate the database", err)
+	}
+	for _, migration := range applied {
+		slog.Info("Applied migration", "version", migration.Version, "name", migration.Name)
 	}
 
-	// Initialize the session manager
-	sentimentAnalysis.InitSentimentAnalysis(env.Get("MISTRAL_API_KEY"), env.Get("EMAIL_PASSWORD"))
+	// Initialize the sentiment analysis
+	sentimentAnalysis.InitSentimentAnalysis(cfg.MistralAPIKey, cfg.EmailPassword)
+
+	// Resume the analyses of the imports interrupted by the previous shutdown
+	if err := feedbackImport.Resume(context.Background()); err != nil {
+		slog.Error("Failed to resume the interrupted imports", "error", err)
+	}
 
 	// Initialize the SessionManager
-	if env.Get("SECRET_KEY") == "" {
-		log.Fatal("SECRET_KEY environment variable is not set")
+	sessionManager.InitSessionManager(cfg.SecretKey, cfg.SessionDuration)
+
+	// Start polling the scheduled feedback sources
+	err = sourceSync.InitScheduler()
+	if err != nil {
+		fatal("Failed to start source scheduler", err)
 	}
-	sessionManager.InitSessionManager(env.Get("SECRET_KEY"), 3*time.Hour)
 
-	err = api.SetupRoutes(app)
+	// Purge the trash of the boards and feedbacks deleted for longer than the retention
+	err = trashPurge.InitPurger(cfg.TrashRetention)
 	if err != nil {
-		log.Fatalf("Failed to set up routes: %v", err)
+		fatal("Failed to start trash purge", err)
+	}
+
+	err = api.SetupRoutes(app, cfg)
+	if err != nil {
+		fatal("Failed to set up routes", err)
 	}
 	app.Get("/swagger/*", swagger.HandlerDefault)
 
-	port := env.Get("PORT")
-	if port == "" {
-		port = "3000"
+	// Listen until SIGINT or SIGTERM, then drain before exiting
+	listenErr := make(chan error, 1)
+	go func() {
+		slog.Info("Starting server", "port", cfg.Port)
+		listenErr <- app.Listen(":" + strconv.Itoa(cfg.Port))
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case err := <-listenErr:
+		fatal("Server failed", err)
+	case sig := <-signals:
+		slog.Info("Shutting down", "signal", sig.String(), "timeout", cfg.ShutdownTimeout.String())
 	}
+	signal.Stop(signals)
+
+	shutdown(app, cfg.ShutdownTimeout)
+	slog.Info("Server stopped")
+}
 
-	log.Printf("Starting server on localhost: %s \n", port)
-	log.Fatal(app.Listen(":" + port))
+// fatal logs the error which prevents the server from running and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
 
 // customErrorHandler provides better error responses
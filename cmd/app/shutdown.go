@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/sessionManager"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/background"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/sourceSync"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/trashPurge"
+)
+
+// sentryFlushTimeout bounds the sending of the buffered Sentry events, on top of the shutdown timeout
+const sentryFlushTimeout = 2 * time.Second
+
+// interruptTimeout bounds the saving of the background work interrupted past the shutdown timeout
+const interruptTimeout = 3 * time.Second
+
+// shutdown stops accepting connections, then waits for the in-flight requests, the scheduled jobs
+// and the background work until the deadline, interrupting the latter past it, before flushing Sentry and closing the connections
+func shutdown(app *fiber.App, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := app.ShutdownWithContext(ctx); err != nil {
+		slog.Warn("In-flight requests not drained", "error", err)
+	}
+
+	// The schedulers stop together, a running sync and a running purge are awaited
+	jobs := []context.Context{trashPurge.Stop()}
+	if sourceSync.Instance != nil {
+		jobs = append(jobs, sourceSync.Instance.Stop())
+	}
+	for _, job := range jobs {
+		select {
+		case <-job.Done():
+		case <-ctx.Done():
+			slog.Warn("Scheduled jobs not drained", "error", ctx.Err())
+		}
+	}
+
+	if err := background.Drain(ctx); err != nil {
+		// The imports save their progress, they are resumed at the next startup
+		slog.Warn("Background work not drained, interrupting it", "error", err)
+		background.Stop()
+		interruptCtx, cancelInterrupt := context.WithTimeout(context.Background(), interruptTimeout)
+		if err := background.Drain(interruptCtx); err != nil {
+			slog.Warn("Background work not interrupted", "error", err)
+		}
+		cancelInterrupt()
+	}
+
+	if sessionManager.Instance != nil {
+		sessionManager.Instance.Close()
+	}
+	if !sentry.Flush(sentryFlushTimeout) {
+		slog.Warn("Some Sentry events were not sent")
+	}
+	if err := database.Close(); err != nil {
+		slog.Error("Failed to close the connections", "error", err)
+	}
+}
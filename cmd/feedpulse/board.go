@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
+)
+
+const boardUsage = "board add-member --board <id> --user <login>"
+
+func runBoard(args []string) error {
+	name, args, err := subcommand(args, boardUsage)
+	if err != nil {
+		return err
+	}
+	if name != "add-member" {
+		return fmt.Errorf("unknown subcommand %q, usage: feedpulse %s", name, boardUsage)
+	}
+
+	flags := newFlagSet("board add-member")
+	boardID := flags.Int("board", 0, "ID of the board")
+	login := flags.String("user", "", "username or email of the new member")
+	flags.Parse(args)
+	if err := requireBoard(*boardID); err != nil {
+		return err
+	}
+	user, err := findUser(*login)
+	if err != nil {
+		return err
+	}
+	board, err := Board.GetBoardByID(*boardID)
+	if err != nil {
+		return err
+	}
+
+	boards, err := Board.GetBoardsByUserID(user.Id)
+	if err != nil {
+		return err
+	}
+	for _, b := range boards {
+		if b.Id == board.Id {
+			return errors.New("user is already a member of the board")
+		}
+	}
+	if err := Board.AssociateBoardUser(board.Id, user.Id); err != nil {
+		return fmt.Errorf("failed to add member: %w", err)
+	}
+	fmt.Printf("Added %s to board %q\n", user.Username, board.Name)
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
+	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/feedbackExport"
+)
+
+const exportUsage = "export --board <id> --format csv|json|ndjson [--output file]"
+
+func runExport(args []string) error {
+	flags := newFlagSet("export")
+	boardID := flags.Int("board", 0, "ID of the board to export")
+	format := flags.String("format", "csv", "export format, csv, json or ndjson")
+	output := flags.String("output", "", "file to write, stdout when omitted")
+	flags.Parse(args)
+	if err := requireBoard(*boardID); err != nil {
+		return err
+	}
+	if _, err := Board.GetBoardByID(*boardID); err != nil {
+		return err
+	}
+
+	feedbacks, err := feedbackDB.GetBoardFeedbacksWithAnalyses(*boardID, feedbackDB.Filter{})
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	if err := feedbackExport.Write(w, *format, feedbacks); err != nil {
+		return err
+	}
+	if *output != "" {
+		fmt.Fprintf(os.Stderr, "Exported %d feedbacks to %s\n", len(feedbacks), *output)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/feedbackGenerator"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/feedbackImport"
+)
+
+const generateUsage = "generate [--count n] [--seed s] [--languages fr,en] [--channels c] [--topics t] [--sentiment positive:50,...] [--days d] [--end YYYY-MM-DD] [--format json|ndjson] [--output file | --board <id>]"
+
+func runGenerate(args []string) error {
+	flags := newFlagSet("generate")
+	values := map[string]*string{}
+	for key, description := range map[string]string{
+		"count":     "number of feedbacks to generate, 50 by default",
+		"seed":      "seed of the generator, random when omitted",
+		"languages": "comma separated languages among fr and en",
+		"channels":  "comma separated channels, twitter, facebook, instagram and web by default",
+		"topics":    "comma separated topics of the sentiment analysis",
+		"sentiment": "sentiment mix such as positive:60,neutral:20,negative:20",
+		"days":      "number of days before --end the dates are spread over, 30 by default",
+		"end":       "date (YYYY-MM-DD) closing the spread, today by default",
+	} {
+		values[key] = flags.String(key, "", description)
+	}
+	format := flags.String("format", feedbackImport.FormatJSON, "output format, json or ndjson")
+	output := flags.String("output", "", "file to write, stdout when omitted")
+	boardID := flags.Int("board", 0, "ID of a board to import the feedbacks into instead of writing them")
+	email := flags.String("email", "", "with --board, email receiving the negative feedback alerts, the first board member by default")
+	flags.Parse(args)
+
+	opts, err := feedbackGenerator.ParseOptions(func(key string) string { return *values[key] })
+	if err != nil {
+		return err
+	}
+	feedbacks, err := feedbackGenerator.Generate(opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Generated %d feedbacks with seed %d\n", len(feedbacks), opts.Seed)
+
+	if *boardID > 0 {
+		return importGenerated(*boardID, *email, opts.Seed, feedbacks)
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	encoder := json.NewEncoder(w)
+	switch *format {
+	case feedbackImport.FormatJSON:
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(feedbacks)
+	case feedbackImport.FormatNDJSON:
+		for _, feedback := range feedbacks {
+			if err := encoder.Encode(feedback); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported format %q, expected json or ndjson", *format)
+	}
+}
+
+// importGenerated imports the generated feedbacks into the board as a file import would
+func importGenerated(boardID int, email string, seed int64, feedbacks []feedbackModel.FeedbackJson) error {
+	connect(true)
+	if _, err := Board.GetBoardByID(boardID); err != nil {
+		return err
+	}
+	var err error
+	if email == "" {
+		if email, err = Board.GetBoardNotificationEmail(boardID); err != nil {
+			return err
+		}
+	}
+
+	importer, err := feedbackImport.Start(context.Background(), boardID, fmt.Sprintf("synthetic-%d.json", seed), feedbackImport.FormatJSON, email, "")
+	if err != nil {
+		return err
+	}
+	for i, feedback := range feedbacks {
+		if err := importer.Add(i+1, feedback); err != nil {
+			importer.Abort(err)
+			return fmt.Errorf("failed to store feedbacks: %w", err)
+		}
+	}
+	return commitImport(importer)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/feedbackImport"
+)
+
+const importUsage = "import <file> --board <id> [--format csv|json|ndjson] [--delimiter d] [--header auto|true|false] [--email e]"
+
+func runImport(args []string) error {
+	// The file comes first, as in feedpulse import feedbacks.csv --board 3
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("missing file, usage: feedpulse %s", importUsage)
+	}
+	path := args[0]
+
+	flags := newFlagSet("import")
+	boardID := flags.Int("board", 0, "ID of the board receiving the feedbacks")
+	format := flags.String("format", "", "format of the file, csv, json or ndjson, guessed from its extension when omitted")
+	delimiter := flags.String("delimiter", "auto", "CSV delimiter: auto, comma, semicolon, tab or a single character")
+	header := flags.String("header", "auto", "whether the CSV file has a header row: auto, true or false")
+	email := flags.String("email", "", "email receiving the negative feedback alerts, the first board member by default")
+	flags.Parse(args[1:])
+	if err := requireBoard(*boardID); err != nil {
+		return err
+	}
+	if _, err := Board.GetBoardByID(*boardID); err != nil {
+		return err
+	}
+
+	if *format == "" {
+		*format = formatFromExtension(path)
+	}
+	opts := feedbackImport.CsvOptions{Header: strings.ToLower(*header)}
+	if opts.Header != "auto" && opts.Header != "true" && opts.Header != "false" {
+		return errors.New("--header must be one of auto, true or false")
+	}
+	var err error
+	if opts.Delimiter, err = feedbackImport.ParseDelimiter(*delimiter); err != nil {
+		return err
+	}
+	if *email == "" {
+		if *email, err = Board.GetBoardNotificationEmail(*boardID); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	importer, err := feedbackImport.Start(context.Background(), *boardID, filepath.Base(path), *format, *email, "")
+	if err != nil {
+		return err
+	}
+	storeErr, err := feedbackImport.Decode(file, *format, opts, importer)
+	if storeErr != nil {
+		importer.Abort(storeErr)
+		return fmt.Errorf("failed to store feedbacks: %w", storeErr)
+	}
+	if err != nil {
+		importer.Abort(err)
+		return fmt.Errorf("invalid %s file: %w", *format, err)
+	}
+	return commitImport(importer)
+}
+
+// commitImport commits the rows added to the importer and waits for their analysis, printing the outcome
+func commitImport(importer *feedbackImport.Importer) error {
+	job, err := importer.Commit()
+	if err != nil {
+		printJobErrors(job.Errors)
+		return err
+	}
+
+	fmt.Printf("Import job %d: %d rows, %d imported, %d duplicates skipped, analyzing...\n", job.Id, job.TotalRows, job.ImportedCount, job.SkippedCount)
+	job = importer.Wait()
+	fmt.Printf("Import job %d %s: %d analyzed, %d errors\n", job.Id, job.Status, job.AnalyzedCount, job.ErrorCount)
+	printJobErrors(job.Errors)
+	return nil
+}
+
+// formatFromExtension guesses the format of a feedback file, JSON by default
+func formatFromExtension(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv", ".tsv":
+		return feedbackImport.FormatCSV
+	case ".ndjson", ".jsonl":
+		return feedbackImport.FormatNDJSON
+	default:
+		return feedbackImport.FormatJSON
+	}
+}
+
+func printJobErrors(errs []string) {
+	for _, e := range errs {
+		fmt.Fprintln(os.Stderr, "  "+e)
+	}
+}
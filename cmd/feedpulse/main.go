@@ -0,0 +1,122 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/config"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/sentimentAnalysis"
+)
+
+// command is a subcommand of the CLI, run with the arguments following its name
+type command struct {
+	usage string
+	run   func(args []string) error
+	// analyzes tells whether the command needs the sentiment analysis
+	analyzes bool
+	// offline commands run without the database, they call connect themselves when they need it
+	offline bool
+}
+
+var commands = map[string]command{
+	"migrate":   {usage: migrateUsage, run: runMigrate},
+	"user":      {usage: userUsage, run: runUser},
+	"board":     {usage: boardUsage, run: runBoard},
+	"import":    {usage: importUsage, run: runImport, analyzes: true},
+	"reanalyze": {usage: reanalyzeUsage, run: runReanalyze, analyzes: true},
+	"export":    {usage: exportUsage, run: runExport},
+	"generate":  {usage: generateUsage, run: runGenerate, offline: true},
+}
+
+// feedpulse runs the operational tasks of Feed Pulse against the database configured for the API
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		if os.Args[1] != "help" && os.Args[1] != "-h" && os.Args[1] != "--help" {
+			fmt.Fprintf(os.Stderr, "unknown command %q\n\n", os.Args[1])
+		}
+		usage()
+		os.Exit(2)
+	}
+
+	if !cmd.offline {
+		connect(cmd.analyzes)
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		log.Fatalf("%s: %v", os.Args[1], err)
+	}
+}
+
+// connect opens the database and the cache, along with the sentiment analysis when analyzes is set.
+// The configuration is the one of the API, see cmd/app.
+func connect(analyzes bool) {
+	var required []string
+	if analyzes {
+		required = append(required, config.KeyMistralAPIKey)
+	}
+	cfg, err := config.Load(required...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = database.InitDatabase(cfg.Database.User, cfg.Database.Password, cfg.Database.Name, cfg.Database.Host, strconv.Itoa(cfg.Database.Port), cfg.Database.SSLMode)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	// The cache is optional here, the feedbacks changed by a command are evicted from it when it is configured
+	if cfg.RedisURL != "" {
+		if err := database.InitRedis(cfg.RedisURL); err != nil {
+			log.Fatalf("Failed to initialize redis: %v", err)
+		}
+	}
+	if analyzes {
+		sentimentAnalysis.InitSentimentAnalysis(cfg.MistralAPIKey, cfg.EmailPassword)
+	}
+}
+
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "Usage: feedpulse <command> [arguments]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s\n", commands[name].usage)
+	}
+	fmt.Fprintln(os.Stderr, "\nRun feedpulse <command> -h for the flags of a command.")
+}
+
+// subcommand returns the name of the subcommand and its arguments, failing with the usage when it is missing
+func subcommand(args []string, usage string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("missing subcommand, usage: feedpulse %s", usage)
+	}
+	return args[0], args[1:], nil
+}
+
+// newFlagSet returns a flag set exiting on -h and failing on invalid flags
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet("feedpulse "+name, flag.ExitOnError)
+}
+
+// requireBoard checks that the --board flag was given
+func requireBoard(boardID int) error {
+	if boardID <= 0 {
+		return fmt.Errorf("--board is required")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/migrations"
+)
+
+const migrateUsage = "migrate up | down [--steps n] | status"
+
+func runMigrate(args []string) error {
+	name, args, err := subcommand(args, migrateUsage)
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case "up":
+		newFlagSet("migrate up").Parse(args)
+		applied, err := migrations.Up(database.DB)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("Database is up to date")
+		}
+		for _, migration := range applied {
+			fmt.Printf("Applied migration %d_%s\n", migration.Version, migration.Name)
+		}
+	case "down":
+		flags := newFlagSet("migrate down")
+		steps := flags.Int("steps", 1, "number of migrations to revert")
+		flags.Parse(args)
+		reverted, err := migrations.Down(database.DB, *steps)
+		if err != nil {
+			return err
+		}
+		for _, migration := range reverted {
+			fmt.Printf("Reverted migration %d_%s\n", migration.Version, migration.Name)
+		}
+	case "status":
+		newFlagSet("migrate status").Parse(args)
+		statuses, err := migrations.GetStatus(database.DB)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.AppliedAt != nil {
+				state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, state)
+		}
+	default:
+		return fmt.Errorf("unknown subcommand %q, usage: feedpulse %s", name, migrateUsage)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
+	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/feedbackBulk"
+)
+
+const reanalyzeUsage = "reanalyze --board <id> [--email e]"
+
+func runReanalyze(args []string) error {
+	flags := newFlagSet("reanalyze")
+	boardID := flags.Int("board", 0, "ID of the board to reanalyze")
+	email := flags.String("email", "", "email receiving the negative feedback alerts, the first board member by default")
+	flags.Parse(args)
+	if err := requireBoard(*boardID); err != nil {
+		return err
+	}
+
+	var err error
+	if *email == "" {
+		if *email, err = Board.GetBoardNotificationEmail(*boardID); err != nil {
+			return err
+		}
+	}
+	ids, err := feedbackDB.FindFeedbackIDs(*boardID, feedbackDB.Filter{})
+	if err != nil {
+		return err
+	}
+
+	// Every chunk is analyzed in its own transaction, as the bulk reanalyze action does
+	analyzed := 0
+	for start := 0; start < len(ids); start += feedbackBulk.ChunkSize {
+		end := min(start+feedbackBulk.ChunkSize, len(ids))
+		n, err := feedbackDB.ReanalyzeFeedbacks(context.Background(), *boardID, ids[start:end], *email)
+		if err != nil {
+			return fmt.Errorf("failed after %d of %d feedbacks: %w", analyzed, len(ids), err)
+		}
+		analyzed += n
+		fmt.Printf("Reanalyzed %d/%d feedbacks\n", analyzed, len(ids))
+	}
+	if len(ids) == 0 {
+		fmt.Println("No feedback to reanalyze")
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	userDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/user"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/User"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const userUsage = "user create | reset-password | delete"
+
+func runUser(args []string) error {
+	name, args, err := subcommand(args, userUsage)
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case "create":
+		flags := newFlagSet("user create")
+		username := flags.String("username", "", "username of the user")
+		email := flags.String("email", "", "email of the user")
+		password := flags.String("password", "", "password of the user, read from stdin when omitted")
+		flags.Parse(args)
+		return createUser(*username, *email, *password)
+	case "reset-password":
+		flags := newFlagSet("user reset-password")
+		login := flags.String("user", "", "username or email of the user")
+		password := flags.String("password", "", "new password, read from stdin when omitted")
+		flags.Parse(args)
+		return resetPassword(*login, *password)
+	case "delete":
+		flags := newFlagSet("user delete")
+		login := flags.String("user", "", "username or email of the user")
+		flags.Parse(args)
+		return deleteUser(*login)
+	default:
+		return fmt.Errorf("unknown subcommand %q, usage: feedpulse %s", name, userUsage)
+	}
+}
+
+func createUser(username, email, password string) error {
+	if username == "" || email == "" {
+		return errors.New("--username and --email are required")
+	}
+	if !utils.IsValidEmail(email) {
+		return errors.New("invalid email format")
+	}
+	if existing, err := userDB.GetUserByUsername(username); err == nil && existing != nil {
+		return errors.New("username already exists")
+	}
+	if existing, err := userDB.GetUserByEmail(email); err == nil && existing != nil {
+		return errors.New("email already exists")
+	}
+	hashedPassword, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	// The personal board of the user is created along with it, as on registration
+	user := &User.User{
+		Username: username,
+		Email:    email,
+		Password: hashedPassword,
+	}
+	if err := userDB.CreateUser(user); err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+	fmt.Printf("Created user %d (%s)\n", user.Id, user.UUID)
+	return nil
+}
+
+func resetPassword(login, password string) error {
+	user, err := findUser(login)
+	if err != nil {
+		return err
+	}
+	hashedPassword, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	user.Password = hashedPassword
+	if err := userDB.UpdateUser(user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	fmt.Printf("Password of user %s reset\n", user.Username)
+	return nil
+}
+
+func deleteUser(login string) error {
+	user, err := findUser(login)
+	if err != nil {
+		return err
+	}
+	if err := userDB.DeleteUserWithMemberships(user.Id); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	fmt.Printf("Deleted user %s\n", user.Username)
+	return nil
+}
+
+// findUser returns the user with the given username or email
+func findUser(login string) (*User.User, error) {
+	if login == "" {
+		return nil, errors.New("--user is required")
+	}
+	user, err := userDB.GetUserEitherByEmailOrUsername(login)
+	if err != nil {
+		return nil, fmt.Errorf("user %q not found", login)
+	}
+	return user, nil
+}
+
+// hashPassword validates the password like the registration does and hashes it, reading it from stdin when empty
+func hashPassword(password string) (string, error) {
+	if password == "" {
+		fmt.Fprint(os.Stderr, "Password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("failed to read password: %w", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+	if len(password) < 8 {
+		return "", errors.New("password must be at least 8 characters long")
+	}
+	if len(password) > 50 {
+		return "", errors.New("password must be at most 50 characters long")
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hashed), nil
+}
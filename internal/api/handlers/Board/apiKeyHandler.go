@@ -0,0 +1,152 @@
+package Board
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	apiKeyDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/APIKey"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	apiKeyModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/APIKey"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/apiKey"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
+)
+
+// GetAPIKeysHandler godoc
+// @Summary List the board API keys
+// @Description List the API keys of the user's board, revoked ones included. The keys themselves are never returned.
+// @Tags Board
+// @Produce json
+// @Success 200 {array} APIKey.APIKey "List of API keys"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/board/api-keys [get]
+func GetAPIKeysHandler(c *fiber.Ctx) error {
+	boardID, err := getUserBoardID(c, "GetAPIKeysHandler")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	keys, err := apiKeyDB.GetAPIKeysByBoardID(boardID)
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to retrieve API keys for board %d: %v", boardID, err),
+			Level:   sentry.LevelError,
+			Tags: map[string]string{
+				"handler": "GetAPIKeysHandler",
+				"action":  "get_api_keys",
+			},
+		})
+		return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to retrieve API keys"))
+	}
+	return c.Status(fiber.StatusOK).JSON(keys)
+}
+
+// CreateAPIKeyHandler godoc
+// @Summary Create a board API key
+// @Description Create an API key for the user's board. The key is only returned in this response.
+// @Description Available scopes are feedback:read, feedback:write and metrics:read.
+// @Tags Board
+// @Accept json
+// @Produce json
+// @Param key body APIKey.APIKeyJson true "API key name, scopes and optional expiry"
+// @Success 201 {object} APIKey.CreatedAPIKey "Created API key"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/board/api-keys [post]
+func CreateAPIKeyHandler(c *fiber.Ctx) error {
+	boardID, err := getUserBoardID(c, "CreateAPIKeyHandler")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	var body apiKeyModel.APIKeyJson
+	if err := c.BodyParser(&body); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid request payload"))
+	}
+	body.Name = strings.TrimSpace(body.Name)
+	if body.Name == "" {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("name is required"))
+	}
+	if err := apiKey.ValidateScopes(body.Scopes); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+	}
+	if body.ExpiresAt != nil && !body.ExpiresAt.After(time.Now()) {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("expires_at must be in the future"))
+	}
+
+	key, prefix, hash, err := apiKey.Generate()
+	if err != nil {
+		return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to generate API key"))
+	}
+
+	created, err := apiKeyDB.CreateAPIKey(apiKeyModel.APIKey{
+		Name:      body.Name,
+		Prefix:    prefix,
+		Hash:      hash,
+		Scopes:    body.Scopes,
+		ExpiresAt: body.ExpiresAt,
+		BoardID:   boardID,
+	})
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to create API key for board %d: %v", boardID, err),
+			Level:   sentry.LevelError,
+			Tags: map[string]string{
+				"handler": "CreateAPIKeyHandler",
+				"action":  "create_api_key",
+			},
+		})
+		return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to create API key"))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(apiKeyModel.CreatedAPIKey{
+		APIKey: created,
+		Key:    key,
+	})
+}
+
+// RevokeAPIKeyHandler godoc
+// @Summary Revoke a board API key
+// @Description Revoke an API key of the user's board, requests using it are rejected immediately
+// @Tags Board
+// @Produce json
+// @Param id path int true "API key ID"
+// @Success 200 {object} httpUtils.HTTPMessage "API key revoked"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "API key not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/board/api-keys/{id} [delete]
+func RevokeAPIKeyHandler(c *fiber.Ctx) error {
+	boardID, err := getUserBoardID(c, "RevokeAPIKeyHandler")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid API key id"))
+	}
+
+	if err := apiKeyDB.RevokeAPIKey(boardID, id); err != nil {
+		if errors.Is(err, apiKeyDB.ErrAPIKeyNotFound) {
+			return httpUtils.NewError(c, fiber.StatusNotFound, err)
+		}
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to revoke API key %d of board %d: %v", id, boardID, err),
+			Level:   sentry.LevelError,
+			Tags: map[string]string{
+				"handler": "RevokeAPIKeyHandler",
+				"action":  "revoke_api_key",
+			},
+		})
+		return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to revoke API key"))
+	}
+	return httpUtils.NewMessage(c, fiber.StatusOK, "API key revoked")
+}
@@ -0,0 +1,122 @@
+package Board
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	apiKeyModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/APIKey"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/apiKey"
+)
+
+func expectUserBoard(mock sqlmock.Sqlmock, userUUID string, boardID int) {
+	mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards (.+) WHERE users.uuid = \$1 AND "boards"."deleted_at" IS NULL ORDER BY boards.id ASC LIMIT \$2`).
+		WithArgs(userUUID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(boardID, "Board"))
+}
+
+func newAPIKeyApp(method, path string, handler fiber.Handler) *fiber.App {
+	app := fiber.New()
+	app.Add(method, path, func(c *fiber.Ctx) error {
+		c.Locals("userUUID", "test-user-uuid")
+		return handler(c)
+	})
+	return app
+}
+
+func TestCreateAPIKeyHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mock := setupTest(t)
+		expectUserBoard(mock, "test-user-uuid", 4)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO "api_keys"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+		mock.ExpectCommit()
+
+		app := newAPIKeyApp("POST", "/api/board/api-keys", CreateAPIKeyHandler)
+		req := httptest.NewRequest("POST", "/api/board/api-keys", strings.NewReader(`{"name":"ci","scopes":["feedback:write"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		body, _ := io.ReadAll(resp.Body)
+		var created apiKeyModel.CreatedAPIKey
+		assert.NoError(t, json.Unmarshal(body, &created))
+		assert.Equal(t, 12, created.Id)
+		assert.Equal(t, 4, created.BoardID)
+		assert.True(t, strings.HasPrefix(created.Key, created.Prefix+"_"))
+		assert.True(t, apiKey.Matches(created.Key, apiKey.Hash(created.Key)))
+		assert.NotContains(t, string(body), `"hash"`)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	tests := []struct {
+		name     string
+		body     string
+		contains string
+	}{
+		{"Missing name", `{"scopes":["feedback:write"]}`, "name is required"},
+		{"Unknown scope", `{"name":"ci","scopes":["admin"]}`, "unknown scope"},
+		{"Expired", `{"name":"ci","scopes":["metrics:read"],"expires_at":"2020-01-01T00:00:00Z"}`, "must be in the future"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := setupTest(t)
+			expectUserBoard(mock, "test-user-uuid", 4)
+
+			app := newAPIKeyApp("POST", "/api/board/api-keys", CreateAPIKeyHandler)
+			req := httptest.NewRequest("POST", "/api/board/api-keys", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+			body, _ := io.ReadAll(resp.Body)
+			assert.Contains(t, string(body), tt.contains)
+		})
+	}
+}
+
+func TestRevokeAPIKeyHandler_NotFound(t *testing.T) {
+	mock := setupTest(t)
+	expectUserBoard(mock, "test-user-uuid", 4)
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "api_keys" SET (.+)`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	app := newAPIKeyApp("DELETE", "/api/board/api-keys/:id", RevokeAPIKeyHandler)
+	resp, err := app.Test(httptest.NewRequest("DELETE", "/api/board/api-keys/3", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestBoardMetricsHandler_APIKeyBoard(t *testing.T) {
+	mock := setupTest(t)
+
+	// The board comes from the API key, no user lookup is made
+	mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL`).
+		WithArgs(5, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(5, "Board"))
+	mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL`).
+		WithArgs(5, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(5, "Board"))
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE "feedbacks"."board_id" = \$1 AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	app := fiber.New()
+	app.Get("/api/board/metrics", func(c *fiber.Ctx) error {
+		c.Locals("boardID", 5)
+		return BoardMetricsHandler(c)
+	})
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/board/metrics", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
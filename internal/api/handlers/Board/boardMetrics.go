@@ -9,6 +9,8 @@ import (
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
 	boardModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Board"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	metric "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Metric"
 	calculmetric "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/calculMetric"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
 	"gorm.io/gorm"
@@ -25,65 +27,70 @@ type ErrorResponse struct {
 // @Tags Board
 // @Accept json
 // @Produce json
+// @Param metadata.{key} query string false "Only count the feedbacks having this metadata value, e.g. metadata.plan=pro"
+// @Param group_by query string false "Metadata key to compute the metrics of each of its values, feedbacks without it are grouped under (none)"
 // @Success 200 {object} metric.Metric "Metrics data"
 // @Failure 400 {object} ErrorResponse "Bad request error"
 // @Failure 401 {object} ErrorResponse "Unauthorized error"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /api/board/metrics [get]
 func BoardMetricsHandler(c *fiber.Ctx) error {
-	// Get board ID from query parameter - default to 1 if not provided
+	// API keys carry their board, user sessions use the first board of the user
+	boardID, fromAPIKey := middleware.GetBoardID(c)
 	userUUID, ok := middleware.GetUserUUID(c)
-	if !ok {
-		sentry.CaptureEvent(&sentry.Event{
-			Message: "Unauthorized access: user UUID not found in context",
-			Level:   sentry.LevelError,
-			Tags: map[string]string{
-				"handler": "BoardMetricsHandler",
-				"action":  "get_board_metrics",
-			},
-		})
-		return httpUtils.NewError(c, fiber.StatusUnauthorized, errors.New("unauthorized: user not found in context"))
-	}
+	if !fromAPIKey {
+		if !ok {
+			middleware.CaptureEvent(c, &sentry.Event{
+				Message: "Unauthorized access: user UUID not found in context",
+				Level:   sentry.LevelError,
+				Tags: map[string]string{
+					"handler": "BoardMetricsHandler",
+					"action":  "get_board_metrics",
+				},
+			})
+			return httpUtils.NewError(c, fiber.StatusUnauthorized, errors.New("unauthorized: user not found in context"))
+		}
 
-	// Get user information from database
-	userBoard, err := Board.GetBoardsByUserUUID(userUUID)
-	if err != nil {
-		sentry.CaptureEvent(&sentry.Event{
-			Message: fmt.Sprintf("Failed to retrieve boards for user %s: %v", userUUID, err),
-			Level:   sentry.LevelError,
-			User: sentry.User{
-				ID: userUUID,
-			},
-			Tags: map[string]string{
-				"handler": "BoardMetricsHandler",
-				"action":  "get_board_metrics",
-			},
-		})
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid board",
-		})
-	}
-	if len(userBoard) == 0 {
-		sentry.CaptureEvent(&sentry.Event{
-			Message: fmt.Sprintf("No boards found for user %s", userUUID),
-			Level:   sentry.LevelWarning,
-			User: sentry.User{
-				ID: userUUID,
-			},
-			Tags: map[string]string{
-				"handler": "BoardMetricsHandler",
-				"action":  "get_board_metrics",
-			},
-		})
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "No boards found for this user",
-		})
+		// The oldest board of the user, the same one as the other routes
+		userBoard, err := Board.GetFirstBoardByUserUUID(userUUID)
+		if errors.Is(err, Board.ErrNoUserBoard) {
+			middleware.CaptureEvent(c, &sentry.Event{
+				Message: fmt.Sprintf("No boards found for user %s", userUUID),
+				Level:   sentry.LevelWarning,
+				User: sentry.User{
+					ID: userUUID,
+				},
+				Tags: map[string]string{
+					"handler": "BoardMetricsHandler",
+					"action":  "get_board_metrics",
+				},
+			})
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "No boards found for this user",
+			})
+		}
+		if err != nil {
+			middleware.CaptureEvent(c, &sentry.Event{
+				Message: fmt.Sprintf("Failed to retrieve boards for user %s: %v", userUUID, err),
+				Level:   sentry.LevelError,
+				User: sentry.User{
+					ID: userUUID,
+				},
+				Tags: map[string]string{
+					"handler": "BoardMetricsHandler",
+					"action":  "get_board_metrics",
+				},
+			})
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid board",
+			})
+		}
+		boardID = userBoard.Id
 	}
-	boardID := userBoard[0].Id
 
 	// Check if the board exists in the database before proceeding
 	if err := validateBoardExists(boardID); err != nil {
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: fmt.Sprintf("Board validation failed for ID %d: %v", boardID, err),
 			Level:   sentry.LevelError,
 			Extra: map[string]interface{}{
@@ -104,7 +111,7 @@ func BoardMetricsHandler(c *fiber.Ctx) error {
 
 	board, err := Board.GetBoardsWithFeedbacks(boardID)
 	if err != nil {
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: fmt.Sprintf("Failed to retrieve board feedbacks for board ID %d: %v", boardID, err),
 			Level:   sentry.LevelError,
 			Extra: map[string]interface{}{
@@ -123,9 +130,19 @@ func BoardMetricsHandler(c *fiber.Ctx) error {
 		})
 	}
 
-	metrics, err := calculmetric.CalculMetric(board.Feedbacks)
-	if err != nil {
-		sentry.CaptureEvent(&sentry.Event{
+	// Keep the feedbacks matching the metadata filters
+	feedbacks := board.Feedbacks
+	if filters := feedbackModel.ParseMetadataFilters(c.Queries()); len(filters) > 0 {
+		feedbacks = make([]feedbackModel.Feedback, 0, len(board.Feedbacks))
+		for _, feedback := range board.Feedbacks {
+			if feedback.Metadata.Matches(filters) {
+				feedbacks = append(feedbacks, feedback)
+			}
+		}
+	}
+
+	metricsError := func(err error) error {
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: fmt.Sprintf("Failed to calculate metrics for board ID %d: %v", boardID, err),
 			Level:   sentry.LevelError,
 			Extra: map[string]interface{}{
@@ -143,6 +160,32 @@ func BoardMetricsHandler(c *fiber.Ctx) error {
 			"error": "Failed to calculate metrics: " + err.Error(),
 		})
 	}
+
+	// Compute the metrics of each value of a metadata key
+	if groupBy := c.Query("group_by"); groupBy != "" {
+		grouped := make(map[string][]feedbackModel.Feedback)
+		for _, feedback := range feedbacks {
+			group := feedback.Metadata.Group(groupBy)
+			grouped[group] = append(grouped[group], feedback)
+		}
+		groups := make(map[string]metric.Metric, len(grouped))
+		for group, groupFeedbacks := range grouped {
+			metrics, err := calculmetric.CalculMetric(groupFeedbacks)
+			if err != nil {
+				return metricsError(err)
+			}
+			groups[group] = metrics
+		}
+		return c.JSON(fiber.Map{
+			"group_by": groupBy,
+			"groups":   groups,
+		})
+	}
+
+	metrics, err := calculmetric.CalculMetric(feedbacks)
+	if err != nil {
+		return metricsError(err)
+	}
 	return c.JSON(metrics)
 }
 
@@ -47,12 +47,12 @@ func TestBoardMetricsHandler(t *testing.T) {
 		// Setup expectations for GetBoardsByUserUUID
 		boardRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name"}).
 			AddRow(1, testDate, testDate, "Test Board")
-		mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id JOIN users ON user_boards.user_id = users.id WHERE users.uuid = \$1`).
-			WithArgs("test-user-uuid").
+		mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id JOIN users ON user_boards.user_id = users.id WHERE users.uuid = \$1 AND "boards"."deleted_at" IS NULL ORDER BY boards.id ASC LIMIT \$2`).
+			WithArgs("test-user-uuid", 1).
 			WillReturnRows(boardRows)
 
 		// Setup expectations for validateBoardExists
-		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name"}).
 				AddRow(1, testDate, testDate, "Test Board"))
@@ -60,25 +60,32 @@ func TestBoardMetricsHandler(t *testing.T) {
 		// First query: Get the board
 		boardQueryRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name"}).
 			AddRow(1, testDate, testDate, "Test Board")
-		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(boardQueryRows) // Second query: Preload feedbacks
 		feedbackQueryRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "date", "channel", "text", "board_id", "sentiment", "score"}).
 			AddRow(1, testDate, testDate, testDate, "test", "Great feedback", 1, "positive", 0.95)
-		mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE "feedbacks"."board_id" = \$1`).
+		mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE "feedbacks"."board_id" = \$1 AND "feedbacks"."deleted_at" IS NULL`).
 			WithArgs(1).
-			WillReturnRows(feedbackQueryRows) // Third query: GetAnalysisByFeedbackID for metric calculation (called multiple times)
+			WillReturnRows(feedbackQueryRows)
+		// Preload of the feedback tags
+		mock.ExpectQuery(`SELECT \* FROM "feedback_tags" WHERE "feedback_tags"."feedback_id" = \$1`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"feedback_id", "tag_id"}).AddRow(1, 7))
+		mock.ExpectQuery(`SELECT \* FROM "tags" WHERE "tags"."id" = \$1`).
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "name", "color"}).AddRow(7, 1, "bug", "#ff0000")) // Third query: GetAnalysisByFeedbackID for metric calculation (called multiple times)
 		analysisQueryRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic"}).
 			AddRow(1, testDate, testDate, 1, "user experience")
 		// The analysis query is called multiple times during metric calculation
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(analysisQueryRows)
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic"}).
 				AddRow(1, testDate, testDate, 1, "user experience"))
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic"}).
 				AddRow(1, testDate, testDate, 1, "user experience"))
@@ -110,6 +117,7 @@ func TestBoardMetricsHandler(t *testing.T) {
 		body, err := io.ReadAll(resp.Body)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, body)
+		assert.Contains(t, string(body), `"distributionByTag":{"bug":100}`)
 
 		// Verify that all mock expectations were met
 		err = mock.ExpectationsWereMet()
@@ -139,8 +147,8 @@ func TestBoardMetricsHandler(t *testing.T) {
 		mock := setupTest(t)
 
 		// Setup expectation for GetBoardsByUserUUID to return an error
-		mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id JOIN users ON user_boards.user_id = users.id WHERE users.uuid = \$1`).
-			WithArgs("test-user-uuid").
+		mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id JOIN users ON user_boards.user_id = users.id WHERE users.uuid = \$1 AND "boards"."deleted_at" IS NULL ORDER BY boards.id ASC LIMIT \$2`).
+			WithArgs("test-user-uuid", 1).
 			WillReturnError(errors.New("database connection failed"))
 
 		// Create test app and handler
@@ -176,8 +184,8 @@ func TestBoardMetricsHandler(t *testing.T) {
 
 		// Setup expectation for GetBoardsByUserUUID to return empty result
 		emptyRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name"})
-		mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id JOIN users ON user_boards.user_id = users.id WHERE users.uuid = \$1`).
-			WithArgs("test-user-uuid").
+		mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id JOIN users ON user_boards.user_id = users.id WHERE users.uuid = \$1 AND "boards"."deleted_at" IS NULL ORDER BY boards.id ASC LIMIT \$2`).
+			WithArgs("test-user-uuid", 1).
 			WillReturnRows(emptyRows)
 
 		// Create test app and handler
@@ -215,12 +223,12 @@ func TestBoardMetricsHandler(t *testing.T) {
 		// Setup expectations for GetBoardsByUserUUID
 		boardRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name"}).
 			AddRow(1, testDate, testDate, "Test Board")
-		mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id JOIN users ON user_boards.user_id = users.id WHERE users.uuid = \$1`).
-			WithArgs("test-user-uuid").
+		mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id JOIN users ON user_boards.user_id = users.id WHERE users.uuid = \$1 AND "boards"."deleted_at" IS NULL ORDER BY boards.id ASC LIMIT \$2`).
+			WithArgs("test-user-uuid", 1).
 			WillReturnRows(boardRows)
 
 		// Setup expectations for validateBoardExists to fail
-		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnError(gorm.ErrRecordNotFound)
 
@@ -259,18 +267,18 @@ func TestBoardMetricsHandler(t *testing.T) {
 		// Setup expectations for GetBoardsByUserUUID
 		boardRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name"}).
 			AddRow(1, testDate, testDate, "Test Board")
-		mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id JOIN users ON user_boards.user_id = users.id WHERE users.uuid = \$1`).
-			WithArgs("test-user-uuid").
+		mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id JOIN users ON user_boards.user_id = users.id WHERE users.uuid = \$1 AND "boards"."deleted_at" IS NULL ORDER BY boards.id ASC LIMIT \$2`).
+			WithArgs("test-user-uuid", 1).
 			WillReturnRows(boardRows)
 
 		// Setup expectations for validateBoardExists
-		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name"}).
 				AddRow(1, testDate, testDate, "Test Board"))
 
 		// Setup expectations for GetBoardsWithFeedbacks to fail
-		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnError(errors.New("database connection failed"))
 
@@ -309,12 +317,12 @@ func TestBoardMetricsHandler(t *testing.T) {
 		// Setup expectations for GetBoardsByUserUUID
 		boardRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name"}).
 			AddRow(1, testDate, testDate, "Test Board")
-		mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id JOIN users ON user_boards.user_id = users.id WHERE users.uuid = \$1`).
-			WithArgs("test-user-uuid").
+		mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id JOIN users ON user_boards.user_id = users.id WHERE users.uuid = \$1 AND "boards"."deleted_at" IS NULL ORDER BY boards.id ASC LIMIT \$2`).
+			WithArgs("test-user-uuid", 1).
 			WillReturnRows(boardRows)
 
 		// Setup expectations for validateBoardExists
-		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name"}).
 				AddRow(1, testDate, testDate, "Test Board"))
@@ -322,18 +330,21 @@ func TestBoardMetricsHandler(t *testing.T) {
 		// Setup expectations for GetBoardsWithFeedbacks
 		boardQueryRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name"}).
 			AddRow(1, testDate, testDate, "Test Board")
-		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(boardQueryRows)
 
 		feedbackQueryRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "date", "channel", "text", "board_id", "sentiment", "score"}).
 			AddRow(1, testDate, testDate, testDate, "test", "Great feedback", 1, "positive", 0.95)
-		mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE "feedbacks"."board_id" = \$1`).
+		mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE "feedbacks"."board_id" = \$1 AND "feedbacks"."deleted_at" IS NULL`).
 			WithArgs(1).
 			WillReturnRows(feedbackQueryRows)
+		mock.ExpectQuery(`SELECT \* FROM "feedback_tags" WHERE "feedback_tags"."feedback_id" = \$1`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"feedback_id", "tag_id"}))
 
 		// Setup expectations for GetAnalysisByFeedbackID to fail (causing CalculMetric to fail)
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnError(errors.New("analysis query failed"))
 
@@ -372,7 +383,7 @@ func TestValidateBoardExists(t *testing.T) {
 		testDate := time.Now()
 
 		// Setup expectations with the correct GORM query pattern
-		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 			WithArgs(1, 1). // GORM adds LIMIT 1 for First() queries
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name"}).
 				AddRow(1, testDate, testDate, "Test Board"))
@@ -385,7 +396,7 @@ func TestValidateBoardExists(t *testing.T) {
 		mock := setupTest(t)
 
 		// Setup expectations with the correct GORM query pattern
-		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 			WithArgs(999, 1). // GORM adds LIMIT 1 for First() queries
 			WillReturnError(gorm.ErrRecordNotFound)
 
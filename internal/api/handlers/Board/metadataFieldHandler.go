@@ -0,0 +1,129 @@
+package Board
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	boardModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Board"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
+)
+
+// GetMetadataFieldsHandler godoc
+// @Summary List the board metadata fields
+// @Description List the custom metadata fields declared on the user's board
+// @Tags Board
+// @Produce json
+// @Success 200 {array} Board.MetadataField "List of metadata fields"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/board/metadata-fields [get]
+func GetMetadataFieldsHandler(c *fiber.Ctx) error {
+	boardID, err := getUserBoardID(c, "GetMetadataFieldsHandler")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	fields, err := Board.GetMetadataSchema(boardID)
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to retrieve metadata fields for board %d: %v", boardID, err),
+			Level:   sentry.LevelError,
+			Tags: map[string]string{
+				"handler": "GetMetadataFieldsHandler",
+				"action":  "get_metadata_fields",
+			},
+		})
+		return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to retrieve metadata fields"))
+	}
+	return c.Status(fiber.StatusOK).JSON(fields)
+}
+
+// SaveMetadataFieldHandler godoc
+// @Summary Declare a board metadata field
+// @Description Declare a custom metadata field on the user's board, or replace the declaration of an existing key.
+// @Description Available types are string, number, boolean and date. Ingested feedbacks are checked against the declared fields.
+// @Tags Board
+// @Accept json
+// @Produce json
+// @Param field body Board.MetadataFieldJson true "Field key, type and optional allowed values"
+// @Success 200 {object} Board.MetadataField "Saved metadata field"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/board/metadata-fields [put]
+func SaveMetadataFieldHandler(c *fiber.Ctx) error {
+	boardID, err := getUserBoardID(c, "SaveMetadataFieldHandler")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	var body boardModel.MetadataFieldJson
+	if err := c.BodyParser(&body); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid request payload"))
+	}
+	body.Key = strings.TrimSpace(body.Key)
+	if err := body.Validate(); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+	}
+
+	field, err := Board.SaveMetadataField(boardModel.MetadataField{
+		BoardID:       boardID,
+		Key:           body.Key,
+		Type:          body.Type,
+		AllowedValues: body.AllowedValues,
+	})
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to save metadata field %q for board %d: %v", body.Key, boardID, err),
+			Level:   sentry.LevelError,
+			Tags: map[string]string{
+				"handler": "SaveMetadataFieldHandler",
+				"action":  "save_metadata_field",
+			},
+		})
+		return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to save metadata field"))
+	}
+	return c.Status(fiber.StatusOK).JSON(field)
+}
+
+// DeleteMetadataFieldHandler godoc
+// @Summary Remove a board metadata field
+// @Description Remove the declaration of a metadata field, the values already stored on feedbacks are kept
+// @Tags Board
+// @Produce json
+// @Param key path string true "Field key"
+// @Success 200 {object} httpUtils.HTTPMessage "Metadata field removed"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Metadata field not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/board/metadata-fields/{key} [delete]
+func DeleteMetadataFieldHandler(c *fiber.Ctx) error {
+	boardID, err := getUserBoardID(c, "DeleteMetadataFieldHandler")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	key := c.Params("key")
+	if err := Board.DeleteMetadataField(boardID, key); err != nil {
+		if errors.Is(err, Board.ErrMetadataFieldNotFound) {
+			return httpUtils.NewError(c, fiber.StatusNotFound, err)
+		}
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to delete metadata field %q of board %d: %v", key, boardID, err),
+			Level:   sentry.LevelError,
+			Tags: map[string]string{
+				"handler": "DeleteMetadataFieldHandler",
+				"action":  "delete_metadata_field",
+			},
+		})
+		return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to delete metadata field"))
+	}
+	return httpUtils.NewMessage(c, fiber.StatusOK, "Metadata field removed")
+}
@@ -0,0 +1,227 @@
+package Board
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
+	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	userDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/user"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	boardModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Board"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/sourceSync"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/trashPurge"
+)
+
+// TrashedBoard is a board in the trash with the time it gets purged
+type TrashedBoard struct {
+	boardModel.Board
+	PurgeAt time.Time `json:"purge_at"`
+}
+
+// TrashedFeedback is a feedback in the trash with the time it gets purged
+type TrashedFeedback struct {
+	feedbackModel.Feedback
+	PurgeAt time.Time `json:"purge_at"`
+}
+
+// Trash lists the deleted boards of the user and the deleted feedbacks of the user's board
+type Trash struct {
+	Boards        []TrashedBoard    `json:"boards"`
+	Feedbacks     []TrashedFeedback `json:"feedbacks"`
+	RetentionDays int               `json:"retention_days"`
+}
+
+// DeleteBoardHandler godoc
+// @Summary Delete a board
+// @Description Put a board of the user in the trash along with its feedbacks. It can be restored until it is purged after the retention period.
+// @Tags Board
+// @Produce json
+// @Param id path int true "Board ID"
+// @Success 200 {object} httpUtils.HTTPMessage "Board moved to the trash"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Board not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/board/{id} [delete]
+func DeleteBoardHandler(c *fiber.Ctx) error {
+	userID, err := getUserID(c, "DeleteBoardHandler")
+	if err != nil {
+		return fiberError(c, err)
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid board id"))
+	}
+
+	boards, err := Board.GetBoardsByUserID(userID)
+	if err != nil {
+		return trashError(c, "DeleteBoardHandler", "delete_board", err)
+	}
+	member := false
+	for _, board := range boards {
+		member = member || board.Id == id
+	}
+	if !member {
+		return httpUtils.NewError(c, fiber.StatusNotFound, errors.New("board not found"))
+	}
+
+	if err := Board.DeleteBoard(id); err != nil {
+		return trashError(c, "DeleteBoardHandler", "delete_board", err)
+	}
+	// The sources of a board in the trash are not synced anymore
+	sourceSync.ReloadScheduler()
+	return httpUtils.NewMessage(c, fiber.StatusOK, "Board moved to the trash")
+}
+
+// GetTrashHandler godoc
+// @Summary List the trash
+// @Description List the deleted boards of the user and the deleted feedbacks of the user's board, with the time each one gets purged
+// @Tags Board
+// @Produce json
+// @Success 200 {object} Trash "Boards and feedbacks in the trash"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/trash [get]
+func GetTrashHandler(c *fiber.Ctx) error {
+	userID, err := getUserID(c, "GetTrashHandler")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	trash := Trash{
+		Boards:        make([]TrashedBoard, 0),
+		Feedbacks:     make([]TrashedFeedback, 0),
+		RetentionDays: int(trashPurge.Retention / (24 * time.Hour)),
+	}
+	boards, err := Board.GetDeletedBoardsByUserID(userID)
+	if err != nil {
+		return trashError(c, "GetTrashHandler", "get_trash", err)
+	}
+	for _, board := range boards {
+		trash.Boards = append(trash.Boards, TrashedBoard{Board: board, PurgeAt: trashPurge.PurgeAt(board.DeletedAt.Time)})
+	}
+
+	// A user whose boards are all in the trash has no deleted feedbacks to list
+	userUUID, _ := middleware.GetUserUUID(c)
+	liveBoard, err := Board.GetFirstBoardByUserUUID(userUUID)
+	if err != nil && !errors.Is(err, Board.ErrNoUserBoard) {
+		return trashError(c, "GetTrashHandler", "get_trash", err)
+	}
+	if err == nil {
+		feedbacks, err := feedbackDB.GetDeletedFeedbacks(liveBoard.Id)
+		if err != nil {
+			return trashError(c, "GetTrashHandler", "get_trash", err)
+		}
+		for _, feedback := range feedbacks {
+			trash.Feedbacks = append(trash.Feedbacks, TrashedFeedback{Feedback: feedback, PurgeAt: trashPurge.PurgeAt(feedback.DeletedAt.Time)})
+		}
+	}
+	return c.Status(fiber.StatusOK).JSON(trash)
+}
+
+// RestoreBoardHandler godoc
+// @Summary Restore a board
+// @Description Take a board of the user out of the trash along with the feedbacks deleted with it
+// @Tags Board
+// @Produce json
+// @Param id path int true "Board ID"
+// @Success 200 {object} Board.Board "Restored board"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Board not in the trash"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/trash/boards/{id}/restore [post]
+func RestoreBoardHandler(c *fiber.Ctx) error {
+	userID, err := getUserID(c, "RestoreBoardHandler")
+	if err != nil {
+		return fiberError(c, err)
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid board id"))
+	}
+
+	board, err := Board.RestoreBoard(userID, id)
+	if err != nil {
+		return trashError(c, "RestoreBoardHandler", "restore_board", err)
+	}
+	sourceSync.ReloadScheduler()
+	return c.Status(fiber.StatusOK).JSON(board)
+}
+
+// RestoreFeedbackHandler godoc
+// @Summary Restore a feedback
+// @Description Take a feedback of the user's board out of the trash along with its analysis
+// @Tags Board
+// @Produce json
+// @Param id path int true "Feedback ID"
+// @Success 200 {object} Feedback.Feedback "Restored feedback"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Feedback not in the trash"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/trash/feedbacks/{id}/restore [post]
+func RestoreFeedbackHandler(c *fiber.Ctx) error {
+	boardID, err := getUserBoardID(c, "RestoreFeedbackHandler")
+	if err != nil {
+		return fiberError(c, err)
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid feedback id"))
+	}
+
+	feedback, err := feedbackDB.RestoreFeedback(boardID, id)
+	if err != nil {
+		return trashError(c, "RestoreFeedbackHandler", "restore_feedback", err)
+	}
+	return c.Status(fiber.StatusOK).JSON(feedback)
+}
+
+// getUserID returns the ID of the authenticated user,
+// failures are returned as *fiber.Error carrying the response status
+func getUserID(c *fiber.Ctx, handler string) (int, error) {
+	userUUID, ok := middleware.GetUserUUID(c)
+	if !ok {
+		return 0, fiber.NewError(fiber.StatusUnauthorized, "unauthorized: user not found in context")
+	}
+	user, err := userDB.GetUserByUUID(userUUID)
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to retrieve user %s: %v", userUUID, err),
+			Level:   sentry.LevelError,
+			User: sentry.User{
+				ID: userUUID,
+			},
+			Tags: map[string]string{
+				"handler": handler,
+				"action":  "get_user",
+			},
+		})
+		return 0, fiber.NewError(fiber.StatusInternalServerError, "failed to retrieve user")
+	}
+	return user.Id, nil
+}
+
+// trashError writes the response for a failed trash request, database errors are captured in Sentry
+func trashError(c *fiber.Ctx, handler, action string, err error) error {
+	if errors.Is(err, Board.ErrBoardNotInTrash) || errors.Is(err, feedbackDB.ErrFeedbackNotInTrash) {
+		return httpUtils.NewError(c, fiber.StatusNotFound, err)
+	}
+	middleware.CaptureEvent(c, &sentry.Event{
+		Message: fmt.Sprintf("Failed to %s: %v", strings.ReplaceAll(action, "_", " "), err),
+		Level:   sentry.LevelError,
+		Tags: map[string]string{
+			"handler": handler,
+			"action":  action,
+		},
+	})
+	return httpUtils.NewError(c, fiber.StatusInternalServerError, fmt.Errorf("failed to %s", strings.ReplaceAll(action, "_", " ")))
+}
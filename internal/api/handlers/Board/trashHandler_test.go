@@ -0,0 +1,55 @@
+package Board
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestoreFeedbackHandler(t *testing.T) {
+	t.Run("Invalid id", func(t *testing.T) {
+		mock := setupTest(t)
+		userUUID := "test-user-uuid"
+
+		mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards (.+) WHERE users.uuid = \$1 AND "boards"."deleted_at" IS NULL ORDER BY boards.id ASC LIMIT \$2`).
+			WithArgs(userUUID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Board"))
+
+		app := fiber.New()
+		app.Post("/api/trash/feedbacks/:id/restore", func(c *fiber.Ctx) error {
+			c.Locals("userUUID", userUUID)
+			return RestoreFeedbackHandler(c)
+		})
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/api/trash/feedbacks/abc/restore", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not in the trash", func(t *testing.T) {
+		mock := setupTest(t)
+		userUUID := "test-user-uuid"
+
+		mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards (.+) WHERE users.uuid = \$1 AND "boards"."deleted_at" IS NULL ORDER BY boards.id ASC LIMIT \$2`).
+			WithArgs(userUUID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Board"))
+		mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE id = \$1 AND board_id = \$2 AND deleted_at IS NOT NULL`).
+			WithArgs(7, 1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		app := fiber.New()
+		app.Post("/api/trash/feedbacks/:id/restore", func(c *fiber.Ctx) error {
+			c.Locals("userUUID", userUUID)
+			return RestoreFeedbackHandler(c)
+		})
+
+		resp, err := app.Test(httptest.NewRequest("POST", "/api/trash/feedbacks/7/restore", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,49 @@
+package Board
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
+)
+
+// getUserBoardID returns the first board of the authenticated user,
+// failures are returned as *fiber.Error carrying the response status
+func getUserBoardID(c *fiber.Ctx, handler string) (int, error) {
+	userUUID, ok := middleware.GetUserUUID(c)
+	if !ok {
+		return 0, fiber.NewError(fiber.StatusUnauthorized, "unauthorized: user not found in context")
+	}
+	board, err := Board.GetFirstBoardByUserUUID(userUUID)
+	if errors.Is(err, Board.ErrNoUserBoard) {
+		return 0, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to retrieve boards for user %s: %v", userUUID, err),
+			Level:   sentry.LevelError,
+			User: sentry.User{
+				ID: userUUID,
+			},
+			Tags: map[string]string{
+				"handler": handler,
+				"action":  "get_user_board",
+			},
+		})
+		return 0, fiber.NewError(fiber.StatusInternalServerError, "failed to retrieve boards for user")
+	}
+	return board.Id, nil
+}
+
+// fiberError writes a *fiber.Error as an HTTPError response
+func fiberError(c *fiber.Ctx, err error) error {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return httpUtils.NewError(c, fiberErr.Code, errors.New(fiberErr.Message))
+	}
+	return httpUtils.NewError(c, fiber.StatusInternalServerError, err)
+}
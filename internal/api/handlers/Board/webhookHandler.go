@@ -0,0 +1,99 @@
+package Board
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	boardModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Board"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/webhookSignature"
+)
+
+// WebhookCredentials is returned to the board members to configure the inbound webhook
+type WebhookCredentials struct {
+	URL             string `json:"url"`
+	Token           string `json:"token"`
+	Secret          string `json:"secret"`
+	SignatureHeader string `json:"signature_header"`
+	TimestampHeader string `json:"timestamp_header"`
+}
+
+func newWebhookCredentials(c *fiber.Ctx, board boardModel.Board) WebhookCredentials {
+	token := ""
+	if board.WebhookToken != nil {
+		token = *board.WebhookToken
+	}
+	return WebhookCredentials{
+		URL:             c.BaseURL() + "/api/ingest/webhook/" + token,
+		Token:           token,
+		Secret:          board.WebhookSecret,
+		SignatureHeader: webhookSignature.SignatureHeader,
+		TimestampHeader: webhookSignature.TimestampHeader,
+	}
+}
+
+// GetWebhookHandler godoc
+// @Summary Get the board webhook credentials
+// @Description Return the inbound webhook URL and signing secret of the user's board, generating them on first call
+// @Tags Board
+// @Produce json
+// @Success 200 {object} WebhookCredentials "Webhook credentials"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/board/webhook [get]
+func GetWebhookHandler(c *fiber.Ctx) error {
+	boardID, err := getUserBoardID(c, "GetWebhookHandler")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	board, err := Board.EnsureWebhookCredentials(boardID)
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to generate webhook credentials for board %d: %v", boardID, err),
+			Level:   sentry.LevelError,
+			Tags: map[string]string{
+				"handler": "GetWebhookHandler",
+				"action":  "ensure_webhook_credentials",
+			},
+		})
+		return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to generate webhook credentials"))
+	}
+	return c.Status(fiber.StatusOK).JSON(newWebhookCredentials(c, board))
+}
+
+// RotateWebhookHandler godoc
+// @Summary Rotate the board webhook credentials
+// @Description Replace the inbound webhook token and secret of the user's board, the previous ones stop working immediately
+// @Tags Board
+// @Produce json
+// @Success 200 {object} WebhookCredentials "New webhook credentials"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/board/webhook/rotate [post]
+func RotateWebhookHandler(c *fiber.Ctx) error {
+	boardID, err := getUserBoardID(c, "RotateWebhookHandler")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	board, err := Board.RotateWebhookCredentials(boardID)
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to rotate webhook credentials for board %d: %v", boardID, err),
+			Level:   sentry.LevelError,
+			Tags: map[string]string{
+				"handler": "RotateWebhookHandler",
+				"action":  "rotate_webhook_credentials",
+			},
+		})
+		return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to rotate webhook credentials"))
+	}
+	return c.Status(fiber.StatusOK).JSON(newWebhookCredentials(c, board))
+}
@@ -0,0 +1,72 @@
+package Board
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWebhookHandler(t *testing.T) {
+	t.Run("Unauthorized", func(t *testing.T) {
+		app := fiber.New()
+		app.Get("/api/board/webhook", GetWebhookHandler)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/board/webhook", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("Existing credentials", func(t *testing.T) {
+		mock := setupTest(t)
+		userUUID := "test-user-uuid"
+
+		mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards (.+) WHERE users.uuid = \$1 AND "boards"."deleted_at" IS NULL ORDER BY boards.id ASC LIMIT \$2`).
+			WithArgs(userUUID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Board"))
+		mock.ExpectQuery(`SELECT (.+) FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL`).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "webhook_token", "webhook_secret"}).AddRow(1, "Board", "tok", "secret"))
+
+		app := fiber.New()
+		app.Get("/api/board/webhook", func(c *fiber.Ctx) error {
+			c.Locals("userUUID", userUUID)
+			return GetWebhookHandler(c)
+		})
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/board/webhook", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		body, _ := io.ReadAll(resp.Body)
+		var credentials WebhookCredentials
+		assert.NoError(t, json.Unmarshal(body, &credentials))
+		assert.Equal(t, "tok", credentials.Token)
+		assert.Equal(t, "secret", credentials.Secret)
+		assert.Contains(t, credentials.URL, "/api/ingest/webhook/tok")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRotateWebhookHandler_NoBoard(t *testing.T) {
+	mock := setupTest(t)
+	userUUID := "test-user-uuid"
+
+	mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards (.+) WHERE users.uuid = \$1 AND "boards"."deleted_at" IS NULL ORDER BY boards.id ASC LIMIT \$2`).
+		WithArgs(userUUID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	app := fiber.New()
+	app.Post("/api/board/webhook/rotate", func(c *fiber.Ctx) error {
+		c.Locals("userUUID", userUUID)
+		return RotateWebhookHandler(c)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/api/board/webhook/rotate", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,118 @@
+package Feedback
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/User"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
+)
+
+// boardContext is the board a request works on and the user notified of its negative feedbacks
+type boardContext struct {
+	BoardID   int
+	UserID    int
+	UserUUID  string
+	UserEmail string
+}
+
+// resolveBoardContext returns the board granted by the API key of the request or,
+// for user sessions, the first board of the user.
+// Failures are captured in Sentry and returned as *fiber.Error carrying the response status.
+func resolveBoardContext(c *fiber.Ctx, handler, action string) (boardContext, error) {
+	tags := map[string]string{
+		"handler": handler,
+		"action":  action,
+	}
+
+	if boardID, ok := middleware.GetBoardID(c); ok {
+		userEmail, err := Board.GetBoardNotificationEmail(boardID)
+		if err != nil {
+			middleware.CaptureEvent(c, &sentry.Event{
+				Message: fmt.Sprintf("Failed to retrieve notification email for board %d: %v", boardID, err),
+				Level:   sentry.LevelError,
+				Tags:    tags,
+			})
+			return boardContext{}, fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve board members")
+		}
+		return boardContext{BoardID: boardID, UserEmail: userEmail}, nil
+	}
+
+	// Get user UUID from context
+	userUUID, check := middleware.GetUserUUID(c)
+	if !check {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: "Unauthorized: user not found in context",
+			Level:   sentry.LevelError,
+			Tags:    tags,
+		})
+		return boardContext{}, fiber.NewError(fiber.StatusUnauthorized, "Unauthorized: user not found in context")
+	}
+
+	// Get user ID from userUUID
+	var u User.User
+	err := database.DB.Model(&User.User{}).Where("uuid = ?", userUUID).Select("id, email").Scan(&u).Error
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to retrieve user ID for UUID %s: %v", userUUID, err),
+			Level:   sentry.LevelError,
+			Extra: map[string]interface{}{
+				"error": err.Error(),
+			},
+			User: sentry.User{
+				ID: userUUID,
+			},
+			Tags: tags,
+		})
+		return boardContext{}, fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve user ID")
+	}
+
+	// Get the board of the user
+	board, err := Board.GetFirstBoardByUserUUID(userUUID)
+	if errors.Is(err, Board.ErrNoUserBoard) {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("No boards found for user ID %d", u.Id),
+			Level:   sentry.LevelWarning,
+			User: sentry.User{
+				ID: userUUID,
+			},
+			Tags: tags,
+		})
+		return boardContext{}, fiber.NewError(fiber.StatusBadRequest, "No boards found for this user")
+	}
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to retrieve boards for user ID %d: %v", u.Id, err),
+			Level:   sentry.LevelError,
+			Extra: map[string]interface{}{
+				"error": err.Error(),
+			},
+			User: sentry.User{
+				ID: userUUID,
+			},
+			Tags: tags,
+		})
+		return boardContext{}, fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve boards for user")
+	}
+
+	return boardContext{
+		BoardID:   board.Id,
+		UserID:    u.Id,
+		UserUUID:  userUUID,
+		UserEmail: u.Email,
+	}, nil
+}
+
+// fiberError writes a *fiber.Error as an HTTPError response
+func fiberError(c *fiber.Ctx, err error) error {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return httpUtils.NewError(c, fiberErr.Code, errors.New(fiberErr.Message))
+	}
+	return httpUtils.NewError(c, fiber.StatusInternalServerError, err)
+}
@@ -0,0 +1,81 @@
+package Feedback
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	tagDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Tag"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/feedbackBulk"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
+)
+
+// BulkFeedbacksHandler godoc
+// @Summary Run a bulk action on feedbacks
+// @Description Delete, re-analyze, set the status of, tag, untag or move to another board the feedbacks listed by ids in the body or, without ids, the ones matching the listing filters of the query.
+// @Description The feedbacks are processed in chunks, each one in its own transaction, a failed chunk is rolled back and reported in the summary.
+// @Tags Feedback
+// @Accept json
+// @Produce json
+// @Param request body feedbackBulk.Request true "Action and feedback IDs"
+// @Param channel query string false "Filter by channel"
+// @Param status query string false "Filter by triage statuses, comma separated"
+// @Param priority query string false "Filter by priority"
+// @Param assignee_id query string false "Filter by assignee ID, none for the unassigned feedbacks"
+// @Param tag query string false "Filter by tag names, comma separated"
+// @Param q query string false "Filter by text, case insensitive"
+// @Success 200 {object} feedbackBulk.Summary "Summary of the action"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 403 {object} httpUtils.HTTPError "Target board not allowed"
+// @Failure 404 {object} httpUtils.HTTPError "Tag not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/feedbacks/bulk [post]
+func BulkFeedbacksHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "BulkFeedbacksHandler", "bulk_feedbacks")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	var request feedbackBulk.Request
+	if err := c.BodyParser(&request); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid request payload"))
+	}
+	if err := request.Validate(); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+	}
+	filter, err := parseListingFilter(c)
+	if err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+	}
+	// An empty selection would target the whole board
+	if len(request.IDs) == 0 && filter.Empty() {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("ids or at least one filter is required"))
+	}
+	if len(request.IDs) > 0 && !filter.Empty() {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("ids and filters cannot be combined"))
+	}
+
+	summary, err := feedbackBulk.Run(c.UserContext(), bc.BoardID, request, filter, feedbackBulk.Actor{UserID: actorID(bc), Email: bc.UserEmail})
+	if err != nil {
+		switch {
+		case errors.Is(err, tagDB.ErrTagNotFound):
+			return httpUtils.NewError(c, fiber.StatusNotFound, err)
+		case errors.Is(err, feedbackBulk.ErrTargetBoardForbidden):
+			return httpUtils.NewError(c, fiber.StatusForbidden, err)
+		case errors.Is(err, feedbackBulk.ErrSameBoard), errors.Is(err, feedbackBulk.ErrTooManyFeedbacks), errors.Is(err, feedbackBulk.ErrTooManyReanalyzed):
+			return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+		}
+		return feedbackError(c, bc, "BulkFeedbacksHandler", "bulk_feedbacks", err)
+	}
+	return c.Status(fiber.StatusOK).JSON(summary)
+}
+
+// ReanalyzeRequested reports whether the body of a bulk request asks for a re-analysis, the action calling the analyzer
+func ReanalyzeRequested(c *fiber.Ctx) bool {
+	var request struct {
+		Action string `json:"action"`
+	}
+	_ = json.Unmarshal(c.Body(), &request)
+	return request.Action == feedbackBulk.ActionReanalyze
+}
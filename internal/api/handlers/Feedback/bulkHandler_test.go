@@ -0,0 +1,94 @@
+package Feedback
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/feedbackBulk"
+)
+
+func TestBulkFeedbacksHandler_SelectionRequired(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	expectBoardContext(mock, "test-user-uuid", 2, 1)
+
+	app := newTriageApp(fiber.MethodPost, "/api/feedbacks/bulk", BulkFeedbacksHandler)
+	req := httptest.NewRequest(fiber.MethodPost, "/api/feedbacks/bulk", strings.NewReader(`{"action":"delete"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBulkFeedbacksHandler_Delete(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	expectBoardContext(mock, "test-user-uuid", 2, 1)
+	// Feedback 9 is not on the board
+	mock.ExpectQuery(`SELECT "id" FROM "feedbacks" WHERE \(board_id = \$1 AND id IN \(\$2,\$3\)\) AND "feedbacks"."deleted_at" IS NULL ORDER BY id`).
+		WithArgs(1, 3, 9).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT "id" FROM "feedbacks" WHERE \(board_id = \$1 AND id IN \(\$2\)\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(1, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectExec(`UPDATE "analyses" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE feedback_id IN \(\$3\) AND "analyses"."deleted_at" IS NULL`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "feedbacks" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE id IN \(\$3\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	app := newTriageApp(fiber.MethodPost, "/api/feedbacks/bulk", BulkFeedbacksHandler)
+	req := httptest.NewRequest(fiber.MethodPost, "/api/feedbacks/bulk", strings.NewReader(`{"action":"delete","ids":[3,9]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var summary feedbackBulk.Summary
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&summary))
+	assert.Equal(t, 1, summary.Matched)
+	assert.Equal(t, 1, summary.Processed)
+	assert.Equal(t, 1, summary.Chunks)
+	assert.Equal(t, []int{9}, summary.NotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBulkFeedbacksHandler_MoveToSameBoard(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	expectBoardContext(mock, "test-user-uuid", 2, 1)
+
+	app := newTriageApp(fiber.MethodPost, "/api/feedbacks/bulk", BulkFeedbacksHandler)
+	req := httptest.NewRequest(fiber.MethodPost, "/api/feedbacks/bulk?channel=email", strings.NewReader(`{"action":"move","target_board_id":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReanalyzeRequested(t *testing.T) {
+	app := fiber.New()
+	app.Post("/", func(c *fiber.Ctx) error {
+		return c.JSON(ReanalyzeRequested(c))
+	})
+	for body, want := range map[string]string{`{"action":"reanalyze","ids":[1]}`: "true", `{"action":"delete"}`: "false", `not json`: "false"} {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(body)))
+		assert.NoError(t, err)
+		got, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, want, string(got), body)
+	}
+}
@@ -0,0 +1,229 @@
+package Feedback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/logging"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/User"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/background"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/sentimentAnalysis"
+)
+
+// GetCommentsHandler godoc
+// @Summary List the comments of a feedback
+// @Description List the internal comment threads of a feedback of the board, oldest first, with the replies of each thread
+// @Tags Feedback
+// @Produce json
+// @Param id path int true "Feedback ID"
+// @Success 200 {array} Feedback.Comment "Comment threads"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Feedback not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/feedbacks/{id}/comments [get]
+func GetCommentsHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "GetCommentsHandler", "get_comments")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid feedback id"))
+	}
+
+	comments, err := feedbackDB.GetFeedbackComments(bc.BoardID, id)
+	if err != nil {
+		return commentError(c, bc, "GetCommentsHandler", "get_comments", err)
+	}
+	return c.Status(fiber.StatusOK).JSON(comments)
+}
+
+// CreateCommentHandler godoc
+// @Summary Comment a feedback
+// @Description Add an internal comment on a feedback of the board, or reply to a thread with parent_id.
+// @Description Board members mentioned with @username are notified by email.
+// @Tags Feedback
+// @Accept json
+// @Produce json
+// @Param id path int true "Feedback ID"
+// @Param comment body Feedback.CommentJson true "Comment body and optional parent comment"
+// @Success 201 {object} Feedback.Comment "Created comment"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Feedback or parent comment not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/feedbacks/{id}/comments [post]
+func CreateCommentHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "CreateCommentHandler", "create_comment")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid feedback id"))
+	}
+
+	var body feedbackModel.CommentJson
+	if err := c.BodyParser(&body); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid request payload"))
+	}
+	body.Body = strings.TrimSpace(body.Body)
+	if err := body.Validate(); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+	}
+
+	comment, mentioned, err := feedbackDB.CreateComment(bc.BoardID, feedbackModel.Comment{
+		FeedbackID: id,
+		ParentID:   body.ParentID,
+		AuthorID:   &bc.UserID,
+		Body:       body.Body,
+	})
+	if err != nil {
+		return commentError(c, bc, "CreateCommentHandler", "create_comment", err)
+	}
+	// The context of the request is read before it ends, the notification outlives it
+	ctx := c.UserContext()
+	background.Go(func() { notifyMentions(ctx, bc, comment, mentioned) })
+	return c.Status(fiber.StatusCreated).JSON(comment)
+}
+
+// UpdateCommentHandler godoc
+// @Summary Edit a comment
+// @Description Change the body of a comment, only its author can edit it. Members mentioned for the first time are notified by email.
+// @Tags Feedback
+// @Accept json
+// @Produce json
+// @Param id path int true "Feedback ID"
+// @Param commentId path int true "Comment ID"
+// @Param comment body Feedback.CommentJson true "New comment body"
+// @Success 200 {object} Feedback.Comment "Updated comment"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 403 {object} httpUtils.HTTPError "Not the author of the comment"
+// @Failure 404 {object} httpUtils.HTTPError "Feedback or comment not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/feedbacks/{id}/comments/{commentId} [patch]
+func UpdateCommentHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "UpdateCommentHandler", "update_comment")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	id, commentID, err := commentParams(c)
+	if err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+	}
+
+	var body feedbackModel.CommentJson
+	if err := c.BodyParser(&body); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid request payload"))
+	}
+	body.Body = strings.TrimSpace(body.Body)
+	body.ParentID = nil
+	if err := body.Validate(); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+	}
+
+	comment, mentioned, err := feedbackDB.UpdateComment(bc.BoardID, id, commentID, bc.UserID, body.Body)
+	if err != nil {
+		return commentError(c, bc, "UpdateCommentHandler", "update_comment", err)
+	}
+	ctx := c.UserContext()
+	background.Go(func() { notifyMentions(ctx, bc, comment, mentioned) })
+	return c.Status(fiber.StatusOK).JSON(comment)
+}
+
+// DeleteCommentHandler godoc
+// @Summary Delete a comment
+// @Description Delete a comment and its replies, only its author can delete it
+// @Tags Feedback
+// @Produce json
+// @Param id path int true "Feedback ID"
+// @Param commentId path int true "Comment ID"
+// @Success 200 {object} httpUtils.HTTPMessage "Comment deleted"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 403 {object} httpUtils.HTTPError "Not the author of the comment"
+// @Failure 404 {object} httpUtils.HTTPError "Feedback or comment not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/feedbacks/{id}/comments/{commentId} [delete]
+func DeleteCommentHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "DeleteCommentHandler", "delete_comment")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	id, commentID, err := commentParams(c)
+	if err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+	}
+
+	if err := feedbackDB.DeleteComment(bc.BoardID, id, commentID, bc.UserID); err != nil {
+		return commentError(c, bc, "DeleteCommentHandler", "delete_comment", err)
+	}
+	return httpUtils.NewMessage(c, fiber.StatusOK, "Comment deleted")
+}
+
+func commentParams(c *fiber.Ctx) (int, int, error) {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return 0, 0, errors.New("invalid feedback id")
+	}
+	commentID, err := c.ParamsInt("commentId")
+	if err != nil || commentID <= 0 {
+		return 0, 0, errors.New("invalid comment id")
+	}
+	return id, commentID, nil
+}
+
+// notifyMentions emails the board members mentioned in a comment, failures are captured in Sentry
+func notifyMentions(ctx context.Context, bc boardContext, comment feedbackModel.Comment, members []User.User) {
+	for _, member := range members {
+		body := fmt.Sprintf("Hello %s,\n\n%s mentioned you in a comment on feedback #%d:\n\n%s\n\nBest regards,\nFeedPulse Team",
+			member.Username, bc.UserEmail, comment.FeedbackID, comment.Body)
+		if err := sentimentAnalysis.SendEmail(member.Email, "You were mentioned on a feedback", body); err != nil {
+			sentry.CaptureEvent(&sentry.Event{
+				Message: fmt.Sprintf("Failed to notify user %d of a mention on feedback %d: %v", member.Id, comment.FeedbackID, err),
+				Level:   sentry.LevelWarning,
+				Tags: map[string]string{
+					"handler":    "notifyMentions",
+					"action":     "notify_mention",
+					"request_id": logging.RequestID(ctx),
+				},
+			})
+		}
+	}
+}
+
+// commentError writes the response for a failed comment request, database errors are captured in Sentry
+func commentError(c *fiber.Ctx, bc boardContext, handler, action string, err error) error {
+	switch {
+	case errors.Is(err, feedbackDB.ErrFeedbackNotFound), errors.Is(err, feedbackDB.ErrCommentNotFound):
+		return httpUtils.NewError(c, fiber.StatusNotFound, err)
+	case errors.Is(err, feedbackDB.ErrNotCommentAuthor):
+		return httpUtils.NewError(c, fiber.StatusForbidden, err)
+	}
+	middleware.CaptureEvent(c, &sentry.Event{
+		Message: fmt.Sprintf("Failed to %s for board %d: %v", strings.ReplaceAll(action, "_", " "), bc.BoardID, err),
+		Level:   sentry.LevelError,
+		User: sentry.User{
+			ID: bc.UserUUID,
+		},
+		Tags: map[string]string{
+			"handler": handler,
+			"action":  action,
+		},
+	})
+	return httpUtils.NewError(c, fiber.StatusInternalServerError, fmt.Errorf("failed to %s", strings.ReplaceAll(action, "_", " ")))
+}
@@ -0,0 +1,72 @@
+package Feedback
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCommentHandler(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	expectBoardContext(mock, "test-user-uuid", 2, 1)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "feedbacks" WHERE \(id = \$1 AND board_id = \$2\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(10, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`INSERT INTO "feedback_comments"`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 10, nil, 2, "Looks like a pricing issue", "[]").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectCommit()
+
+	app := newTriageApp(fiber.MethodPost, "/api/feedbacks/:id/comments", CreateCommentHandler)
+	req := httptest.NewRequest(fiber.MethodPost, "/api/feedbacks/10/comments", strings.NewReader(`{"body":"  Looks like a pricing issue "}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode, string(body))
+	assert.Contains(t, string(body), `"author_id":2`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateCommentHandler_EmptyBody(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	expectBoardContext(mock, "test-user-uuid", 2, 1)
+
+	app := newTriageApp(fiber.MethodPost, "/api/feedbacks/:id/comments", CreateCommentHandler)
+	req := httptest.NewRequest(fiber.MethodPost, "/api/feedbacks/10/comments", strings.NewReader(`{"body":"   "}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCommentHandler_NotAuthor(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	expectBoardContext(mock, "test-user-uuid", 2, 1)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "feedbacks" WHERE \(id = \$1 AND board_id = \$2\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(10, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT \* FROM "feedback_comments" WHERE id = \$1 AND feedback_id = \$2`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "feedback_id", "author_id"}).AddRow(3, 10, 7))
+	mock.ExpectRollback()
+
+	app := newTriageApp(fiber.MethodDelete, "/api/feedbacks/:id/comments/:commentId", DeleteCommentHandler)
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodDelete, "/api/feedbacks/10/comments/3", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
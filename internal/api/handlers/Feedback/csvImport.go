@@ -0,0 +1,56 @@
+package Feedback
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/feedbackImport"
+)
+
+// getCsvOptions reads the CSV options from the query string or the multipart form
+func getCsvOptions(c *fiber.Ctx) (feedbackImport.CsvOptions, error) {
+	value := func(key string) string {
+		if v := c.Query(key); v != "" {
+			return v
+		}
+		return c.FormValue(key)
+	}
+
+	opts := feedbackImport.CsvOptions{
+		Header:              strings.ToLower(value("header")),
+		DateColumn:          value("date_column"),
+		ChannelColumn:       value("channel_column"),
+		TextColumn:          value("text_column"),
+		ExternalIDColumn:    value("external_id_column"),
+		CustomerIDColumn:    value("customer_id_column"),
+		CustomerNameColumn:  value("customer_name_column"),
+		CustomerEmailColumn: value("customer_email_column"),
+		RatingColumn:        value("rating_column"),
+	}
+	if opts.Header == "" {
+		opts.Header = "auto"
+	}
+	if opts.Header != "auto" && opts.Header != "true" && opts.Header != "false" {
+		return opts, errors.New("header must be one of auto, true or false")
+	}
+
+	if scale := value("rating_scale"); scale != "" {
+		ratingScale, err := strconv.Atoi(scale)
+		if err != nil || (ratingScale != feedbackModel.RatingScaleFive && ratingScale != feedbackModel.RatingScaleTen) {
+			return opts, fmt.Errorf("rating_scale must be %d or %d", feedbackModel.RatingScaleFive, feedbackModel.RatingScaleTen)
+		}
+		opts.RatingScale = &ratingScale
+	}
+
+	delimiter, err := feedbackImport.ParseDelimiter(value("delimiter"))
+	if err != nil {
+		return opts, err
+	}
+	opts.Delimiter = delimiter
+
+	return opts, nil
+}
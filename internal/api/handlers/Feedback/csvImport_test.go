@@ -0,0 +1,16 @@
+package Feedback
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/feedbackImport"
+)
+
+func TestUploadFormat(t *testing.T) {
+	assert.Equal(t, feedbackImport.FormatJSON, uploadFormat("application/json", "feedbacks.json"))
+	assert.Equal(t, feedbackImport.FormatCSV, uploadFormat("text/csv; charset=utf-8", "feedbacks.csv"))
+	assert.Equal(t, feedbackImport.FormatCSV, uploadFormat("application/octet-stream", "export.CSV"))
+	assert.Equal(t, "", uploadFormat("application/octet-stream", "export.xlsx"))
+	assert.Equal(t, "", uploadFormat("image/png", "feedbacks.csv"))
+}
@@ -0,0 +1,165 @@
+package Feedback
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	customerDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Customer"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	customerModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Customer"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
+)
+
+// GetCustomersHandler godoc
+// @Summary List the customers of the board
+// @Description List the authors of the board's feedbacks with their feedback count and average sentiment, most recently seen first
+// @Tags Customer
+// @Produce json
+// @Param q query string false "Filter on the name, email or external ID"
+// @Param limit query int false "Maximum number of customers to return (default 50)"
+// @Param offset query int false "Number of customers to skip"
+// @Success 200 {array} Customer.CustomerWithStats "List of customers"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/customers [get]
+func GetCustomersHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "GetCustomersHandler", "get_customers")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	customers, err := customerDB.GetCustomersByBoardID(bc.BoardID, c.Query("q"), c.QueryInt("limit", 50), c.QueryInt("offset", 0))
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to retrieve customers for board %d: %v", bc.BoardID, err),
+			Level:   sentry.LevelError,
+			User: sentry.User{
+				ID: bc.UserUUID,
+			},
+			Tags: map[string]string{
+				"handler": "GetCustomersHandler",
+				"action":  "get_customers",
+			},
+		})
+		return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to retrieve customers"))
+	}
+	return c.Status(fiber.StatusOK).JSON(customers)
+}
+
+// GetCustomerHandler godoc
+// @Summary Get a customer
+// @Description Get a customer of the board
+// @Tags Customer
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Success 200 {object} Customer.Customer "Customer"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Customer not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/customers/{id} [get]
+func GetCustomerHandler(c *fiber.Ctx) error {
+	customer, bc, err := getBoardCustomer(c, "GetCustomerHandler", "get_customer")
+	if err != nil {
+		return customerError(c, bc, "GetCustomerHandler", "get_customer", err)
+	}
+	return c.Status(fiber.StatusOK).JSON(customer)
+}
+
+// GetCustomerFeedbacksHandler godoc
+// @Summary Get the feedback history of a customer
+// @Description List the feedbacks given by a customer with their analysis, newest first
+// @Tags Customer
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Success 200 {array} Feedback.FeedbackWithAnalysis "Feedbacks of the customer"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Customer not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/customers/{id}/feedbacks [get]
+func GetCustomerFeedbacksHandler(c *fiber.Ctx) error {
+	customer, bc, err := getBoardCustomer(c, "GetCustomerFeedbacksHandler", "get_customer_feedbacks")
+	if err != nil {
+		return customerError(c, bc, "GetCustomerFeedbacksHandler", "get_customer_feedbacks", err)
+	}
+
+	feedbacks, err := customerDB.GetCustomerFeedbacks(customer.Id)
+	if err != nil {
+		return customerError(c, bc, "GetCustomerFeedbacksHandler", "get_customer_feedbacks", err)
+	}
+	return c.Status(fiber.StatusOK).JSON(feedbacks)
+}
+
+// GetCustomerSentimentHandler godoc
+// @Summary Get the sentiment of a customer over time
+// @Description Get the number of feedbacks of a customer and their average sentiment per day, week or month
+// @Tags Customer
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Param interval query string false "Period of each point: day, week or month" default(week)
+// @Success 200 {array} Customer.SentimentPoint "Sentiment timeline"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Customer not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/customers/{id}/sentiment [get]
+func GetCustomerSentimentHandler(c *fiber.Ctx) error {
+	interval := c.Query("interval", "week")
+	if !slices.Contains(customerDB.Intervals, interval) {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("interval must be one of day, week or month"))
+	}
+
+	customer, bc, err := getBoardCustomer(c, "GetCustomerSentimentHandler", "get_customer_sentiment")
+	if err != nil {
+		return customerError(c, bc, "GetCustomerSentimentHandler", "get_customer_sentiment", err)
+	}
+
+	points, err := customerDB.GetCustomerSentimentTimeline(customer.Id, interval)
+	if err != nil {
+		return customerError(c, bc, "GetCustomerSentimentHandler", "get_customer_sentiment", err)
+	}
+	return c.Status(fiber.StatusOK).JSON(points)
+}
+
+// getBoardCustomer returns the customer of the :id param if it belongs to the board of the request
+func getBoardCustomer(c *fiber.Ctx, handler, action string) (customerModel.Customer, boardContext, error) {
+	bc, err := resolveBoardContext(c, handler, action)
+	if err != nil {
+		return customerModel.Customer{}, bc, err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return customerModel.Customer{}, bc, fiber.NewError(fiber.StatusBadRequest, "invalid customer id")
+	}
+
+	customer, err := customerDB.GetCustomer(bc.BoardID, id)
+	if errors.Is(err, customerDB.ErrCustomerNotFound) {
+		return customerModel.Customer{}, bc, fiber.NewError(fiber.StatusNotFound, err.Error())
+	}
+	return customer, bc, err
+}
+
+// customerError writes the response for a customer endpoint, database errors are captured in Sentry
+func customerError(c *fiber.Ctx, bc boardContext, handler, action string, err error) error {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberError(c, err)
+	}
+	middleware.CaptureEvent(c, &sentry.Event{
+		Message: fmt.Sprintf("Failed to retrieve customer data for board %d: %v", bc.BoardID, err),
+		Level:   sentry.LevelError,
+		User: sentry.User{
+			ID: bc.UserUUID,
+		},
+		Tags: map[string]string{
+			"handler": handler,
+			"action":  action,
+		},
+	})
+	return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to retrieve customer"))
+}
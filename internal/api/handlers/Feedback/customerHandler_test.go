@@ -0,0 +1,38 @@
+package Feedback
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCustomersHandler_Unauthorized(t *testing.T) {
+	_, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	app := fiber.New()
+	app.Get("/api/customers", GetCustomersHandler)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/customers", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetCustomerSentimentHandler_InvalidInterval(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	app := fiber.New()
+	app.Get("/api/customers/:id/sentiment", func(c *fiber.Ctx) error {
+		c.Locals("userUUID", "test-user-uuid")
+		return GetCustomerSentimentHandler(c)
+	})
+
+	// The interval is checked before any lookup
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/customers/3/sentiment?interval=year", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
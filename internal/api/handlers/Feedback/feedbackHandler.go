@@ -0,0 +1,187 @@
+package Feedback
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
+)
+
+// GetFeedbackHandler godoc
+// @Summary Get a feedback
+// @Description Get a feedback of the board with its tags
+// @Tags Feedback
+// @Produce json
+// @Param id path int true "Feedback ID"
+// @Success 200 {object} Feedback.Feedback "Feedback"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Feedback not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/feedbacks/{id} [get]
+func GetFeedbackHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "GetFeedbackHandler", "get_feedback")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid feedback id"))
+	}
+
+	feedback, err := feedbackDB.GetBoardFeedback(bc.BoardID, id)
+	if err != nil {
+		return feedbackError(c, bc, "GetFeedbackHandler", "get_feedback", err)
+	}
+	return c.Status(fiber.StatusOK).JSON(feedback)
+}
+
+// CreateFeedbackHandler godoc
+// @Summary Create a feedback
+// @Description Add a single feedback to the board and analyze it. A feedback whose external ID is already stored replaces it when its text changed.
+// @Tags Feedback
+// @Accept json
+// @Produce json
+// @Param feedback body Feedback.FeedbackJson true "Feedback"
+// @Success 200 {object} Feedback.Feedback "Replaced feedback"
+// @Success 201 {object} Feedback.Feedback "Created feedback"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 409 {object} httpUtils.HTTPError "Feedback already stored on the board"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/feedbacks [post]
+func CreateFeedbackHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "CreateFeedbackHandler", "create_feedback")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	var body feedbackModel.FeedbackJson
+	if err := c.BodyParser(&body); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid request payload"))
+	}
+	if strings.TrimSpace(body.Channel) == "" || strings.TrimSpace(body.Text) == "" {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("channel and text are required"))
+	}
+	if body.Date.IsZero() {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("date is required"))
+	}
+
+	feedback, outcome, err := feedbackDB.CreateFeedback(c.UserContext(), convertJsonToFeedbacks([]feedbackModel.FeedbackJson{body}, bc.BoardID)[0], bc.UserEmail)
+	if err != nil {
+		return feedbackError(c, bc, "CreateFeedbackHandler", "create_feedback", err)
+	}
+	if outcome == feedbackDB.OutcomeUpdated {
+		return c.Status(fiber.StatusOK).JSON(feedback)
+	}
+	return c.Status(fiber.StatusCreated).JSON(feedback)
+}
+
+// UpdateFeedbackHandler godoc
+// @Summary Update a feedback
+// @Description Change the date, channel, text, metadata or rating of a feedback of the board, a feedback whose text changes is analyzed again
+// @Tags Feedback
+// @Accept json
+// @Produce json
+// @Param id path int true "Feedback ID"
+// @Param feedback body Feedback.FeedbackPatch true "Fields to change"
+// @Success 200 {object} Feedback.Feedback "Updated feedback"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Feedback not found"
+// @Failure 409 {object} httpUtils.HTTPError "Feedback already stored on the board"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/feedbacks/{id} [patch]
+func UpdateFeedbackHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "UpdateFeedbackHandler", "update_feedback")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid feedback id"))
+	}
+
+	var patch feedbackModel.FeedbackPatch
+	if err := c.BodyParser(&patch); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid request payload"))
+	}
+	if patch.Empty() {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("at least one field to change is required"))
+	}
+
+	feedback, err := feedbackDB.PatchFeedback(c.UserContext(), bc.BoardID, id, patch, bc.UserEmail)
+	if err != nil {
+		return feedbackError(c, bc, "UpdateFeedbackHandler", "update_feedback", err)
+	}
+	return c.Status(fiber.StatusOK).JSON(feedback)
+}
+
+// TextPatched reports whether the body of an update sets the text, the change analyzing the feedback again
+func TextPatched(c *fiber.Ctx) bool {
+	var patch feedbackModel.FeedbackPatch
+	_ = json.Unmarshal(c.Body(), &patch)
+	return patch.Text != nil
+}
+
+// DeleteFeedbackHandler godoc
+// @Summary Delete a feedback
+// @Description Put a feedback of the board in the trash along with its analysis, its tags, comments and triage history are kept until it is purged
+// @Tags Feedback
+// @Produce json
+// @Param id path int true "Feedback ID"
+// @Success 200 {object} httpUtils.HTTPMessage "Feedback moved to the trash"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Feedback not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/feedbacks/{id} [delete]
+func DeleteFeedbackHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "DeleteFeedbackHandler", "delete_feedback")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid feedback id"))
+	}
+
+	if err := feedbackDB.DeleteBoardFeedback(bc.BoardID, id); err != nil {
+		return feedbackError(c, bc, "DeleteFeedbackHandler", "delete_feedback", err)
+	}
+	return httpUtils.NewMessage(c, fiber.StatusOK, "Feedback moved to the trash")
+}
+
+// feedbackError writes the response for a failed feedback request, database errors are captured in Sentry
+func feedbackError(c *fiber.Ctx, bc boardContext, handler, action string, err error) error {
+	switch {
+	case errors.Is(err, feedbackDB.ErrFeedbackNotFound):
+		return httpUtils.NewError(c, fiber.StatusNotFound, err)
+	case errors.Is(err, feedbackDB.ErrInvalidFeedback):
+		return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+	case errors.Is(err, feedbackDB.ErrDuplicateFeedback):
+		return httpUtils.NewError(c, fiber.StatusConflict, err)
+	}
+	middleware.CaptureEvent(c, &sentry.Event{
+		Message: fmt.Sprintf("Failed to %s for board %d: %v", strings.ReplaceAll(action, "_", " "), bc.BoardID, err),
+		Level:   sentry.LevelError,
+		User: sentry.User{
+			ID: bc.UserUUID,
+		},
+		Tags: map[string]string{
+			"handler": handler,
+			"action":  action,
+		},
+	})
+	return httpUtils.NewError(c, fiber.StatusInternalServerError, fmt.Errorf("failed to %s", strings.ReplaceAll(action, "_", " ")))
+}
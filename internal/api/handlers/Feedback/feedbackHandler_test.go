@@ -0,0 +1,114 @@
+package Feedback
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllFeedbacksHandler_Unauthorized(t *testing.T) {
+	_, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	app := fiber.New()
+	app.Get("/api/feedbacks", GetAllFeedbacksHandler)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/api/feedbacks", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetFeedbackHandler_OtherBoard(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	// The feedback exists on another board, it is not found on the user's board
+	expectBoardContext(mock, "test-user-uuid", 2, 1)
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE \(id = \$1 AND board_id = \$2\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(10, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	app := newTriageApp(fiber.MethodGet, "/api/feedbacks/:id", GetFeedbackHandler)
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/api/feedbacks/10", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateFeedbackHandler_InvalidRating(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	expectBoardContext(mock, "test-user-uuid", 2, 1)
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE \(id = \$1 AND board_id = \$2\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(10, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "channel", "text"}).AddRow(10, 1, "email", "Great"))
+
+	app := newTriageApp(fiber.MethodPatch, "/api/feedbacks/:id", UpdateFeedbackHandler)
+	req := httptest.NewRequest(fiber.MethodPatch, "/api/feedbacks/10", strings.NewReader(`{"rating":12,"rating_scale":10}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, string(body))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateFeedbackHandler_EmptyPatch(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	expectBoardContext(mock, "test-user-uuid", 2, 1)
+
+	app := newTriageApp(fiber.MethodPatch, "/api/feedbacks/:id", UpdateFeedbackHandler)
+	req := httptest.NewRequest(fiber.MethodPatch, "/api/feedbacks/10", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteFeedbackHandler(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	expectBoardContext(mock, "test-user-uuid", 2, 1)
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "feedbacks" WHERE \(id = \$1 AND board_id = \$2\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(10, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE id = \$1 AND "feedbacks"."deleted_at" IS NULL`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id"}).AddRow(10, 1))
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "analyses" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE feedback_id IN \(\$3\) AND "analyses"."deleted_at" IS NULL`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 10).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "feedbacks" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE id IN \(\$3\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 10).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	app := newTriageApp(fiber.MethodDelete, "/api/feedbacks/:id", DeleteFeedbackHandler)
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodDelete, "/api/feedbacks/10", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTextPatched(t *testing.T) {
+	app := fiber.New()
+	app.Patch("/", func(c *fiber.Ctx) error {
+		return c.JSON(TextPatched(c))
+	})
+	for body, want := range map[string]string{`{"text":"Slow app"}`: "true", `{"channel":"email"}`: "false", `{"text":null}`: "false"} {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodPatch, "/", strings.NewReader(body)))
+		assert.NoError(t, err)
+		got, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, want, string(got), body)
+	}
+}
@@ -1,144 +1,55 @@
 package Feedback
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/getsentry/sentry-go"
-	"io"
-	"math/rand"
-	"net/http"
-	"time"
-
-	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
-	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
-	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/User"
 
+	"github.com/getsentry/sentry-go"
 	"github.com/gofiber/fiber/v2"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
 	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
 	boardModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Board"
-	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/feedbackGenerator"
 	"gorm.io/gorm"
 )
 
-// Comment represents the JSONPlaceholder comment structure
-type Comment struct {
-	PostID int    `json:"postId"`
-	ID     int    `json:"id"`
-	Name   string `json:"name"`
-	Email  string `json:"email"`
-	Body   string `json:"body"`
-}
+// maxFetchCount is the maximum number of feedbacks generated by one fetch call, they are analyzed before it answers.
+// Larger sets go through GenerateFeedbackHandler and the upload, which analyzes them in the background.
+const maxFetchCount = 100
 
 // FetchFeedbackHandler godoc
-// @Summary Fetch feedback data from external API
-// @Description Fetches comment data from JSONPlaceholder API and converts them to feedback data
+// @Summary Generate and save synthetic feedbacks
+// @Description Generates realistic French/English product feedbacks on the known topics and channels and saves them to the board.
+// @Description The same seed generates the same feedbacks, saving them again skips them as duplicates. The seed used is returned.
 // @Tags Feedback
 // @Accept json
 // @Produce json
-// @Param limit query int false "Limit the number of feedbacks to fetch"
-// @Success 200 {object} map[string]interface{} "JSONPlaceholder data processed successfully"
+// @Param count query int false "Number of feedbacks to generate, 50 by default and 100 at most"
+// @Param limit query int false "Alias of count"
+// @Param seed query int false "Seed of the generator, random when omitted"
+// @Param languages query string false "Comma separated languages among fr and en"
+// @Param channels query string false "Comma separated channels, twitter, facebook, instagram and web by default"
+// @Param topics query string false "Comma separated topics of the sentiment analysis"
+// @Param sentiment query string false "Sentiment mix such as positive:60,neutral:20,negative:20"
+// @Param days query int false "Number of days before end the dates are spread over, 30 by default"
+// @Param end query string false "Date (YYYY-MM-DD) closing the spread, today by default"
+// @Success 200 {object} map[string]interface{} "Synthetic feedbacks saved successfully"
 // @Failure 400 {object} ErrorResponse "Bad request error"
 // @Failure 401 {object} ErrorResponse "Unauthorized error"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /api/feedbacks/fetch [post]
 func FetchFeedbackHandler(c *fiber.Ctx) error {
-	// Default URL is JSONPlaceholder comments API
-	url := "https://jsonplaceholder.typicode.com/comments"
-
-	// Initialize random number generator properly
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-
-	// Social media channels to choose from
-	channels := []string{"twitter", "facebook", "instagram", "web"}
-
-	// Get user UUID from context
-	userUUID, check := middleware.GetUserUUID(c)
-	if !check {
-		sentry.CaptureEvent(&sentry.Event{
-			Message: "Unauthorized access: user not found in context",
-			Level:   sentry.LevelError,
-			Tags: map[string]string{
-				"handler": "FetchFeedbackHandler",
-				"action":  "fetch_feedback",
-			},
-		})
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Unauthorized: user not found in context",
-		})
-	}
-
-	// Get user ID from userUUID
-	var userId int
-	var userEmail string
-	var u User.User
-	err := database.DB.Model(&User.User{}).Where("uuid = ?", userUUID).Select("id, email").Scan(&u).Error
-	if err != nil {
-		sentry.CaptureEvent(&sentry.Event{
-			Message: fmt.Sprintf("Failed to retrieve user ID for UUID %s: %v", userUUID, err),
-			Level:   sentry.LevelError,
-			Extra: map[string]interface{}{
-				"error": err.Error(),
-			},
-			User: sentry.User{
-				ID: userUUID,
-			},
-			Tags: map[string]string{
-				"handler": "FetchFeedbackHandler",
-				"action":  "fetch_feedback",
-			},
-		})
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to retrieve user ID",
-		})
-	}
-	userId = u.Id
-	userEmail = u.Email
-
-	// Get board ID from user ID
-	boards, err := Board.GetBoardsByUserID(userId)
+	bc, err := resolveBoardContext(c, "FetchFeedbackHandler", "fetch_feedback")
 	if err != nil {
-		sentry.CaptureEvent(&sentry.Event{
-			Message: fmt.Sprintf("Failed to retrieve boards for user ID %d: %v", userId, err),
-			Level:   sentry.LevelError,
-			Extra: map[string]interface{}{
-				"error": err.Error(),
-			},
-			User: sentry.User{
-				ID: userUUID,
-			},
-
-			Tags: map[string]string{
-				"handler": "FetchFeedbackHandler",
-				"action":  "fetch_feedback",
-			},
-		})
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to retrieve boards for user",
-		})
+		return fiberError(c, err)
 	}
-	if len(boards) == 0 {
-		sentry.CaptureEvent(&sentry.Event{
-			Message: fmt.Sprintf("No boards found for user ID %d", userId),
-			Level:   sentry.LevelWarning,
-			User: sentry.User{
-				ID: userUUID,
-			},
-			Tags: map[string]string{
-				"handler": "FetchFeedbackHandler",
-				"action":  "fetch_feedback",
-			},
-		})
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "No boards found for this user",
-		})
-	}
-	boardID := boards[0].BaseModel.Id
+	boardID := bc.BoardID
+	userEmail := bc.UserEmail
 
 	// Check if the board exists in the database before proceeding
 	if err := validateBoardExists(boardID); err != nil {
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: fmt.Sprintf("Board validation failed for ID %d: %v", boardID, err),
 			Level:   sentry.LevelError,
 			Extra: map[string]interface{}{
@@ -155,64 +66,36 @@ func FetchFeedbackHandler(c *fiber.Ctx) error {
 		})
 	}
 
-	// Fetch comments from external API
-	comments, err := fetchCommentsFromAPI(url)
+	opts, err := parseGeneratorOptions(c)
 	if err != nil {
-		sentry.CaptureEvent(&sentry.Event{
-			Message: fmt.Sprintf("Failed to fetch comments from API: %v", err),
-			Level:   sentry.LevelError,
-			Extra: map[string]interface{}{
-				"url":   url,
-				"error": err.Error(),
-			},
-			Tags: map[string]string{
-				"handler": "FetchFeedbackHandler",
-				"action":  "fetch_feedback",
-			},
-		})
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
-
-	// Convert comments to feedback models
-	feedbacks := convertCommentsToFeedback(comments, boardID, channels, rng)
-
-	// Validate feedback data
-	validFeedbacks, preValidationErrors := validateFeedbacks(feedbacks)
-	if len(validFeedbacks) == 0 {
-		sentry.CaptureEvent(&sentry.Event{
-			Message: "No valid feedback data found after validation",
-			Level:   sentry.LevelWarning,
-			Extra: map[string]interface{}{
-				"feedbacks": feedbacks,
-			},
-			Tags: map[string]string{
-				"handler": "FetchFeedbackHandler",
-				"action":  "fetch_feedback",
-			},
-		})
+	if opts.Count > maxFetchCount {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":             "No valid feedback data found after validation",
-			"validation_errors": preValidationErrors,
+			"error": fmt.Sprintf("count must be at most %d, upload the feedbacks of /api/feedbacks/generate for more", maxFetchCount),
 		})
 	}
-
-	// Apply limit if requested
-	limitParam := c.QueryInt("limit", 0)
-	if limitParam > 0 && limitParam < len(validFeedbacks) {
-		validFeedbacks = validFeedbacks[:limitParam]
+	generated, err := feedbackGenerator.Generate(opts)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
 	}
+	feedbacks := convertJsonToFeedbacks(generated, boardID)
 
 	// Save feedbacks to database
-	successCount, dbErrors, err := feedbackDB.FetchAndSaveFeedbacks(validFeedbacks, userEmail)
+	successCount, skippedCount, dbErrors, err := feedbackDB.FetchAndSaveFeedbacks(c.UserContext(), feedbacks, userEmail)
 	if err != nil {
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: fmt.Sprintf("Database error while saving feedbacks: %v", err),
 			Level:   sentry.LevelError,
 			Extra: map[string]interface{}{
-				"feedbacks": validFeedbacks,
-				"error":     err.Error(),
+				"board_id": boardID,
+				"seed":     opts.Seed,
+				"count":    opts.Count,
+				"error":    err.Error(),
 			},
 			User: sentry.User{
 				Email: userEmail,
@@ -227,16 +110,63 @@ func FetchFeedbackHandler(c *fiber.Ctx) error {
 		})
 	}
 
-	// Combine all errors
-	allErrors := append(preValidationErrors, dbErrors...)
-
 	// Return summary of the operation
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message":       "JSONPlaceholder data processed successfully",
+		"message":       "Synthetic feedbacks saved successfully",
+		"seed":          opts.Seed,
 		"total":         len(feedbacks),
 		"success_count": successCount,
-		"error_count":   len(feedbacks) - successCount,
-		"errors":        allErrors,
+		"skipped_count": skippedCount,
+		"error_count":   len(feedbacks) - successCount - skippedCount,
+		"errors":        dbErrors,
+	})
+}
+
+// GenerateFeedbackHandler godoc
+// @Summary Generate synthetic feedbacks
+// @Description Returns realistic French/English product feedbacks without saving them, ready to be uploaded as a JSON file.
+// @Description The same options and seed always return the same feedbacks.
+// @Tags Feedback
+// @Produce json
+// @Param count query int false "Number of feedbacks to generate, 50 by default"
+// @Param seed query int false "Seed of the generator, random when omitted"
+// @Param languages query string false "Comma separated languages among fr and en"
+// @Param channels query string false "Comma separated channels, twitter, facebook, instagram and web by default"
+// @Param topics query string false "Comma separated topics of the sentiment analysis"
+// @Param sentiment query string false "Sentiment mix such as positive:60,neutral:20,negative:20"
+// @Param days query int false "Number of days before end the dates are spread over, 30 by default"
+// @Param end query string false "Date (YYYY-MM-DD) closing the spread, today by default"
+// @Success 200 {object} map[string]interface{} "Generated feedbacks along with the seed"
+// @Failure 400 {object} ErrorResponse "Bad request error"
+// @Failure 401 {object} ErrorResponse "Unauthorized error"
+// @Router /api/feedbacks/generate [get]
+func GenerateFeedbackHandler(c *fiber.Ctx) error {
+	opts, err := parseGeneratorOptions(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	feedbacks, err := feedbackGenerator.Generate(opts)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"seed": opts.Seed,
+		"data": feedbacks,
+	})
+}
+
+// parseGeneratorOptions reads the generator options from the query string, limit being an alias of count
+func parseGeneratorOptions(c *fiber.Ctx) (feedbackGenerator.Options, error) {
+	return feedbackGenerator.ParseOptions(func(key string) string {
+		if key == "count" && c.Query("count") == "" {
+			return c.Query("limit")
+		}
+		return c.Query(key)
 	})
 }
 
@@ -252,67 +182,3 @@ func validateBoardExists(boardID int) error {
 	}
 	return nil
 }
-
-// fetchCommentsFromAPI fetches comments from the JSONPlaceholder API
-func fetchCommentsFromAPI(url string) ([]Comment, error) {
-	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: 15 * time.Second,
-	}
-
-	// Make the request to the external API
-	resp, err := client.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to JSONPlaceholder API: %v", err)
-	}
-	defer resp.Body.Close()
-
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("external API returned error: %d %s", resp.StatusCode, resp.Status)
-	}
-
-	// Read response body
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read API response: %v", err)
-	}
-
-	// Parse JSON data from response
-	var comments []Comment
-	err = json.Unmarshal(bodyBytes, &comments)
-	if err != nil {
-		return nil, fmt.Errorf("invalid JSON format in API response: %v", err)
-	}
-
-	// Validate the comments
-	if len(comments) == 0 {
-		return nil, fmt.Errorf("no comment data found in API response")
-	}
-
-	return comments, nil
-}
-
-// convertCommentsToFeedback converts comments to feedback models with random dates and channels
-func convertCommentsToFeedback(comments []Comment, boardID int, channels []string, rng *rand.Rand) []feedbackModel.Feedback {
-	feedbacks := make([]feedbackModel.Feedback, len(comments))
-	now := time.Now()
-
-	for i, comment := range comments {
-		// Generate a random date between 1 month ago and now
-		randomDuration := time.Duration(-1*rng.Intn(30*24)) * time.Hour
-		randomDate := now.Add(randomDuration).UTC()
-
-		// Choose a random channel
-		randomChannel := channels[rng.Intn(len(channels))]
-
-		feedbacks[i] = feedbackModel.Feedback{
-			Date:    randomDate,
-			Channel: randomChannel,
-			Text:    comment.Body,
-			BoardID: boardID,
-		}
-	}
-
-	return feedbacks
-}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
-	"math/rand"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -19,34 +18,6 @@ import (
 	"gorm.io/gorm"
 )
 
-// Mock HTTP server for testing external API calls
-func createMockJSONPlaceholderServer() *httptest.Server {
-	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path == "/comments" {
-			comments := []Comment{
-				{
-					PostID: 1,
-					ID:     1,
-					Name:   "id labore ex et quam laborum",
-					Email:  "Eliseo@gardner.biz",
-					Body:   "laudantium enim quasi est quidem magnam voluptate ipsam eos",
-				},
-				{
-					PostID: 1,
-					ID:     2,
-					Name:   "quo vero reiciendis velit similique earum",
-					Email:  "Jayne_Kuhic@sydney.com",
-					Body:   "est natus enim nihil est dolore omnis voluptatem numquam",
-				},
-			}
-			w.Header().Set("Content-Type", "application/json")
-			_ = json.NewEncoder(w).Encode(comments)
-		} else {
-			w.WriteHeader(http.StatusNotFound)
-		}
-	}))
-}
-
 func setupMockDB(t *testing.T) (sqlmock.Sqlmock, func()) {
 	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
 	if err != nil {
@@ -79,9 +50,6 @@ func TestFetchFeedbackHandler(t *testing.T) {
 	// Initialize sentiment analysis with dummy values for testing
 	sentimentAnalysis.InitSentimentAnalysis("dummy-key", "dummy-password")
 
-	// Create mock JSON placeholder server
-	mockServer := createMockJSONPlaceholderServer()
-	defer mockServer.Close()
 	t.Run("Successful feedback fetch and save", func(t *testing.T) {
 		mock, cleanup := setupMockDB(t)
 		defer cleanup()
@@ -96,8 +64,8 @@ func TestFetchFeedbackHandler(t *testing.T) {
 		// Mock board lookup query
 		boardRows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
 			AddRow(1, "Test Board", time.Now(), time.Now())
-		mock.ExpectQuery(`SELECT.*FROM "boards".*WHERE.*user_id.*`).
-			WithArgs(1).
+		mock.ExpectQuery(`SELECT.*FROM "boards".*WHERE.*users.uuid.*ORDER BY boards.id ASC LIMIT`).
+			WithArgs("test-user-uuid", 1).
 			WillReturnRows(boardRows)
 
 		// Mock board existence validation
@@ -176,7 +144,7 @@ func TestFetchFeedbackHandler(t *testing.T) {
 		req.Header.Set("Content-Type", "application/json")
 
 		// Execute request
-		resp, err := app.Test(req, 30000) // 30 second timeout for external API call
+		resp, err := app.Test(req, 30000)
 		assert.NoError(t, err)
 
 		// Read response body first
@@ -201,7 +169,7 @@ func TestFetchFeedbackHandler(t *testing.T) {
 			err = json.Unmarshal(body, &response)
 			assert.NoError(t, err)
 
-			assert.Equal(t, "JSONPlaceholder data processed successfully", response["message"])
+			assert.Equal(t, "Synthetic feedbacks saved successfully", response["message"])
 			if total, ok := response["total"].(float64); ok {
 				assert.True(t, total > 0)
 			}
@@ -271,8 +239,8 @@ func TestFetchFeedbackHandler(t *testing.T) {
 			WillReturnRows(userRows)
 
 		// Mock board lookup query - no boards found
-		mock.ExpectQuery(`SELECT.*FROM "boards".*WHERE.*user_id.*`).
-			WithArgs(1).
+		mock.ExpectQuery(`SELECT.*FROM "boards".*WHERE.*users.uuid.*ORDER BY boards.id ASC LIMIT`).
+			WithArgs("test-user-uuid", 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}))
 
 		app := fiber.New()
@@ -310,8 +278,8 @@ func TestFetchFeedbackHandler(t *testing.T) {
 		// Mock board lookup query - return board with user_id column included
 		boardRows := sqlmock.NewRows([]string{"id", "name", "user_id", "created_at", "updated_at"}).
 			AddRow(999, "Test Board", 1, time.Now(), time.Now())
-		mock.ExpectQuery(`SELECT.*FROM "boards".*WHERE.*user_id.*`).
-			WithArgs(1).
+		mock.ExpectQuery(`SELECT.*FROM "boards".*WHERE.*users.uuid.*ORDER BY boards.id ASC LIMIT`).
+			WithArgs("test-user-uuid", 1).
 			WillReturnRows(boardRows)
 		// Mock board existence validation - board not found
 		mock.ExpectQuery(`SELECT.*FROM "boards".*WHERE.*id.*ORDER BY.*LIMIT`).
@@ -345,6 +313,38 @@ func TestFetchFeedbackHandler(t *testing.T) {
 			assert.True(t, len(response) > 0) // At least check we got some response
 		}
 	})
+	t.Run("Count above the fetch limit", func(t *testing.T) {
+		mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		mock.ExpectQuery(`SELECT.*FROM "users".*WHERE.*uuid.*`).
+			WithArgs("test-user-uuid").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(1, "test@example.com"))
+		mock.ExpectQuery(`SELECT.*FROM "boards".*WHERE.*users.uuid.*ORDER BY boards.id ASC LIMIT`).
+			WithArgs("test-user-uuid", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Test Board"))
+		mock.ExpectQuery(`SELECT.*FROM "boards".*WHERE.*id.*ORDER BY.*LIMIT`).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Test Board"))
+
+		app := fiber.New()
+		app.Post("/api/feedbacks/fetch", func(c *fiber.Ctx) error {
+			c.Locals("userUUID", "test-user-uuid")
+			return FetchFeedbackHandler(c)
+		})
+
+		// Nothing is generated nor saved
+		req := httptest.NewRequest(http.MethodPost, "/api/feedbacks/fetch?count=101", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+		var response map[string]interface{}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		assert.Contains(t, response["error"], "count must be at most 100")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("With limit parameter", func(t *testing.T) {
 		mock, cleanup := setupMockDB(t)
 		defer cleanup()
@@ -358,8 +358,8 @@ func TestFetchFeedbackHandler(t *testing.T) {
 		// Mock board lookup query - return proper board results
 		boardRows := sqlmock.NewRows([]string{"id", "name", "user_id", "created_at", "updated_at"}).
 			AddRow(1, "Test Board", 1, time.Now(), time.Now())
-		mock.ExpectQuery(`SELECT.*FROM "boards".*WHERE.*user_id.*`).
-			WithArgs(1).
+		mock.ExpectQuery(`SELECT.*FROM "boards".*WHERE.*users.uuid.*ORDER BY boards.id ASC LIMIT`).
+			WithArgs("test-user-uuid", 1).
 			WillReturnRows(boardRows)
 
 		// Mock board existence validation
@@ -381,15 +381,14 @@ func TestFetchFeedbackHandler(t *testing.T) {
 
 		body, err := io.ReadAll(resp.Body)
 		assert.NoError(t, err)
-		// Check if we got a 400 error (likely due to external API call)
+		// The feedbacks inserts are not mocked, saving them fails
 		if resp.StatusCode == fiber.StatusBadRequest || resp.StatusCode == fiber.StatusInternalServerError {
-			// This is expected since we can't mock external API calls easily
 			// Just verify it's a reasonable error response
 			var response map[string]interface{}
 			err = json.Unmarshal(body, &response)
 			assert.NoError(t, err)
 			assert.True(t, len(response) > 0) // Just check that we got some response
-			t.Logf("Test completed with expected external API error: %v", response)
+			t.Logf("Test completed with expected database error: %v", response)
 			return
 		}
 
@@ -400,7 +399,7 @@ func TestFetchFeedbackHandler(t *testing.T) {
 			assert.NoError(t, err)
 
 			if message, ok := response["message"]; ok {
-				assert.Equal(t, "JSONPlaceholder data processed successfully", message)
+				assert.Equal(t, "Synthetic feedbacks saved successfully", message)
 			}
 			// Check success_count safely
 			if successCount, ok := response["success_count"]; ok && successCount != nil {
@@ -463,121 +462,35 @@ func TestValidateBoardExists(t *testing.T) {
 	})
 }
 
-func TestFetchCommentsFromAPI(t *testing.T) {
-	t.Run("Successful API call", func(t *testing.T) {
-		// Create mock server
-		server := createMockJSONPlaceholderServer()
-		defer server.Close()
+func TestGenerateFeedbackHandler(t *testing.T) {
+	app := fiber.New()
+	app.Get("/api/feedbacks/generate", GenerateFeedbackHandler)
 
-		comments, err := fetchCommentsFromAPI(server.URL + "/comments")
+	get := func(query string) (int, map[string]interface{}) {
+		req := httptest.NewRequest(http.MethodGet, "/api/feedbacks/generate"+query, nil)
+		resp, err := app.Test(req)
 		assert.NoError(t, err)
-		assert.Len(t, comments, 2)
-		assert.Equal(t, "laudantium enim quasi est quidem magnam voluptate ipsam eos", comments[0].Body)
-		assert.Equal(t, "Eliseo@gardner.biz", comments[0].Email)
-	})
-
-	t.Run("API returns error status", func(t *testing.T) {
-		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusInternalServerError)
-		}))
-		defer server.Close()
-
-		comments, err := fetchCommentsFromAPI(server.URL)
-		assert.Error(t, err)
-		assert.Nil(t, comments)
-		assert.Contains(t, err.Error(), "external API returned error: 500")
-	})
-
-	t.Run("Invalid JSON response", func(t *testing.T) {
-		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			_, _ = w.Write([]byte("invalid json"))
-		}))
-		defer server.Close()
-
-		comments, err := fetchCommentsFromAPI(server.URL)
-		assert.Error(t, err)
-		assert.Nil(t, comments)
-		assert.Contains(t, err.Error(), "invalid JSON format")
-	})
-
-	t.Run("Empty response", func(t *testing.T) {
-		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			_, _ = w.Write([]byte("[]"))
-		}))
-		defer server.Close()
-
-		comments, err := fetchCommentsFromAPI(server.URL)
-		assert.Error(t, err)
-		assert.Nil(t, comments)
-		assert.Contains(t, err.Error(), "no comment data found")
-	})
-
-	t.Run("Connection timeout", func(t *testing.T) {
-		// Test with invalid URL to simulate connection failure
-		comments, err := fetchCommentsFromAPI("http://invalid-url-that-does-not-exist.com")
-		assert.Error(t, err)
-		assert.Nil(t, comments)
-		assert.Contains(t, err.Error(), "failed to connect to JSONPlaceholder API")
-	})
-}
-
-func TestConvertCommentsToFeedback(t *testing.T) {
-	t.Run("Convert comments to feedbacks", func(t *testing.T) {
-		comments := []Comment{
-			{
-				PostID: 1,
-				ID:     1,
-				Name:   "Test Comment",
-				Email:  "test@example.com",
-				Body:   "This is a test comment",
-			},
-			{
-				PostID: 2,
-				ID:     2,
-				Name:   "Another Comment",
-				Email:  "another@example.com",
-				Body:   "Another test comment",
-			},
-		}
-
-		channels := []string{"twitter", "facebook", "instagram", "web"}
-		boardID := 1
-		// Use a fixed seed for predictable testing
-		rng := rand.New(rand.NewSource(1)) // Fixed seed for reproducible tests
-
-		feedbacks := convertCommentsToFeedback(comments, boardID, channels, rng)
+		var response map[string]interface{}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		return resp.StatusCode, response
+	}
 
-		assert.Len(t, feedbacks, 2)
+	status, first := get("?count=5&seed=42&languages=fr&end=2024-05-01")
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, float64(42), first["seed"])
+	assert.Len(t, first["data"], 5)
 
-		// Check first feedback
-		assert.Equal(t, "This is a test comment", feedbacks[0].Text)
-		assert.Equal(t, boardID, feedbacks[0].BoardID)
-		// Channel will be determined by the random generator with fixed seed
-		assert.Contains(t, channels, feedbacks[0].Channel)
+	// The same seed generates the same feedbacks
+	_, second := get("?count=5&seed=42&languages=fr&end=2024-05-01")
+	assert.Equal(t, first["data"], second["data"])
 
-		// Check second feedback
-		assert.Equal(t, "Another test comment", feedbacks[1].Text)
-		assert.Equal(t, boardID, feedbacks[1].BoardID)
-		assert.Contains(t, channels, feedbacks[1].Channel)
+	status, response := get("?count=0")
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	assert.Contains(t, response["error"], "count must be between 1 and")
 
-		// Check that dates are in the past (within last month)
-		now := time.Now()
-		for _, feedback := range feedbacks {
-			assert.True(t, feedback.Date.Before(now))
-			assert.True(t, feedback.Date.After(now.AddDate(0, -1, -1))) // More than a month ago
-		}
-	})
-	t.Run("Empty comments list", func(t *testing.T) {
-		comments := []Comment{}
-		channels := []string{"twitter", "facebook"}
-		boardID := 1
-		rng := rand.New(rand.NewSource(1))
-
-		feedbacks := convertCommentsToFeedback(comments, boardID, channels, rng)
-		assert.Len(t, feedbacks, 0)
-	})
+	status, response = get("?sentiment=positive")
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	assert.Contains(t, response["error"], "sentiment must be a list")
 }
 
 func TestFetchFeedbackHandler_EdgeCases(t *testing.T) {
@@ -624,8 +537,8 @@ func TestFetchFeedbackHandler_EdgeCases(t *testing.T) {
 			WillReturnRows(userRows)
 
 		// Mock board lookup error
-		mock.ExpectQuery(`SELECT.*FROM "boards".*WHERE.*user_id.*`).
-			WithArgs(1).
+		mock.ExpectQuery(`SELECT.*FROM "boards".*WHERE.*users.uuid.*ORDER BY boards.id ASC LIMIT`).
+			WithArgs("test-user-uuid", 1).
 			WillReturnError(errors.New("board query failed"))
 
 		app := fiber.New()
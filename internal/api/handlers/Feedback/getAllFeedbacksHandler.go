@@ -4,26 +4,36 @@ import (
 	"github.com/getsentry/sentry-go"
 	"github.com/gofiber/fiber/v2"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
 	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
 )
 
 // GetAllFeedbacksHandler godoc
 // @Summary Get all feedbacks
-// @Description Fetch all feedbacks from the database
+// @Description Fetch all feedbacks of the board with their tags
 // @Tags Feedback
 // @Produce json
-// @Success 200 {object} Feedback.Feedback "List of feedbacks"
+// @Success 200 {array} Feedback.Feedback "List of feedbacks"
+// @Failure 401 {object} ErrorResponse "Unauthorized error"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /api/feedbacks [get]
 func GetAllFeedbacksHandler(c *fiber.Ctx) error {
-	feedbacks, err := Feedback.GetAllFeedbacks()
+	bc, err := resolveBoardContext(c, "GetAllFeedbacksHandler", "fetch_feedbacks")
 	if err != nil {
-		sentry.CaptureEvent(&sentry.Event{
+		return fiberError(c, err)
+	}
+
+	feedbacks, err := Feedback.GetFeedbacksByBoardID(bc.BoardID)
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: "Failed to fetch feedbacks",
 			Level:   sentry.LevelError,
 			Extra: map[string]interface{}{
 				"error": err.Error(),
 			},
+			User: sentry.User{
+				ID: bc.UserUUID,
+			},
 			Tags: map[string]string{
 				"handler": "GetAllFeedbacksHandler",
 				"action":  "fetch_feedbacks",
@@ -34,7 +44,7 @@ func GetAllFeedbacksHandler(c *fiber.Ctx) error {
 		})
 	}
 
-	var result []feedbackModel.Feedback
+	result := make([]feedbackModel.Feedback, 0, len(feedbacks))
 	for _, fb := range feedbacks {
 		cached, err := Feedback.GetFeedbackFromCache(fb.Id)
 		if err == nil {
@@ -17,9 +17,7 @@ import (
 
 // setupTestApp creates a test Fiber app with the handler
 func setupTestApp() *fiber.App {
-	app := fiber.New()
-	app.Get("/api/feedbacks", GetAllFeedbacksHandler)
-	return app
+	return newTriageApp(fiber.MethodGet, "/api/feedbacks", GetAllFeedbacksHandler)
 }
 
 func TestGetAllFeedbacksHandler_Success(t *testing.T) {
@@ -39,7 +37,14 @@ func TestGetAllFeedbacksHandler_Success(t *testing.T) {
 		AddRow(1, now, now, feedbackDate, "email", "Great product!", 1).
 		AddRow(2, now, now, feedbackDate.Add(24*time.Hour), "web", "Could be improved", 1)
 
-	mock.ExpectQuery(`SELECT \* FROM "feedbacks"`).WillReturnRows(rows)
+	expectBoardContext(mock, "test-user-uuid", 2, 1)
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE board_id = \$1 AND "feedbacks"."deleted_at" IS NULL ORDER BY id`).WillReturnRows(rows)
+	mock.ExpectQuery(`SELECT \* FROM "feedback_tags" WHERE "feedback_tags"."feedback_id" IN \(\$1,\$2\)`).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"feedback_id", "tag_id"}).AddRow(1, 4))
+	mock.ExpectQuery(`SELECT \* FROM "tags" WHERE "tags"."id" = \$1`).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "name", "color"}).AddRow(4, 1, "praise", "#00aa00"))
 
 	// Create test request
 	req := httptest.NewRequest(http.MethodGet, "/api/feedbacks", nil)
@@ -71,6 +76,9 @@ func TestGetAllFeedbacksHandler_Success(t *testing.T) {
 	assert.Equal(t, "email", actualFeedbacks[0].Channel)
 	assert.Equal(t, "Great product!", actualFeedbacks[0].Text)
 	assert.Equal(t, 1, actualFeedbacks[0].BoardID)
+	if assert.Len(t, actualFeedbacks[0].Tags, 1) {
+		assert.Equal(t, "praise", actualFeedbacks[0].Tags[0].Name)
+	}
 
 	// Check second feedback
 	assert.Equal(t, 2, actualFeedbacks[1].BaseModel.Id)
@@ -90,7 +98,8 @@ func TestGetAllFeedbacksHandler_DatabaseError(t *testing.T) {
 
 	// Mock database error
 	expectedError := errors.New("database connection failed")
-	mock.ExpectQuery(`SELECT \* FROM "feedbacks"`).WillReturnError(expectedError)
+	expectBoardContext(mock, "test-user-uuid", 2, 1)
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE board_id = \$1 AND "feedbacks"."deleted_at" IS NULL ORDER BY id`).WillReturnError(expectedError)
 
 	// Create test request
 	req := httptest.NewRequest(http.MethodGet, "/api/feedbacks", nil)
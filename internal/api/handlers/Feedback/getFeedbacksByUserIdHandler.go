@@ -7,6 +7,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
 )
 
 // ErrorResponse represents the error response structure
@@ -21,6 +22,13 @@ type ErrorResponse struct {
 // @Accept json
 // @Produce json
 // @Param channel query string false "Filter feedbacks by channel"
+// @Param status query string false "Filter feedbacks by triage status, comma-separated: new, triaged, in_progress, resolved, ignored"
+// @Param priority query string false "Filter feedbacks by priority: low, medium, high or urgent"
+// @Param assignee_id query string false "Filter feedbacks by assignee user ID, none for the unassigned ones"
+// @Param tag query string false "Filter feedbacks carrying one of the tag names, comma-separated"
+// @Param q query string false "Filter feedbacks whose text contains the search, case insensitive"
+// @Param metadata.{key} query string false "Filter feedbacks on the value of a metadata key, e.g. metadata.plan=pro"
+// @Param group_by query string false "Metadata key to group the feedbacks on, feedbacks without it are grouped under (none)"
 // @Success 200 {array} Feedback.FeedbackWithAnalysis "List of feedbacks with analyses"
 // @Failure 400 {object} ErrorResponse "Bad request error"
 // @Failure 401 {object} ErrorResponse "Unauthorized error"
@@ -31,7 +39,7 @@ func GetFeedbacksByUserIdHandler(c *fiber.Ctx) error {
 	// Get user UUID from context
 	userUUID, check := middleware.GetUserUUID(c)
 	if !check {
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: "Unauthorized access attempt: user not found in context",
 			Level:   sentry.LevelError,
 			User: sentry.User{
@@ -47,13 +55,18 @@ func GetFeedbacksByUserIdHandler(c *fiber.Ctx) error {
 		})
 	}
 
-	// Get channel if specified
-	channel := c.Query("channel", "")
+	// Get channel, triage and metadata filters if specified
+	filter, err := parseListingFilter(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
 
-	feedbacks, err := Feedback.GetFeedbacksWithAnalysesByUserId(userUUID, channel)
+	feedbacks, err := Feedback.GetFeedbacksWithAnalysesByUserId(userUUID, filter)
 	if err != nil {
 		if errors.Is(err, Feedback.ErrUserNotFound) {
-			sentry.CaptureEvent(&sentry.Event{
+			middleware.CaptureEvent(c, &sentry.Event{
 				Message: "User not found while retrieving feedbacks",
 				Level:   sentry.LevelError,
 				User: sentry.User{
@@ -69,7 +82,7 @@ func GetFeedbacksByUserIdHandler(c *fiber.Ctx) error {
 			})
 		}
 		if errors.Is(err, Feedback.ErrBoardNotFound) {
-			sentry.CaptureEvent(&sentry.Event{
+			middleware.CaptureEvent(c, &sentry.Event{
 				Message: "No boards found for user while retrieving feedbacks",
 				Level:   sentry.LevelError,
 				User: sentry.User{
@@ -84,7 +97,7 @@ func GetFeedbacksByUserIdHandler(c *fiber.Ctx) error {
 				"error": "No boards found for this user",
 			})
 		}
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: "Error retrieving feedbacks for user",
 			Level:   sentry.LevelError,
 			Extra: map[string]interface{}{
@@ -113,5 +126,17 @@ func GetFeedbacksByUserIdHandler(c *fiber.Ctx) error {
 		}
 	}
 
+	if groupBy := c.Query("group_by"); groupBy != "" {
+		groups := make(map[string][]feedbackModel.FeedbackWithAnalysis)
+		for _, fb := range feedbacks {
+			group := fb.Metadata.Group(groupBy)
+			groups[group] = append(groups[group], fb)
+		}
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"group_by": groupBy,
+			"groups":   groups,
+		})
+	}
+
 	return c.Status(fiber.StatusOK).JSON(feedbacks)
 }
@@ -49,7 +49,7 @@ func TestGetFeedbacksByUserIdHandler_Success(t *testing.T) {
 		AddRow(1, testDate, "email", "Great service!", 1, 0.8, "Service").
 		AddRow(2, testDate, "web", "Could be better", 1, 0.3, "Quality")
 
-	mock.ExpectQuery(`SELECT feedbacks\.\*, analyses\.\* FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1`).
+	mock.ExpectQuery(`SELECT feedbacks\.id AS feedback_id, (.+), \(SELECT COUNT\(\*\) FROM feedback_comments WHERE feedback_comments.feedback_id = feedbacks.id\) AS comment_count FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1 AND feedbacks.deleted_at IS NULL`).
 		WithArgs(1).
 		WillReturnRows(mockFeedbacks1)
 
@@ -60,9 +60,14 @@ func TestGetFeedbacksByUserIdHandler_Success(t *testing.T) {
 	}).
 		AddRow(3, testDate, "mobile", "Excellent app!", 2, 0.9, "Product")
 
-	mock.ExpectQuery(`SELECT feedbacks\.\*, analyses\.\* FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1`).
+	mock.ExpectQuery(`SELECT feedbacks\.id AS feedback_id, (.+), \(SELECT COUNT\(\*\) FROM feedback_comments WHERE feedback_comments.feedback_id = feedbacks.id\) AS comment_count FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1 AND feedbacks.deleted_at IS NULL`).
 		WithArgs(2).
 		WillReturnRows(mockFeedbacks2)
+
+	// 6. Get the tags of the listed feedbacks
+	mock.ExpectQuery(`SELECT feedback_tags.feedback_id, tags.name FROM "feedback_tags" JOIN tags ON tags.id = feedback_tags.tag_id WHERE feedback_tags.feedback_id IN`).
+		WithArgs(1, 2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"feedback_id", "name"}))
 	// Create test request with user context
 	req := httptest.NewRequest(http.MethodGet, "/api/feedbacks/analyses", nil)
 	req.Header.Set("Content-Type", "application/json")
@@ -277,7 +282,7 @@ func TestGetFeedbacksByUserIdHandler_DatabaseError(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"board_id"}).AddRow(1))
 
 	// Mock database error when fetching feedbacks
-	mock.ExpectQuery(`SELECT feedbacks\.\*, analyses\.\* FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1`).
+	mock.ExpectQuery(`SELECT feedbacks\.id AS feedback_id, (.+), \(SELECT COUNT\(\*\) FROM feedback_comments WHERE feedback_comments.feedback_id = feedbacks.id\) AS comment_count FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1 AND feedbacks.deleted_at IS NULL`).
 		WithArgs(1).
 		WillReturnError(errors.New("database connection failed"))
 
@@ -0,0 +1,97 @@
+package Feedback
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	importJobDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/ImportJob"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	importJobModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/ImportJob"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
+)
+
+// GetImportJobsHandler godoc
+// @Summary List the import jobs of the board
+// @Description List the latest file imports of the board with their progress, newest first. The per-row error report is only returned by the job endpoint.
+// @Tags Feedback
+// @Produce json
+// @Param limit query int false "Maximum number of jobs to return (default 20)"
+// @Success 200 {array} ImportJob.ImportJobProgress "List of import jobs"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/feedbacks/imports [get]
+func GetImportJobsHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "GetImportJobsHandler", "get_import_jobs")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	jobs, err := importJobDB.GetImportJobsByBoardID(bc.BoardID, c.QueryInt("limit", 20))
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to retrieve import jobs for board %d: %v", bc.BoardID, err),
+			Level:   sentry.LevelError,
+			User: sentry.User{
+				ID: bc.UserUUID,
+			},
+			Tags: map[string]string{
+				"handler": "GetImportJobsHandler",
+				"action":  "get_import_jobs",
+			},
+		})
+		return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to retrieve import jobs"))
+	}
+
+	progress := make([]importJobModel.ImportJobProgress, len(jobs))
+	for i, job := range jobs {
+		progress[i] = importJobModel.ImportJobProgress{ImportJob: job, Progress: job.Progress()}
+	}
+	return c.Status(fiber.StatusOK).JSON(progress)
+}
+
+// GetImportJobHandler godoc
+// @Summary Get an import job
+// @Description Get the progress of a file import and its per-row error report
+// @Tags Feedback
+// @Produce json
+// @Param id path int true "Import job ID"
+// @Success 200 {object} ImportJob.ImportJobProgress "Import job"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Import job not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/feedbacks/imports/{id} [get]
+func GetImportJobHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "GetImportJobHandler", "get_import_job")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid import job id"))
+	}
+
+	job, err := importJobDB.GetImportJob(bc.BoardID, id)
+	if err != nil {
+		if errors.Is(err, importJobDB.ErrImportJobNotFound) {
+			return httpUtils.NewError(c, fiber.StatusNotFound, err)
+		}
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to retrieve import job %d: %v", id, err),
+			Level:   sentry.LevelError,
+			User: sentry.User{
+				ID: bc.UserUUID,
+			},
+			Tags: map[string]string{
+				"handler": "GetImportJobHandler",
+				"action":  "get_import_job",
+			},
+		})
+		return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to retrieve import job"))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(importJobModel.ImportJobProgress{ImportJob: job, Progress: job.Progress()})
+}
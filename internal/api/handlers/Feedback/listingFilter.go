@@ -0,0 +1,60 @@
+package Feedback
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+)
+
+// parseListingFilter reads the filters of the feedback listing from the query string
+func parseListingFilter(c *fiber.Ctx) (feedbackDB.Filter, error) {
+	filter := feedbackDB.Filter{
+		Channel:  c.Query("channel", ""),
+		Metadata: feedbackModel.ParseMetadataFilters(c.Queries()),
+		Priority: c.Query("priority", ""),
+		Search:   strings.TrimSpace(c.Query("q", "")),
+	}
+
+	if tags := c.Query("tag"); tags != "" {
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				filter.Tags = append(filter.Tags, tag)
+			}
+		}
+	}
+
+	if statuses := c.Query("status"); statuses != "" {
+		for _, status := range strings.Split(statuses, ",") {
+			status = strings.TrimSpace(status)
+			if !slices.Contains(feedbackModel.Statuses, status) {
+				return filter, fmt.Errorf("status must be one of %s", strings.Join(feedbackModel.Statuses, ", "))
+			}
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+
+	if filter.Priority != "" && !slices.Contains(feedbackModel.Priorities, filter.Priority) {
+		return filter, fmt.Errorf("priority must be one of %s", strings.Join(feedbackModel.Priorities, ", "))
+	}
+
+	switch assignee := c.Query("assignee_id"); assignee {
+	case "":
+	case "none":
+		unassigned := 0
+		filter.AssigneeID = &unassigned
+	default:
+		assigneeID, err := strconv.Atoi(assignee)
+		if err != nil || assigneeID <= 0 {
+			return filter, errors.New("assignee_id must be a user ID or none")
+		}
+		filter.AssigneeID = &assigneeID
+	}
+
+	return filter, nil
+}
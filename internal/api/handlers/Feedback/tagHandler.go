@@ -0,0 +1,254 @@
+package Feedback
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	tagDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Tag"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	tagModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Tag"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
+)
+
+// maxBulkTagging is the maximum number of feedbacks tagged or untagged by a request
+const maxBulkTagging = 500
+
+// TaggingRequest lists the tags to apply to or remove from feedbacks
+type TaggingRequest struct {
+	FeedbackIDs []int `json:"feedback_ids"`
+	TagIDs      []int `json:"tag_ids"`
+}
+
+// GetTagsHandler godoc
+// @Summary List the tags of the board
+// @Description List the tags of the board sorted by name
+// @Tags Tag
+// @Produce json
+// @Success 200 {array} Tag.Tag "List of tags"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/tags [get]
+func GetTagsHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "GetTagsHandler", "get_tags")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	tags, err := tagDB.GetTagsByBoardID(bc.BoardID)
+	if err != nil {
+		return tagError(c, bc, "GetTagsHandler", "get_tags", err)
+	}
+	return c.Status(fiber.StatusOK).JSON(tags)
+}
+
+// CreateTagHandler godoc
+// @Summary Create a tag
+// @Description Create a tag on the board, its name must be unique on the board and its color a #rrggbb hex color
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Param tag body Tag.TagJson true "Tag name and color"
+// @Success 201 {object} Tag.Tag "Created tag"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 409 {object} httpUtils.HTTPError "Tag name already used"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/tags [post]
+func CreateTagHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "CreateTagHandler", "create_tag")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	var body tagModel.TagJson
+	if err := c.BodyParser(&body); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid request payload"))
+	}
+	body.Name = strings.TrimSpace(body.Name)
+	if err := body.Validate(false); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+	}
+
+	tag, err := tagDB.CreateTag(tagModel.Tag{
+		BoardID: bc.BoardID,
+		Name:    body.Name,
+		Color:   strings.ToLower(body.Color),
+	})
+	if err != nil {
+		return tagError(c, bc, "CreateTagHandler", "create_tag", err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(tag)
+}
+
+// UpdateTagHandler godoc
+// @Summary Update a tag
+// @Description Rename or recolor a tag of the board, empty fields are kept
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Param tag body Tag.TagJson true "New tag name or color"
+// @Success 200 {object} Tag.Tag "Updated tag"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Tag not found"
+// @Failure 409 {object} httpUtils.HTTPError "Tag name already used"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/tags/{id} [patch]
+func UpdateTagHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "UpdateTagHandler", "update_tag")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid tag id"))
+	}
+
+	var body tagModel.TagJson
+	if err := c.BodyParser(&body); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid request payload"))
+	}
+	body.Name = strings.TrimSpace(body.Name)
+	body.Color = strings.ToLower(body.Color)
+	if err := body.Validate(true); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+	}
+
+	tag, err := tagDB.UpdateTag(bc.BoardID, id, body)
+	if err != nil {
+		return tagError(c, bc, "UpdateTagHandler", "update_tag", err)
+	}
+	return c.Status(fiber.StatusOK).JSON(tag)
+}
+
+// DeleteTagHandler godoc
+// @Summary Delete a tag
+// @Description Delete a tag of the board and remove it from its feedbacks
+// @Tags Tag
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Success 200 {object} httpUtils.HTTPMessage "Tag deleted"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Tag not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/tags/{id} [delete]
+func DeleteTagHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "DeleteTagHandler", "delete_tag")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid tag id"))
+	}
+
+	if err := tagDB.DeleteTag(bc.BoardID, id); err != nil {
+		return tagError(c, bc, "DeleteTagHandler", "delete_tag", err)
+	}
+	return httpUtils.NewMessage(c, fiber.StatusOK, "Tag deleted")
+}
+
+// ApplyTagsHandler godoc
+// @Summary Tag feedbacks
+// @Description Put tags on up to 500 feedbacks of the board, nothing is changed when a feedback or tag is not found
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Param tagging body TaggingRequest true "Feedback IDs and tag IDs"
+// @Success 200 {object} httpUtils.HTTPMessage "Tags applied"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Feedback or tag not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/tags/apply [post]
+func ApplyTagsHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "ApplyTagsHandler", "apply_tags")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	body, err := parseTaggingRequest(c)
+	if err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+	}
+	if err := tagDB.ApplyTags(bc.BoardID, body.FeedbackIDs, body.TagIDs); err != nil {
+		return tagError(c, bc, "ApplyTagsHandler", "apply_tags", err)
+	}
+	return httpUtils.NewMessage(c, fiber.StatusOK, "Tags applied")
+}
+
+// RemoveTagsHandler godoc
+// @Summary Untag feedbacks
+// @Description Remove tags from up to 500 feedbacks of the board, nothing is changed when a feedback or tag is not found
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Param tagging body TaggingRequest true "Feedback IDs and tag IDs"
+// @Success 200 {object} httpUtils.HTTPMessage "Tags removed"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Feedback or tag not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/tags/remove [post]
+func RemoveTagsHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "RemoveTagsHandler", "remove_tags")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	body, err := parseTaggingRequest(c)
+	if err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+	}
+	if err := tagDB.RemoveTags(bc.BoardID, body.FeedbackIDs, body.TagIDs); err != nil {
+		return tagError(c, bc, "RemoveTagsHandler", "remove_tags", err)
+	}
+	return httpUtils.NewMessage(c, fiber.StatusOK, "Tags removed")
+}
+
+func parseTaggingRequest(c *fiber.Ctx) (TaggingRequest, error) {
+	var body TaggingRequest
+	if err := c.BodyParser(&body); err != nil {
+		return body, errors.New("invalid request payload")
+	}
+	if len(body.FeedbackIDs) == 0 {
+		return body, errors.New("feedback_ids is required")
+	}
+	if len(body.TagIDs) == 0 {
+		return body, errors.New("tag_ids is required")
+	}
+	if len(body.FeedbackIDs) > maxBulkTagging {
+		return body, fmt.Errorf("at most %d feedbacks can be tagged at once", maxBulkTagging)
+	}
+	return body, nil
+}
+
+// tagError writes the response for a failed tag request, database errors are captured in Sentry
+func tagError(c *fiber.Ctx, bc boardContext, handler, action string, err error) error {
+	switch {
+	case errors.Is(err, tagDB.ErrTagNotFound), errors.Is(err, feedbackDB.ErrFeedbackNotFound):
+		return httpUtils.NewError(c, fiber.StatusNotFound, err)
+	case errors.Is(err, tagDB.ErrTagExists):
+		return httpUtils.NewError(c, fiber.StatusConflict, err)
+	}
+	middleware.CaptureEvent(c, &sentry.Event{
+		Message: fmt.Sprintf("Failed to %s for board %d: %v", strings.ReplaceAll(action, "_", " "), bc.BoardID, err),
+		Level:   sentry.LevelError,
+		User: sentry.User{
+			ID: bc.UserUUID,
+		},
+		Tags: map[string]string{
+			"handler": handler,
+			"action":  action,
+		},
+	})
+	return httpUtils.NewError(c, fiber.StatusInternalServerError, fmt.Errorf("failed to %s", strings.ReplaceAll(action, "_", " ")))
+}
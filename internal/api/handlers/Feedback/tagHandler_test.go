@@ -0,0 +1,75 @@
+package Feedback
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTagHandler(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	expectBoardContext(mock, "test-user-uuid", 2, 1)
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "tags" WHERE board_id = \$1 AND name = \$2 AND id <> \$3`).
+		WithArgs(1, "bug", 0).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "tags"`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1, "bug", "#ff00aa").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+	mock.ExpectCommit()
+
+	app := newTriageApp(fiber.MethodPost, "/api/tags", CreateTagHandler)
+	req := httptest.NewRequest(fiber.MethodPost, "/api/tags", strings.NewReader(`{"name":" bug ","color":"#FF00AA"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode, string(body))
+	assert.Contains(t, string(body), `"name":"bug"`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateTagHandler_InvalidColor(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	expectBoardContext(mock, "test-user-uuid", 2, 1)
+
+	app := newTriageApp(fiber.MethodPost, "/api/tags", CreateTagHandler)
+	req := httptest.NewRequest(fiber.MethodPost, "/api/tags", strings.NewReader(`{"name":"bug","color":"red"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApplyTagsHandler_TagNotFound(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	expectBoardContext(mock, "test-user-uuid", 2, 1)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "feedbacks" WHERE \(board_id = \$1 AND id IN \(\$2\)\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(1, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "tags" WHERE board_id = \$1 AND id IN \(\$2\)`).
+		WithArgs(1, 9).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectRollback()
+
+	app := newTriageApp(fiber.MethodPost, "/api/tags/apply", ApplyTagsHandler)
+	req := httptest.NewRequest(fiber.MethodPost, "/api/tags/apply", strings.NewReader(`{"feedback_ids":[10],"tag_ids":[9]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
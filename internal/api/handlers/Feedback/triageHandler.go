@@ -0,0 +1,166 @@
+package Feedback
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
+)
+
+// maxBulkTriage is the maximum number of feedbacks changed by a bulk triage request
+const maxBulkTriage = 500
+
+// BulkTriageRequest is a triage update applied to several feedbacks
+type BulkTriageRequest struct {
+	IDs []int `json:"ids"`
+	feedbackModel.TriageUpdate
+}
+
+// TriageFeedbackHandler godoc
+// @Summary Triage a feedback
+// @Description Change the status, assignee or priority of a feedback of the board, each change is recorded in its history.
+// @Description Statuses are new, triaged, in_progress, resolved and ignored, priorities are low, medium, high and urgent.
+// @Description The assignee must be a member of the board, assignee_id 0 unassigns the feedback and an empty priority clears it.
+// @Tags Feedback
+// @Accept json
+// @Produce json
+// @Param id path int true "Feedback ID"
+// @Param update body Feedback.TriageUpdate true "Fields to change"
+// @Success 200 {object} Feedback.Feedback "Updated feedback"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Feedback not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/feedbacks/{id}/triage [patch]
+func TriageFeedbackHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "TriageFeedbackHandler", "triage_feedback")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid feedback id"))
+	}
+
+	var update feedbackModel.TriageUpdate
+	if err := c.BodyParser(&update); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid request payload"))
+	}
+	if err := update.Validate(); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+	}
+
+	feedbacks, err := feedbackDB.TriageFeedbacks(bc.BoardID, []int{id}, update, actorID(bc))
+	if err != nil {
+		return triageError(c, bc, "TriageFeedbackHandler", err)
+	}
+	return c.Status(fiber.StatusOK).JSON(feedbacks[0])
+}
+
+// BulkTriageHandler godoc
+// @Summary Triage several feedbacks
+// @Description Apply the same status, assignee or priority to up to 500 feedbacks of the board.
+// @Description Nothing is changed when one of the feedbacks is not found.
+// @Tags Feedback
+// @Accept json
+// @Produce json
+// @Param update body BulkTriageRequest true "Feedback IDs and fields to change"
+// @Success 200 {array} Feedback.Feedback "Updated feedbacks"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Feedback not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/feedbacks/triage [post]
+func BulkTriageHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "BulkTriageHandler", "bulk_triage_feedbacks")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	var body BulkTriageRequest
+	if err := c.BodyParser(&body); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid request payload"))
+	}
+	if len(body.IDs) == 0 {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("ids is required"))
+	}
+	if len(body.IDs) > maxBulkTriage {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, fmt.Errorf("at most %d feedbacks can be triaged at once", maxBulkTriage))
+	}
+	if err := body.TriageUpdate.Validate(); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+	}
+
+	feedbacks, err := feedbackDB.TriageFeedbacks(bc.BoardID, body.IDs, body.TriageUpdate, actorID(bc))
+	if err != nil {
+		return triageError(c, bc, "BulkTriageHandler", err)
+	}
+	return c.Status(fiber.StatusOK).JSON(feedbacks)
+}
+
+// GetFeedbackTransitionsHandler godoc
+// @Summary Get the triage history of a feedback
+// @Description List the changes of status, assignee and priority of a feedback of the board, oldest first
+// @Tags Feedback
+// @Produce json
+// @Param id path int true "Feedback ID"
+// @Success 200 {array} Feedback.Transition "Triage history"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Feedback not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/feedbacks/{id}/transitions [get]
+func GetFeedbackTransitionsHandler(c *fiber.Ctx) error {
+	bc, err := resolveBoardContext(c, "GetFeedbackTransitionsHandler", "get_feedback_transitions")
+	if err != nil {
+		return fiberError(c, err)
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid feedback id"))
+	}
+
+	transitions, err := feedbackDB.GetFeedbackTransitions(bc.BoardID, id)
+	if err != nil {
+		return triageError(c, bc, "GetFeedbackTransitionsHandler", err)
+	}
+	return c.Status(fiber.StatusOK).JSON(transitions)
+}
+
+// actorID returns the user making a change, nil for an API key
+func actorID(bc boardContext) *int {
+	if bc.UserID == 0 {
+		return nil
+	}
+	userID := bc.UserID
+	return &userID
+}
+
+// triageError writes the response for a failed triage request, database errors are captured in Sentry
+func triageError(c *fiber.Ctx, bc boardContext, handler string, err error) error {
+	switch {
+	case errors.Is(err, feedbackDB.ErrFeedbackNotFound):
+		return httpUtils.NewError(c, fiber.StatusNotFound, err)
+	case errors.Is(err, feedbackDB.ErrAssigneeNotMember):
+		return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+	}
+	middleware.CaptureEvent(c, &sentry.Event{
+		Message: fmt.Sprintf("Failed to triage feedbacks of board %d: %v", bc.BoardID, err),
+		Level:   sentry.LevelError,
+		User: sentry.User{
+			ID: bc.UserUUID,
+		},
+		Tags: map[string]string{
+			"handler": handler,
+			"action":  "triage_feedbacks",
+		},
+	})
+	return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to triage feedbacks"))
+}
@@ -0,0 +1,90 @@
+package Feedback
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// expectBoardContext mocks the lookups of resolveBoardContext for a user session
+func expectBoardContext(mock sqlmock.Sqlmock, userUUID string, userID, boardID int) {
+	mock.ExpectQuery(`SELECT id, email FROM "users" WHERE uuid = \$1`).
+		WithArgs(userUUID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(userID, "user@example.com"))
+	mock.ExpectQuery(`SELECT "boards"(.+) FROM "boards" JOIN user_boards (.+) WHERE users.uuid = \$1 AND "boards"."deleted_at" IS NULL ORDER BY boards.id ASC LIMIT \$2`).
+		WithArgs(userUUID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(boardID, "Board"))
+}
+
+func newTriageApp(method, path string, handler fiber.Handler) *fiber.App {
+	app := fiber.New()
+	app.Add(method, path, func(c *fiber.Ctx) error {
+		c.Locals("userUUID", "test-user-uuid")
+		return handler(c)
+	})
+	return app
+}
+
+func TestTriageFeedbackHandler(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	expectBoardContext(mock, "test-user-uuid", 2, 1)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE \(board_id = \$1 AND id IN \(\$2\)\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(1, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "status"}).AddRow(10, 1, "new"))
+	mock.ExpectExec(`UPDATE "feedbacks" SET "status"=\$1`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO "feedback_transitions"`).
+		WithArgs(10, "status", "new", "resolved", 2, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	app := newTriageApp("PATCH", "/api/feedbacks/:id/triage", TriageFeedbackHandler)
+	req := httptest.NewRequest("PATCH", "/api/feedbacks/10/triage", strings.NewReader(`{"status":"resolved"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `"status":"resolved"`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBulkTriageHandler_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		contains string
+	}{
+		{"Missing ids", `{"status":"triaged"}`, "ids is required"},
+		{"Nothing to change", `{"ids":[1]}`, "at least one of status"},
+		{"Unknown status", `{"ids":[1],"status":"done"}`, "status must be one of"},
+		{"Unknown priority", `{"ids":[1],"priority":"asap"}`, "priority must be one of"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, cleanup := setupMockDB(t)
+			defer cleanup()
+			expectBoardContext(mock, "test-user-uuid", 2, 1)
+
+			app := newTriageApp("POST", "/api/feedbacks/triage", BulkTriageHandler)
+			req := httptest.NewRequest("POST", "/api/feedbacks/triage", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+			body, _ := io.ReadAll(resp.Body)
+			assert.Contains(t, string(body), tt.contains)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
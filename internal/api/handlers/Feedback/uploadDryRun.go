@@ -0,0 +1,73 @@
+package Feedback
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/feedbackImport"
+)
+
+// dryRunUpload runs an upload without saving nor analyzing anything and returns what the import would do
+func dryRunUpload(c *fiber.Ctx, bc boardContext, file io.Reader, filename, format string, opts feedbackImport.CsvOptions) error {
+	previewSize := c.QueryInt("preview", defaultPreviewSize)
+	if previewSize < 0 || previewSize > maxPreviewSize {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("preview must be between 0 and %d", maxPreviewSize),
+		})
+	}
+
+	dryRun := feedbackImport.NewDryRun(bc.BoardID, previewSize)
+	var preview feedbackImport.Preview
+	storeErr, err := feedbackImport.Decode(file, format, opts, dryRun)
+	if storeErr == nil && err == nil {
+		preview, storeErr = dryRun.Finish()
+	}
+	if storeErr != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Database error during upload dry run on board %d: %v", bc.BoardID, storeErr),
+			Level:   sentry.LevelError,
+			Extra: map[string]interface{}{
+				"board_id": bc.BoardID,
+				"filename": filename,
+			},
+			User: sentry.User{
+				ID:    bc.UserUUID,
+				Email: bc.UserEmail,
+			},
+			Tags: map[string]string{
+				"handler": "UploadFeedbackFileHandler",
+				"action":  "upload_feedback_file_dry_run",
+			},
+		})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Database error: " + storeErr.Error(),
+		})
+	}
+	if err != nil {
+		if format == feedbackImport.FormatCSV {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid CSV format: " + err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid JSON format: " + err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message":       "Dry run, nothing was saved",
+		"dry_run":       true,
+		"format":        format,
+		"total":         preview.TotalRows,
+		"valid_count":   preview.ValidCount,
+		"new_count":     preview.NewCount,
+		"updated_count": preview.UpdatedCount,
+		"skipped_count": preview.DuplicateCount,
+		"error_count":   preview.ErrorCount,
+		"errors":        preview.Errors,
+		"preview":       preview.Rows,
+	})
+}
@@ -1,109 +1,107 @@
 package Feedback
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/getsentry/sentry-go"
-	"io"
-
-	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
-	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
-	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
-	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/User"
+	"mime"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
-	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	importJobDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/ImportJob"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
 	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	importJobModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/ImportJob"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/feedbackImport"
+)
+
+// IdempotencyKeyHeader lets clients retry an upload without importing the file twice
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+const (
+	// maxIdempotencyKeyLength is the longest Idempotency-Key accepted
+	maxIdempotencyKeyLength = 255
+)
+
+const (
+	// defaultPreviewSize is the number of rows returned by a dry run
+	defaultPreviewSize = 20
+	// maxPreviewSize is the largest preview a dry run may ask for
+	maxPreviewSize = 100
 )
 
 // UploadFeedbackFileHandler godoc
 // @Summary Upload a file containing feedbacks
-// @Description Process a JSON file upload containing feedback data and store it in the database
+// @Description Stream a JSON array, NDJSON or CSV file into the board as an import job.
+// @Description Rows are saved in batches, then analyzed in the background; follow the job with /api/feedbacks/imports/{id}
+// @Description With dry_run=true the file is parsed, validated and checked for duplicates without saving or analyzing anything
 // @Tags Feedback
 // @Accept multipart/form-data
 // @Produce json
-// @Param file formData file true "JSON file containing feedback data"
-// @Success 200 {object} map[string]interface{} "JSONPlaceholder data processed successfully"
+// @Param file formData file true "JSON, NDJSON or CSV file containing feedback data"
+// @Param delimiter query string false "CSV delimiter (',', ';', 'tab', 'pipe'), detected when omitted"
+// @Param header query string false "Whether the CSV has a header row: auto, true or false" default(auto)
+// @Param date_column query string false "CSV column holding the date, header name or 0-based index"
+// @Param channel_column query string false "CSV column holding the channel, header name or 0-based index"
+// @Param text_column query string false "CSV column holding the text, header name or 0-based index"
+// @Param external_id_column query string false "CSV column holding the external ID, header name or 0-based index"
+// @Param customer_id_column query string false "CSV column holding the customer ID, header name or 0-based index"
+// @Param customer_name_column query string false "CSV column holding the customer name, header name or 0-based index"
+// @Param customer_email_column query string false "CSV column holding the customer email, header name or 0-based index"
+// @Param rating_column query string false "CSV column holding the rating, header name or 0-based index"
+// @Param rating_scale query int false "Scale of the CSV ratings: 5 (1 to 5 stars, default) or 10 (0 to 10)"
+// @Param dry_run query boolean false "Preview the import without saving anything" default(false)
+// @Param preview query int false "Number of rows returned by a dry run (max 100)" default(20)
+// @Param Idempotency-Key header string false "Key making retries of the same upload return the first import"
+// @Success 200 {object} map[string]interface{} "Every row was already imported, replay of an idempotent upload, or dry run preview"
+// @Success 202 {object} map[string]interface{} "File imported, analysis in progress"
 // @Failure 400 {object} ErrorResponse "Bad request error"
 // @Failure 401 {object} ErrorResponse "Unauthorized error"
 // @Failure 500 {object} ErrorResponse "Internal server error"
 // @Router /api/feedbacks/upload [post]
 func UploadFeedbackFileHandler(c *fiber.Ctx) error {
-	// Get user UUID from context
-	userUUID, check := middleware.GetUserUUID(c)
-	if !check {
-		sentry.CaptureEvent(&sentry.Event{
-			Message: "Unauthorized: user not found in context",
-			Level:   sentry.LevelError,
-			User: sentry.User{
-				ID: userUUID,
-			},
-			Tags: map[string]string{
-				"handler": "UploadFeedbackFileHandler",
-				"action":  "upload_feedback_file",
-			},
-		})
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Unauthorized: user not found in context",
-		})
+	bc, err := resolveBoardContext(c, "UploadFeedbackFileHandler", "upload_feedback_file")
+	if err != nil {
+		return fiberError(c, err)
 	}
+	boardID := bc.BoardID
+	userUUID := bc.UserUUID
+	userId := bc.UserID
+	userEmail := bc.UserEmail
 
-	// Get user ID from userUUID
-	// Get user ID from userUUID
-	var userId int
-	var userEmail string
-	var u User.User
-	err := database.DB.Model(&User.User{}).Where("uuid = ?", userUUID).Select("id, email").Scan(&u).Error
-	if err != nil {
-		sentry.CaptureEvent(&sentry.Event{
-			Message: fmt.Sprintf("Failed to retrieve user ID for UUID %s: %v", userUUID, err),
+	// Check if the board exists
+	if err := validateBoardExists(boardID); err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Board with ID %d does not exist: %v", boardID, err),
 			Level:   sentry.LevelError,
 			Extra: map[string]interface{}{
-				"error": err.Error(),
-			},
-			User: sentry.User{
-				ID: userUUID,
+				"board_id": boardID,
+				"error":    err.Error(),
 			},
 			Tags: map[string]string{
 				"handler": "UploadFeedbackFileHandler",
 				"action":  "upload_feedback_file",
 			},
 		})
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to retrieve user ID",
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
 		})
 	}
-	userId = u.Id
-	userEmail = u.Email
 
-	// Get board ID from user ID
-	boards, err := Board.GetBoardsByUserID(userId)
+	// Open the uploaded file
+	file, fileHeader, format, err := openUploadedFile(c)
 	if err != nil {
-		sentry.CaptureEvent(&sentry.Event{
-			Message: fmt.Sprintf("Failed to retrieve boards for user ID %d: %v", userId, err),
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to open uploaded file: %v", err),
 			Level:   sentry.LevelError,
 			Extra: map[string]interface{}{
-				"error": err.Error(),
-			},
-			User: sentry.User{
-				ID: userUUID,
-			},
-			Tags: map[string]string{
-				"handler": "UploadFeedbackFileHandler",
-				"action":  "upload_feedback_file",
+				"board_id": boardID,
 			},
-		})
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to retrieve boards for user",
-		})
-	}
-	if len(boards) == 0 {
-		sentry.CaptureEvent(&sentry.Event{
-			Message: fmt.Sprintf("No boards found for user ID %d", userId),
-			Level:   sentry.LevelWarning,
 			User: sentry.User{
-				ID: userUUID,
+				ID:    userUUID,
+				Email: userEmail,
 			},
 			Tags: map[string]string{
 				"handler": "UploadFeedbackFileHandler",
@@ -111,35 +109,48 @@ func UploadFeedbackFileHandler(c *fiber.Ctx) error {
 			},
 		})
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "No boards found for this user",
+			"error": err.Error(),
 		})
 	}
-	boardID := boards[0].BaseModel.Id
+	defer file.Close()
 
-	// Check if the board exists
-	if err := validateBoardExists(boardID); err != nil {
-		sentry.CaptureEvent(&sentry.Event{
-			Message: fmt.Sprintf("Board with ID %d does not exist: %v", boardID, err),
-			Level:   sentry.LevelError,
-			Extra: map[string]interface{}{
-				"board_id": boardID,
-				"error":    err.Error(),
-			},
-			Tags: map[string]string{
-				"handler": "UploadFeedbackFileHandler",
-				"action":  "upload_feedback_file",
-			},
-		})
+	var opts feedbackImport.CsvOptions
+	if format == feedbackImport.FormatCSV {
+		opts, err = getCsvOptions(c)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid CSV format: " + err.Error(),
+			})
+		}
+	}
+
+	if c.QueryBool("dry_run") {
+		return dryRunUpload(c, bc, file, fileHeader.Filename, format, opts)
+	}
+
+	// A retried upload carrying the same Idempotency-Key gets the summary of the first one
+	idempotencyKey := strings.TrimSpace(c.Get(IdempotencyKeyHeader))
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
+			"error": fmt.Sprintf("%s must be at most %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength),
 		})
 	}
+	if idempotencyKey != "" {
+		if job, err := importJobDB.GetImportJobByIdempotencyKey(boardID, idempotencyKey); err == nil {
+			return replayImportJob(c, job)
+		}
+	}
 
-	// Process the uploaded file
-	fileBytes, err := getUploadedFileBytes(c)
+	importer, err := feedbackImport.Start(c.UserContext(), boardID, fileHeader.Filename, format, userEmail, idempotencyKey)
+	if err != nil && idempotencyKey != "" {
+		// A concurrent request with the same key may have created its job first
+		if job, lookupErr := importJobDB.GetImportJobByIdempotencyKey(boardID, idempotencyKey); lookupErr == nil {
+			return replayImportJob(c, job)
+		}
+	}
 	if err != nil {
-		sentry.CaptureEvent(&sentry.Event{
-			Message: fmt.Sprintf("Failed to get uploaded file bytes: %v", err),
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to start import for user ID %d: %v", userId, err),
 			Level:   sentry.LevelError,
 			Extra: map[string]interface{}{
 				"board_id": boardID,
@@ -153,62 +164,58 @@ func UploadFeedbackFileHandler(c *fiber.Ctx) error {
 				"action":  "upload_feedback_file",
 			},
 		})
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Database error: " + err.Error(),
 		})
 	}
 
-	// Parse the JSON data
-	feedbacksJson, err := parseFeedbackJson(fileBytes)
-	if err != nil {
-		sentry.CaptureEvent(&sentry.Event{
-			Message: fmt.Sprintf("Failed to parse JSON feedback data: %v", err),
+	// Stream the rows into the importer, a storage failure stops the decoding
+	storeErr, err := feedbackImport.Decode(file, format, opts, importer)
+	if err == nil {
+		_, err = importer.Commit()
+		if !errors.Is(err, feedbackImport.ErrNoValidFeedback) {
+			storeErr = err
+		}
+	} else {
+		importer.Abort(err)
+	}
+	job := importer.Job()
+
+	switch {
+	case storeErr != nil:
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Database error while uploading feedbacks for user ID %d: %v", userId, storeErr),
 			Level:   sentry.LevelError,
 			Extra: map[string]interface{}{
-				"board_id": boardID,
+				"board_id":      boardID,
+				"import_job_id": job.Id,
+				"filename":      fileHeader.Filename,
 			},
 			User: sentry.User{
 				ID:    userUUID,
 				Email: userEmail,
 			},
-			Attachments: []*sentry.Attachment{
-				{
-					Filename: "feedback_file.json",
-					Payload:  fileBytes,
-				},
-			},
 			Tags: map[string]string{
 				"handler": "UploadFeedbackFileHandler",
 				"action":  "upload_feedback_file",
 			},
 		})
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid JSON format: " + err.Error(),
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Database error: " + storeErr.Error(),
 		})
-	}
-
-	// Convert to model feedbacks
-	feedbacks := convertJsonToFeedbacks(feedbacksJson, boardID)
-
-	// Validate the feedbacks
-	validFeedbacks, preValidationErrors := validateFeedbacks(feedbacks)
-	if len(validFeedbacks) == 0 {
-		sentry.CaptureEvent(&sentry.Event{
+	case errors.Is(err, feedbackImport.ErrNoValidFeedback):
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: fmt.Sprintf("No valid feedback data found after validation for user ID %d", userId),
 			Level:   sentry.LevelWarning,
 			Extra: map[string]interface{}{
-				"board_id": boardID,
+				"board_id":      boardID,
+				"import_job_id": job.Id,
+				"filename":      fileHeader.Filename,
 			},
 			User: sentry.User{
 				ID:    userUUID,
 				Email: userEmail,
 			},
-			Attachments: []*sentry.Attachment{
-				{
-					Filename: "feedback_file.json",
-					Payload:  fileBytes,
-				},
-			},
 			Tags: map[string]string{
 				"handler": "UploadFeedbackFileHandler",
 				"action":  "upload_feedback_file",
@@ -216,92 +223,106 @@ func UploadFeedbackFileHandler(c *fiber.Ctx) error {
 		})
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error":             "No valid feedback data found after validation",
-			"validation_errors": preValidationErrors,
+			"validation_errors": job.Errors,
 		})
-	}
-
-	// Save feedbacks to database using the dedicated database function
-	successCount, dbErrors, err := feedbackDB.UploadFeedbacksFromFile(feedbacks, userEmail)
-	if err != nil {
-		sentry.CaptureEvent(&sentry.Event{
-			Message: fmt.Sprintf("Database error while uploading feedbacks for user ID %d: %v", userId, err),
+	case err != nil:
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to parse %s feedback data: %v", format, err),
 			Level:   sentry.LevelError,
 			Extra: map[string]interface{}{
-				"board_id": boardID,
+				"board_id":      boardID,
+				"import_job_id": job.Id,
+				"filename":      fileHeader.Filename,
 			},
 			User: sentry.User{
 				ID:    userUUID,
 				Email: userEmail,
 			},
-			Attachments: []*sentry.Attachment{
-				{
-					Filename: "feedback_file.json",
-					Payload:  fileBytes,
-				},
-			},
 			Tags: map[string]string{
 				"handler": "UploadFeedbackFileHandler",
 				"action":  "upload_feedback_file",
 			},
 		})
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Database error: " + err.Error(),
+		if format == feedbackImport.FormatCSV {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid CSV format: " + err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid JSON format: " + err.Error(),
 		})
 	}
 
-	// Combine all errors
-	allErrors := append(preValidationErrors, dbErrors...)
+	// Return summary of the import, the analysis goes on in the background
+	if job.Status == importJobModel.StatusCompleted {
+		return c.Status(fiber.StatusOK).JSON(importSummary(job, "File processed, every row was already imported"))
+	}
+	return c.Status(fiber.StatusAccepted).JSON(importSummary(job, "File imported, analysis in progress"))
+}
+
+// importSummary is the upload response describing an import job
+func importSummary(job importJobModel.ImportJob, message string) fiber.Map {
+	return fiber.Map{
+		"message":       message,
+		"job_id":        job.Id,
+		"status":        job.Status,
+		"total":         job.TotalRows,
+		"success_count": job.ImportedCount,
+		"skipped_count": job.SkippedCount,
+		"error_count":   job.ErrorCount,
+		"errors":        job.Errors,
+	}
+}
 
-	// Return summary of the operation
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message":       "File processed successfully",
-		"total":         len(feedbacks),
-		"success_count": successCount,
-		"error_count":   len(feedbacks) - successCount,
-		"errors":        allErrors,
-	})
+// replayImportJob answers a retried upload with the job created by the first request
+func replayImportJob(c *fiber.Ctx, job importJobModel.ImportJob) error {
+	c.Set("Idempotent-Replayed", "true")
+	return c.Status(fiber.StatusOK).JSON(importSummary(job, "File already uploaded with this idempotency key"))
 }
 
-// getUploadedFileBytes retrieves and validates the uploaded file, returning its contents
-func getUploadedFileBytes(c *fiber.Ctx) ([]byte, error) {
+// openUploadedFile retrieves and validates the uploaded file, returning it along with its format
+func openUploadedFile(c *fiber.Ctx) (multipart.File, *multipart.FileHeader, string, error) {
 	// Get uploaded file
-	file, err := c.FormFile("file")
+	fileHeader, err := c.FormFile("file")
 	if err != nil {
-		return nil, errors.New("no file uploaded or invalid form field")
+		return nil, nil, "", errors.New("no file uploaded or invalid form field")
 	}
 
-	// Check if it's a JSON file
-	if file.Header.Get("Content-Type") != "application/json" {
-		return nil, errors.New("file must be JSON format")
+	// Check if it's a JSON, NDJSON or CSV file
+	format := uploadFormat(fileHeader.Header.Get("Content-Type"), fileHeader.Filename)
+	if format == "" {
+		return nil, nil, "", errors.New("file must be JSON, NDJSON or CSV format")
 	}
 
-	// Open and read the file
-	uploadedFile, err := file.Open()
+	// Open the file, it is read as a stream
+	file, err := fileHeader.Open()
 	if err != nil {
-		return nil, errors.New("failed to open uploaded file")
+		return nil, nil, "", errors.New("failed to open uploaded file")
 	}
-	defer uploadedFile.Close()
 
-	return io.ReadAll(uploadedFile)
+	return file, fileHeader, format, nil
 }
 
-// parseFeedbackJson parses the JSON data into the feedback structure
-func parseFeedbackJson(fileBytes []byte) ([]feedbackModel.FeedbackJson, error) {
-	var feedbacksJson []feedbackModel.FeedbackJson
-
-	err := json.Unmarshal(fileBytes, &feedbacksJson)
-	if err != nil {
-		return nil, err
-	}
-
-	// Validate the feedbacks
-	if len(feedbacksJson) == 0 {
-		return nil, errors.New("no feedback data found in file")
-	} else if len(feedbacksJson) > 10 {
-		return nil, errors.New("too many feedbacks in file, maximum is 10")
+// uploadFormat detects the format of an uploaded file from its content type,
+// falling back on the extension for clients sending a generic content type
+func uploadFormat(contentType, filename string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/json":
+		return feedbackImport.FormatJSON
+	case "application/x-ndjson", "application/jsonl", "application/jsonlines":
+		return feedbackImport.FormatNDJSON
+	case "text/csv", "application/csv", "application/vnd.ms-excel":
+		return feedbackImport.FormatCSV
+	case "", "application/octet-stream", "text/plain":
+		switch strings.ToLower(filepath.Ext(filename)) {
+		case ".csv":
+			return feedbackImport.FormatCSV
+		case ".ndjson", ".jsonl":
+			return feedbackImport.FormatNDJSON
+		}
 	}
-
-	return feedbacksJson, nil
+	return ""
 }
 
 // convertJsonToFeedbacks converts JSON feedback data to model instances
@@ -310,10 +331,18 @@ func convertJsonToFeedbacks(feedbacksJson []feedbackModel.FeedbackJson, boardID
 
 	for i, feedback := range feedbacksJson {
 		feedbacks[i] = feedbackModel.Feedback{
-			Date:    feedback.Date,
-			Channel: feedback.Channel,
-			Text:    feedback.Text,
-			BoardID: boardID,
+			Date:        feedback.Date,
+			Channel:     feedback.Channel,
+			Text:        feedback.Text,
+			BoardID:     boardID,
+			Customer:    feedback.Customer,
+			Metadata:    feedback.Metadata,
+			Rating:      feedback.Rating,
+			RatingScale: feedback.RatingScale,
+		}
+		if feedback.ExternalID != "" {
+			externalID := feedback.ExternalID
+			feedbacks[i].ExternalID = &externalID
 		}
 	}
 
@@ -332,6 +361,10 @@ func validateFeedbacks(feedbacks []feedbackModel.Feedback) ([]feedbackModel.Feed
 			validationErrors = append(validationErrors, errorMsg)
 			continue
 		}
+		if _, err := feedbackModel.ValidateRating(feedbackData.Rating, feedbackData.RatingScale); err != nil {
+			validationErrors = append(validationErrors, fmt.Sprintf("Feedback #%d: %v", i+1, err))
+			continue
+		}
 
 		validFeedbacks = append(validFeedbacks, feedbackData)
 	}
@@ -119,51 +119,6 @@ func TestUploadFeedbackFileHandler_UserNotFound(t *testing.T) {
 
 // Test helper functions
 
-func TestParseFeedbackJson_ValidData(t *testing.T) {
-	jsonData := `[{
-		"date": "2024-01-01T00:00:00Z",
-		"channel": "test",
-		"text": "feedback"
-	}]`
-
-	feedbacks, err := parseFeedbackJson([]byte(jsonData))
-	assert.NoError(t, err)
-	assert.Len(t, feedbacks, 1)
-	assert.Equal(t, "test", feedbacks[0].Channel)
-	assert.Equal(t, "feedback", feedbacks[0].Text)
-}
-
-func TestParseFeedbackJson_InvalidJSON(t *testing.T) {
-	jsonData := `invalid json`
-
-	_, err := parseFeedbackJson([]byte(jsonData))
-	assert.Error(t, err)
-}
-
-func TestParseFeedbackJson_EmptyArray(t *testing.T) {
-	jsonData := `[]`
-
-	_, err := parseFeedbackJson([]byte(jsonData))
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "no feedback data found")
-}
-
-func TestParseFeedbackJson_TooManyFeedbacks(t *testing.T) {
-	// Create JSON with 11 feedbacks
-	jsonData := "["
-	for i := 0; i < 11; i++ {
-		if i > 0 {
-			jsonData += ","
-		}
-		jsonData += `{"date": "2024-01-01T00:00:00Z", "channel": "test", "text": "feedback"}`
-	}
-	jsonData += "]"
-
-	_, err := parseFeedbackJson([]byte(jsonData))
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "too many feedbacks")
-}
-
 func TestConvertJsonToFeedbacks(t *testing.T) {
 	date, _ := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
 	feedbacksJson := []feedbackModel.FeedbackJson{
@@ -0,0 +1,165 @@
+package Feedback
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
+	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/webhookSignature"
+)
+
+// maxWebhookBatch is the maximum number of feedbacks accepted in one webhook call
+const maxWebhookBatch = 100
+
+// WebhookHandler godoc
+// @Summary Push feedbacks through the board webhook
+// @Description Accept a single FeedbackJson object or an array of them signed with the board webhook secret.
+// @Description The signature is "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body)) sent in X-FeedPulse-Signature,
+// @Description the unix timestamp is sent in X-FeedPulse-Timestamp and must be within 5 minutes of the server time.
+// @Tags Ingest
+// @Accept json
+// @Produce json
+// @Param board_token path string true "Board webhook token"
+// @Param X-FeedPulse-Timestamp header string true "Unix timestamp of the signature"
+// @Param X-FeedPulse-Signature header string true "HMAC-SHA256 signature of the payload"
+// @Param feedback body Feedback.FeedbackJson true "Feedback or array of feedbacks"
+// @Success 200 {object} map[string]interface{} "Feedbacks processed successfully"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Invalid signature"
+// @Failure 404 {object} httpUtils.HTTPError "Unknown board token"
+// @Failure 409 {object} httpUtils.HTTPError "Replayed delivery"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/ingest/webhook/{board_token} [post]
+func WebhookHandler(c *fiber.Ctx) error {
+	board, err := Board.GetBoardByWebhookToken(c.Params("board_token"))
+	if err != nil {
+		return httpUtils.NewError(c, fiber.StatusNotFound, errors.New("unknown webhook token"))
+	}
+
+	body := c.Body()
+	timestamp := c.Get(webhookSignature.TimestampHeader)
+	signature := c.Get(webhookSignature.SignatureHeader)
+	err = webhookSignature.Verify(board.WebhookSecret, timestamp, signature, body, time.Now(), webhookSignature.DefaultTolerance)
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Rejected webhook delivery for board %d: %v", board.Id, err),
+			Level:   sentry.LevelWarning,
+			Extra: map[string]interface{}{
+				"board_id": board.Id,
+			},
+			Tags: map[string]string{
+				"handler": "WebhookHandler",
+				"action":  "verify_signature",
+			},
+		})
+		return httpUtils.NewError(c, fiber.StatusUnauthorized, err)
+	}
+
+	// The signature covers the timestamp, so a delivery can only be replayed inside the tolerance window
+	firstDelivery, err := feedbackDB.MarkWebhookDelivery(board.Id, signature, 2*webhookSignature.DefaultTolerance)
+	if err == nil && !firstDelivery {
+		return httpUtils.NewError(c, fiber.StatusConflict, errors.New("delivery already received"))
+	}
+	// The delivery is only kept once its feedbacks are saved, otherwise the sender could never retry it
+	marked, saved := err == nil && firstDelivery, false
+	defer func() {
+		if marked && !saved {
+			_ = feedbackDB.UnmarkWebhookDelivery(board.Id, signature)
+		}
+	}()
+
+	feedbacksJson, err := parseWebhookPayload(body)
+	if err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+	}
+
+	feedbacks := convertJsonToFeedbacks(feedbacksJson, board.Id)
+	for i := range feedbacks {
+		if feedbacks[i].Date.IsZero() {
+			feedbacks[i].Date = time.Now().UTC()
+		}
+	}
+
+	validFeedbacks, preValidationErrors := validateFeedbacks(feedbacks)
+	if len(validFeedbacks) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":             "No valid feedback data found after validation",
+			"validation_errors": preValidationErrors,
+		})
+	}
+
+	userEmail, err := Board.GetBoardNotificationEmail(board.Id)
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to retrieve notification email for board %d: %v", board.Id, err),
+			Level:   sentry.LevelError,
+			Tags: map[string]string{
+				"handler": "WebhookHandler",
+				"action":  "get_board_email",
+			},
+		})
+		return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to retrieve board members"))
+	}
+
+	successCount, skippedCount, dbErrors, err := feedbackDB.FetchAndSaveFeedbacks(c.UserContext(), validFeedbacks, userEmail)
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Database error while saving webhook feedbacks for board %d: %v", board.Id, err),
+			Level:   sentry.LevelError,
+			Tags: map[string]string{
+				"handler": "WebhookHandler",
+				"action":  "save_feedbacks",
+			},
+		})
+		return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("Database error: "+err.Error()))
+	}
+	saved = true
+
+	allErrors := append(preValidationErrors, dbErrors...)
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message":       "Feedbacks processed successfully",
+		"total":         len(feedbacks),
+		"success_count": successCount,
+		"skipped_count": skippedCount,
+		"error_count":   len(feedbacks) - successCount - skippedCount,
+		"errors":        allErrors,
+	})
+}
+
+// parseWebhookPayload accepts either a single feedback object or an array of feedbacks
+func parseWebhookPayload(body []byte) ([]feedbackModel.FeedbackJson, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil, errors.New("empty payload")
+	}
+
+	var feedbacksJson []feedbackModel.FeedbackJson
+	if body[0] == '[' {
+		if err := json.Unmarshal(body, &feedbacksJson); err != nil {
+			return nil, errors.New("Invalid JSON format: " + err.Error())
+		}
+	} else {
+		var feedbackJson feedbackModel.FeedbackJson
+		if err := json.Unmarshal(body, &feedbackJson); err != nil {
+			return nil, errors.New("Invalid JSON format: " + err.Error())
+		}
+		feedbacksJson = append(feedbacksJson, feedbackJson)
+	}
+
+	if len(feedbacksJson) == 0 {
+		return nil, errors.New("no feedback data found in payload")
+	}
+	if len(feedbacksJson) > maxWebhookBatch {
+		return nil, fmt.Errorf("too many feedbacks in payload, maximum is %d", maxWebhookBatch)
+	}
+	return feedbacksJson, nil
+}
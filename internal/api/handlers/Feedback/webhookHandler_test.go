@@ -0,0 +1,125 @@
+package Feedback
+
+import (
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/webhookSignature"
+)
+
+func expectWebhookBoard(mock sqlmock.Sqlmock, token, secret string) {
+	mock.ExpectQuery(`SELECT (.+) FROM "boards" WHERE webhook_token = \$1 AND "boards"."deleted_at" IS NULL`).
+		WithArgs(token, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "webhook_token", "webhook_secret"}).
+			AddRow(1, "Board", token, secret))
+}
+
+func TestWebhookHandler_UnknownToken(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`SELECT (.+) FROM "boards" WHERE webhook_token = \$1 AND "boards"."deleted_at" IS NULL`).
+		WithArgs("nope", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	app := fiber.New()
+	app.Post("/api/ingest/webhook/:board_token", WebhookHandler)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/api/ingest/webhook/nope", strings.NewReader(`{}`)))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestWebhookHandler_Signature(t *testing.T) {
+	body := `{"channel":"app","text":"hello"}`
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		status    int
+		contains  string
+	}{
+		{"missing headers", "", "", fiber.StatusUnauthorized, "missing signature"},
+		{"wrong secret", "other-secret", strconv.FormatInt(now.Unix(), 10), fiber.StatusUnauthorized, "invalid signature"},
+		{"replayed timestamp", "board-secret", strconv.FormatInt(now.Add(-time.Hour).Unix(), 10), fiber.StatusUnauthorized, "tolerance window"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, cleanup := setupMockDB(t)
+			defer cleanup()
+			expectWebhookBoard(mock, "tok", "board-secret")
+
+			app := fiber.New()
+			app.Post("/api/ingest/webhook/:board_token", WebhookHandler)
+
+			req := httptest.NewRequest("POST", "/api/ingest/webhook/tok", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.timestamp != "" {
+				req.Header.Set(webhookSignature.TimestampHeader, tt.timestamp)
+				req.Header.Set(webhookSignature.SignatureHeader, webhookSignature.Sign(tt.secret, tt.timestamp, []byte(body)))
+			}
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+			respBody, _ := io.ReadAll(resp.Body)
+			assert.Contains(t, string(respBody), tt.contains)
+		})
+	}
+}
+
+func TestWebhookHandler_InvalidPayload(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+	expectWebhookBoard(mock, "tok", "board-secret")
+
+	app := fiber.New()
+	app.Post("/api/ingest/webhook/:board_token", WebhookHandler)
+
+	body := `[{"channel":"app","text":""}]`
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req := httptest.NewRequest("POST", "/api/ingest/webhook/tok", strings.NewReader(body))
+	req.Header.Set(webhookSignature.TimestampHeader, timestamp)
+	req.Header.Set(webhookSignature.SignatureHeader, webhookSignature.Sign("board-secret", timestamp, []byte(body)))
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	respBody, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(respBody), "No valid feedback data found after validation")
+}
+
+func TestParseWebhookPayload(t *testing.T) {
+	feedbacks, err := parseWebhookPayload([]byte(` {"date":"2024-01-01T00:00:00Z","channel":"app","text":"single"}`))
+	assert.NoError(t, err)
+	assert.Len(t, feedbacks, 1)
+	assert.Equal(t, "single", feedbacks[0].Text)
+
+	feedbacks, err = parseWebhookPayload([]byte(`[{"channel":"a","text":"1"},{"channel":"b","text":"2"}]`))
+	assert.NoError(t, err)
+	assert.Len(t, feedbacks, 2)
+
+	_, err = parseWebhookPayload([]byte(`[]`))
+	assert.Error(t, err)
+
+	_, err = parseWebhookPayload([]byte(``))
+	assert.Error(t, err)
+
+	_, err = parseWebhookPayload([]byte(`{"channel":`))
+	assert.Error(t, err)
+
+	tooMany := "[" + strings.TrimSuffix(strings.Repeat(`{"channel":"a","text":"b"},`, maxWebhookBatch+1), ",") + "]"
+	_, err = parseWebhookPayload([]byte(tooMany))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "too many feedbacks")
+}
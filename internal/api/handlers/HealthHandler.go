@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/health"
+)
+
+// HealthzHandler godoc
+// @Summary Liveness probe
+// @Description Answers as long as the process serves requests, without checking its dependencies
+// @Tags Health
+// @Produce json
+// @Success 200 {object} map[string]string "liveness response"
+// @Router /healthz [get]
+func HealthzHandler(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": health.StatusOK,
+	})
+}
+
+// ReadyzHandler godoc
+// @Summary Readiness probe
+// @Description Checks the database, the migrations, the cache and the analyzer, with the status and latency of each one.
+// @Description The instance is degraded when only optional dependencies (cache, analyzer) fail, and unavailable when a required one fails.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} health.Report "ready or degraded instance"
+// @Failure 503 {object} health.Report "unavailable instance"
+// @Router /readyz [get]
+func ReadyzHandler(c *fiber.Ctx) error {
+	report := health.Run(c.UserContext(), health.DefaultChecks())
+
+	status := fiber.StatusOK
+	if report.Status == health.StatusUnavailable {
+		status = fiber.StatusServiceUnavailable
+	}
+	return c.Status(status).JSON(report)
+}
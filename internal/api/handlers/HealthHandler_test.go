@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/health"
+)
+
+func TestHealthzHandler(t *testing.T) {
+	app := fiber.New()
+	app.Get("/healthz", HealthzHandler)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestReadyzHandler_Unavailable(t *testing.T) {
+	originalDB, originalRedis := database.DB, database.RedisClient
+	database.DB, database.RedisClient = nil, nil
+	defer func() { database.DB, database.RedisClient = originalDB, originalRedis }()
+
+	app := fiber.New()
+	app.Get("/readyz", ReadyzHandler)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+
+	var report health.Report
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	assert.Equal(t, health.StatusUnavailable, report.Status)
+	assert.Equal(t, "database is not initialized", report.Checks["database"].Error)
+	assert.Equal(t, health.StatusDisabled, report.Checks["redis"].Status)
+}
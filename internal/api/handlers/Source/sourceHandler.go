@@ -0,0 +1,222 @@
+package Source
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
+	sourceDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Source"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	sourceModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Source"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/sourceSync"
+)
+
+// getUserBoardID returns the board of the authenticated user
+func getUserBoardID(c *fiber.Ctx) (int, string, error) {
+	userUUID, ok := middleware.GetUserUUID(c)
+	if !ok {
+		return 0, "", errors.New("unauthorized: user not found in context")
+	}
+	board, err := Board.GetFirstBoardByUserUUID(userUUID)
+	if err != nil {
+		return 0, userUUID, err
+	}
+	return board.Id, userUUID, nil
+}
+
+// getBoardSource returns the source of the :id param if it belongs to the board
+func getBoardSource(c *fiber.Ctx, boardID int) (sourceModel.Source, error) {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return sourceModel.Source{}, fiber.NewError(fiber.StatusBadRequest, "invalid source id")
+	}
+	source, err := sourceDB.GetSourceByID(id)
+	if err != nil {
+		if errors.Is(err, sourceDB.ErrSourceNotFound) {
+			return sourceModel.Source{}, fiber.NewError(fiber.StatusNotFound, "source not found")
+		}
+		return sourceModel.Source{}, err
+	}
+	if source.BoardID != boardID {
+		return sourceModel.Source{}, fiber.NewError(fiber.StatusNotFound, "source not found")
+	}
+	return source, nil
+}
+
+// boardError writes the response for an error returned by getUserBoardID
+func boardError(c *fiber.Ctx, handler, userUUID string, err error) error {
+	if userUUID == "" {
+		return httpUtils.NewError(c, fiber.StatusUnauthorized, err)
+	}
+	if errors.Is(err, Board.ErrNoUserBoard) {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+	}
+	middleware.CaptureEvent(c, &sentry.Event{
+		Message: fmt.Sprintf("Failed to retrieve boards for user %s: %v", userUUID, err),
+		Level:   sentry.LevelError,
+		User: sentry.User{
+			ID: userUUID,
+		},
+		Tags: map[string]string{
+			"handler": handler,
+			"action":  "get_user_board",
+		},
+	})
+	return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to retrieve boards for user"))
+}
+
+// sourceError writes the response for an error returned by getBoardSource
+func sourceError(c *fiber.Ctx, err error) error {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return httpUtils.NewError(c, fiberErr.Code, errors.New(fiberErr.Message))
+	}
+	return httpUtils.NewError(c, fiber.StatusInternalServerError, err)
+}
+
+// GetSourcesHandler godoc
+// @Summary List feedback sources
+// @Description List the feedback sources configured on the user's board
+// @Tags Source
+// @Produce json
+// @Success 200 {array} Source.Source "List of sources"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/sources [get]
+func GetSourcesHandler(c *fiber.Ctx) error {
+	boardID, userUUID, err := getUserBoardID(c)
+	if err != nil {
+		return boardError(c, "GetSourcesHandler", userUUID, err)
+	}
+
+	sources, err := sourceDB.GetSourcesByBoardID(boardID)
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to retrieve sources for board %d: %v", boardID, err),
+			Level:   sentry.LevelError,
+			User: sentry.User{
+				ID: userUUID,
+			},
+			Tags: map[string]string{
+				"handler": "GetSourcesHandler",
+				"action":  "get_sources",
+			},
+		})
+		return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to retrieve sources"))
+	}
+	return c.Status(fiber.StatusOK).JSON(sources)
+}
+
+// CreateSourceHandler godoc
+// @Summary Create a feedback source
+// @Description Add a feedback source to the user's board, polled on the given cron schedule when set
+// @Tags Source
+// @Accept json
+// @Produce json
+// @Param source body Source.SourceJson true "Source configuration"
+// @Success 201 {object} Source.Source "Created source"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/sources [post]
+func CreateSourceHandler(c *fiber.Ctx) error {
+	boardID, userUUID, err := getUserBoardID(c)
+	if err != nil {
+		return boardError(c, "CreateSourceHandler", userUUID, err)
+	}
+
+	var body sourceModel.SourceJson
+	if err := c.BodyParser(&body); err != nil {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("invalid request payload"))
+	}
+	body.Name = strings.TrimSpace(body.Name)
+	body.Schedule = strings.TrimSpace(body.Schedule)
+	if body.Name == "" || body.URL == "" {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("name and url are required"))
+	}
+	if !sourceSync.IsSupportedType(body.Type) {
+		return httpUtils.NewError(c, fiber.StatusBadRequest, fmt.Errorf("unsupported source type %q", body.Type))
+	}
+	if body.Schedule != "" {
+		if err := sourceSync.ValidateSchedule(body.Schedule); err != nil {
+			return httpUtils.NewError(c, fiber.StatusBadRequest, err)
+		}
+	}
+
+	enabled := true
+	if body.Enabled != nil {
+		enabled = *body.Enabled
+	}
+	source, err := sourceDB.CreateSource(sourceModel.Source{
+		Name:     body.Name,
+		Type:     body.Type,
+		URL:      body.URL,
+		Schedule: body.Schedule,
+		Enabled:  enabled,
+		BoardID:  boardID,
+	})
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to create source for board %d: %v", boardID, err),
+			Level:   sentry.LevelError,
+			User: sentry.User{
+				ID: userUUID,
+			},
+			Tags: map[string]string{
+				"handler": "CreateSourceHandler",
+				"action":  "create_source",
+			},
+		})
+		return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to create source"))
+	}
+
+	sourceSync.ReloadScheduler()
+	return c.Status(fiber.StatusCreated).JSON(source)
+}
+
+// DeleteSourceHandler godoc
+// @Summary Delete a feedback source
+// @Description Delete a source of the user's board and its sync history, imported feedbacks are kept
+// @Tags Source
+// @Produce json
+// @Param id path int true "Source ID"
+// @Success 200 {object} httpUtils.HTTPMessage "Source deleted"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Source not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/sources/{id} [delete]
+func DeleteSourceHandler(c *fiber.Ctx) error {
+	boardID, userUUID, err := getUserBoardID(c)
+	if err != nil {
+		return boardError(c, "DeleteSourceHandler", userUUID, err)
+	}
+
+	source, err := getBoardSource(c, boardID)
+	if err != nil {
+		return sourceError(c, err)
+	}
+
+	if err := sourceDB.DeleteSource(source.Id); err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to delete source %d: %v", source.Id, err),
+			Level:   sentry.LevelError,
+			User: sentry.User{
+				ID: userUUID,
+			},
+			Tags: map[string]string{
+				"handler": "DeleteSourceHandler",
+				"action":  "delete_source",
+			},
+		})
+		return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to delete source"))
+	}
+
+	sourceSync.ReloadScheduler()
+	return httpUtils.NewMessage(c, fiber.StatusOK, "source deleted")
+}
@@ -0,0 +1,138 @@
+package Source
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	sourceModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Source"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const testUserUUID = "550e8400-e29b-41d4-a716-446655440000"
+
+func setupMockDB(t *testing.T) (sqlmock.Sqlmock, func()) {
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:                 mockDB,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Error opening gorm DB: %v", err)
+	}
+
+	originalDB := database.DB
+	database.DB = db
+
+	return mock, func() {
+		database.DB = originalDB
+		mockDB.Close()
+	}
+}
+
+// setupTestApp registers a handler behind a middleware simulating AuthRequired
+func setupTestApp(method, path string, handler fiber.Handler) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(middleware.UserContextKey, testUserUUID)
+		return c.Next()
+	})
+	app.Add(method, path, handler)
+	return app
+}
+
+func expectUserBoard(mock sqlmock.Sqlmock, boardID int) {
+	mock.ExpectQuery(`SELECT "boards"\."id"(.+) FROM "boards" JOIN user_boards (.+) WHERE users.uuid = \$1 AND "boards"."deleted_at" IS NULL ORDER BY boards.id ASC LIMIT \$2`).
+		WithArgs(testUserUUID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(boardID, "Board"))
+}
+
+func TestGetSourcesHandler_Unauthorized(t *testing.T) {
+	app := fiber.New()
+	app.Get("/api/sources", GetSourcesHandler)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/sources", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGetSourcesHandler_Success(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	expectUserBoard(mock, 2)
+	mock.ExpectQuery(`SELECT (.+) FROM "sources" WHERE board_id = \$1`).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "type", "url", "board_id"}).
+			AddRow(1, "Comments", sourceModel.TypeJSONPlaceholder, "http://example.com", 2))
+
+	app := setupTestApp("GET", "/api/sources", GetSourcesHandler)
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/sources", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var sources []sourceModel.Source
+	assert.NoError(t, json.Unmarshal(body, &sources))
+	assert.Len(t, sources, 1)
+	assert.Equal(t, "Comments", sources[0].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateSourceHandler_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		contains string
+	}{
+		{"missing url", `{"name":"a","type":"json"}`, "name and url are required"},
+		{"bad type", `{"name":"a","type":"ftp","url":"http://x"}`, "unsupported source type"},
+		{"bad schedule", `{"name":"a","type":"json","url":"http://x","schedule":"often"}`, "invalid schedule"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, cleanup := setupMockDB(t)
+			defer cleanup()
+			expectUserBoard(mock, 1)
+
+			app := setupTestApp("POST", "/api/sources", CreateSourceHandler)
+			req := httptest.NewRequest("POST", "/api/sources", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+			body, _ := io.ReadAll(resp.Body)
+			assert.Contains(t, string(body), tt.contains)
+		})
+	}
+}
+
+func TestGetSyncRunsHandler_OtherBoard(t *testing.T) {
+	mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	expectUserBoard(mock, 1)
+	mock.ExpectQuery(`SELECT (.+) FROM "sources" WHERE id = \$1`).
+		WithArgs(5, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id"}).AddRow(5, 99))
+
+	app := setupTestApp("GET", "/api/sources/:id/runs", GetSyncRunsHandler)
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/sources/5/runs", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
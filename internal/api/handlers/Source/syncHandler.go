@@ -0,0 +1,103 @@
+package Source
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	sourceDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Source"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/sourceSync"
+)
+
+// GetSyncRunsHandler godoc
+// @Summary List the sync runs of a source
+// @Description List the latest sync runs of a source with their counts and errors, newest first
+// @Tags Source
+// @Produce json
+// @Param id path int true "Source ID"
+// @Param limit query int false "Maximum number of runs to return (default 20)"
+// @Success 200 {array} Source.SyncRun "List of sync runs"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Source not found"
+// @Failure 500 {object} httpUtils.HTTPError "Internal server error"
+// @Router /api/sources/{id}/runs [get]
+func GetSyncRunsHandler(c *fiber.Ctx) error {
+	boardID, userUUID, err := getUserBoardID(c)
+	if err != nil {
+		return boardError(c, "GetSyncRunsHandler", userUUID, err)
+	}
+
+	source, err := getBoardSource(c, boardID)
+	if err != nil {
+		return sourceError(c, err)
+	}
+
+	runs, err := sourceDB.GetSyncRunsBySourceID(source.Id, c.QueryInt("limit", 20))
+	if err != nil {
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Failed to retrieve sync runs for source %d: %v", source.Id, err),
+			Level:   sentry.LevelError,
+			User: sentry.User{
+				ID: userUUID,
+			},
+			Tags: map[string]string{
+				"handler": "GetSyncRunsHandler",
+				"action":  "get_sync_runs",
+			},
+		})
+		return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to retrieve sync runs"))
+	}
+	return c.Status(fiber.StatusOK).JSON(runs)
+}
+
+// SyncSourceHandler godoc
+// @Summary Sync a source now
+// @Description Run an incremental sync of the source immediately and return the recorded run
+// @Tags Source
+// @Produce json
+// @Param id path int true "Source ID"
+// @Success 200 {object} Source.SyncRun "Finished sync run"
+// @Failure 400 {object} httpUtils.HTTPError "Bad request error"
+// @Failure 401 {object} httpUtils.HTTPError "Unauthorized error"
+// @Failure 404 {object} httpUtils.HTTPError "Source not found"
+// @Failure 409 {object} httpUtils.HTTPError "Sync already running"
+// @Failure 502 {object} Source.SyncRun "Failed sync run"
+// @Router /api/sources/{id}/sync [post]
+func SyncSourceHandler(c *fiber.Ctx) error {
+	boardID, userUUID, err := getUserBoardID(c)
+	if err != nil {
+		return boardError(c, "SyncSourceHandler", userUUID, err)
+	}
+
+	source, err := getBoardSource(c, boardID)
+	if err != nil {
+		return sourceError(c, err)
+	}
+
+	run, err := sourceSync.SyncSource(c.UserContext(), source, sourceSync.TriggerManual)
+	if err != nil {
+		if errors.Is(err, sourceSync.ErrSyncInProgress) {
+			return httpUtils.NewError(c, fiber.StatusConflict, err)
+		}
+		middleware.CaptureEvent(c, &sentry.Event{
+			Message: fmt.Sprintf("Manual sync of source %d failed: %v", source.Id, err),
+			Level:   sentry.LevelError,
+			User: sentry.User{
+				ID: userUUID,
+			},
+			Tags: map[string]string{
+				"handler": "SyncSourceHandler",
+				"action":  "sync_source",
+			},
+		})
+		if run.Id == 0 {
+			return httpUtils.NewError(c, fiber.StatusInternalServerError, err)
+		}
+		return c.Status(fiber.StatusBadGateway).JSON(run)
+	}
+	return c.Status(fiber.StatusOK).JSON(run)
+}
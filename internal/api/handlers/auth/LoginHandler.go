@@ -5,6 +5,7 @@ import (
 
 	"github.com/getsentry/sentry-go"
 	"github.com/gofiber/fiber/v2"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/sessionManager"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/auth"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
@@ -37,7 +38,7 @@ func LoginHandlerWithRepo(c *fiber.Ctx, repo auth.UserRepository) error {
 	// Parse the request body into the LoginUser struct
 	var user LoginUser
 	if err := c.BodyParser(&user); err != nil {
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: "Failed to parse login request body",
 			Extra: map[string]interface{}{
 				"error": err.Error(),
@@ -57,7 +58,7 @@ func LoginHandlerWithRepo(c *fiber.Ctx, repo auth.UserRepository) error {
 	if err != nil {
 		switch {
 		case errors.Is(err, auth.ErrLoginRequired):
-			sentry.CaptureEvent(&sentry.Event{
+			middleware.CaptureEvent(c, &sentry.Event{
 				Message: "No login provided in request",
 				Extra: map[string]interface{}{
 					"error": err.Error(),
@@ -71,7 +72,7 @@ func LoginHandlerWithRepo(c *fiber.Ctx, repo auth.UserRepository) error {
 			})
 			return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("login is required"))
 		case errors.Is(err, auth.ErrPasswordRequired):
-			sentry.CaptureEvent(&sentry.Event{
+			middleware.CaptureEvent(c, &sentry.Event{
 				Message: "No password provided in request",
 				Extra: map[string]interface{}{
 					"error": err.Error(),
@@ -85,7 +86,7 @@ func LoginHandlerWithRepo(c *fiber.Ctx, repo auth.UserRepository) error {
 			})
 			return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("password is required"))
 		case errors.Is(err, auth.ErrInvalidCredentials):
-			sentry.CaptureEvent(&sentry.Event{
+			middleware.CaptureEvent(c, &sentry.Event{
 				Message: "Invalid login credentials provided",
 				Extra: map[string]interface{}{
 					"error": err.Error(),
@@ -102,7 +103,7 @@ func LoginHandlerWithRepo(c *fiber.Ctx, repo auth.UserRepository) error {
 			})
 			return httpUtils.NewError(c, fiber.StatusUnauthorized, errors.New("invalid credentials"))
 		case errors.Is(err, auth.ErrUserNotFound):
-			sentry.CaptureEvent(&sentry.Event{
+			middleware.CaptureEvent(c, &sentry.Event{
 				Message: "User not found during login attempt",
 				Extra: map[string]interface{}{
 					"error": err.Error(),
@@ -119,7 +120,7 @@ func LoginHandlerWithRepo(c *fiber.Ctx, repo auth.UserRepository) error {
 			})
 			return httpUtils.NewError(c, fiber.StatusUnauthorized, errors.New("invalid credentials"))
 		default:
-			sentry.CaptureEvent(&sentry.Event{
+			middleware.CaptureEvent(c, &sentry.Event{
 				Message: "Unexpected error during login",
 				Extra: map[string]interface{}{
 					"error": err.Error(),
@@ -141,7 +142,7 @@ func LoginHandlerWithRepo(c *fiber.Ctx, repo auth.UserRepository) error {
 	// return the sessions JWT token
 	token, err := sessionManager.Instance.CreateSession(userUUID)
 	if err != nil {
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: "Failed to create session during login",
 			Extra: map[string]interface{}{
 				"error": err.Error(),
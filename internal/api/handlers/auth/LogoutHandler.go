@@ -28,7 +28,7 @@ func LogoutHandler(c *fiber.Ctx) error {
 		// get the token from the Authorization Bearer header
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
-			sentry.CaptureEvent(&sentry.Event{
+			middleware.CaptureEvent(c, &sentry.Event{
 				Message: "No token provided in Authorization header",
 				Level:   sentry.LevelError,
 				Tags: map[string]string{
@@ -39,7 +39,7 @@ func LogoutHandler(c *fiber.Ctx) error {
 			return httpUtils.NewError(c, fiber.StatusUnauthorized, errors.New("no token provided"))
 		}
 		if len(authHeader) < 7 {
-			sentry.CaptureEvent(&sentry.Event{
+			middleware.CaptureEvent(c, &sentry.Event{
 				Message: "Invalid token format in Authorization header",
 				Level:   sentry.LevelError,
 				Tags: map[string]string{
@@ -55,7 +55,7 @@ func LogoutHandler(c *fiber.Ctx) error {
 		// check if the token is valid
 		valid, err := sessionManager.Instance.ValidateSession(token)
 		if err != nil || !valid {
-			sentry.CaptureEvent(&sentry.Event{
+			middleware.CaptureEvent(c, &sentry.Event{
 				Message: "Invalid token provided",
 				Level:   sentry.LevelError,
 				Extra: map[string]interface{}{
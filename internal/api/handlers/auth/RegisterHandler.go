@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	userDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/user"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/User"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
@@ -69,7 +70,7 @@ func RegisterHandlerWithRepo(c *fiber.Ctx, repo UserRepository) error {
 	// Parse the request body into the RegisterUser struct
 	var registerUser RegisterUser
 	if err := c.BodyParser(&registerUser); err != nil {
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: "Failed to parse registration request body",
 			Extra: map[string]interface{}{
 				"error": err.Error(),
@@ -85,7 +86,7 @@ func RegisterHandlerWithRepo(c *fiber.Ctx, repo UserRepository) error {
 	}
 	// Validate the request payload
 	if registerUser.Username == "" || registerUser.Email == "" || registerUser.Password == "" {
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: "Missing required fields in registration request",
 			User: sentry.User{
 				Username: registerUser.Username,
@@ -100,7 +101,7 @@ func RegisterHandlerWithRepo(c *fiber.Ctx, repo UserRepository) error {
 		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("username, email, and password are required"))
 	}
 	if len(registerUser.Password) < 8 {
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: "Password too short in registration request",
 			Extra: map[string]interface{}{
 				"password_length": len(registerUser.Password),
@@ -118,7 +119,7 @@ func RegisterHandlerWithRepo(c *fiber.Ctx, repo UserRepository) error {
 		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("password must be at least 8 characters long"))
 	}
 	if len(registerUser.Password) > 50 {
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: "Password too long in registration request",
 			Extra: map[string]interface{}{
 				"password_length": len(registerUser.Password),
@@ -136,7 +137,7 @@ func RegisterHandlerWithRepo(c *fiber.Ctx, repo UserRepository) error {
 		return httpUtils.NewError(c, fiber.StatusBadRequest, errors.New("password must be at most 50 characters long"))
 	}
 	if !utils.IsValidEmail(registerUser.Email) {
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: "Invalid email format in registration request",
 			User: sentry.User{
 				Username: registerUser.Username,
@@ -153,7 +154,7 @@ func RegisterHandlerWithRepo(c *fiber.Ctx, repo UserRepository) error {
 	// Hash the password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(registerUser.Password), bcrypt.DefaultCost)
 	if err != nil {
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: "Failed to hash password during registration",
 			Extra: map[string]interface{}{
 				"error": err.Error(),
@@ -179,7 +180,7 @@ func RegisterHandlerWithRepo(c *fiber.Ctx, repo UserRepository) error {
 	// get the user by username
 	existingUser, err := repo.GetUserByUsername(newUser.Username)
 	if err == nil && existingUser != nil {
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: "Username already exists during registration",
 			User: sentry.User{
 				Username: registerUser.Username,
@@ -196,7 +197,7 @@ func RegisterHandlerWithRepo(c *fiber.Ctx, repo UserRepository) error {
 	// get the user by email
 	existingUser, err = repo.GetUserByEmail(newUser.Email)
 	if err == nil && existingUser != nil {
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: "Email already exists during registration",
 			User: sentry.User{
 				Username: registerUser.Username,
@@ -214,7 +215,7 @@ func RegisterHandlerWithRepo(c *fiber.Ctx, repo UserRepository) error {
 	// Save the registerUser to the database
 	err = repo.CreateUser(newUser)
 	if err != nil {
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: "Failed to create user during registration",
 			Extra: map[string]interface{}{
 				"error": err.Error(),
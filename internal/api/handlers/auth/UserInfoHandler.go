@@ -30,7 +30,7 @@ func UserInfoHandlerWithRepo(c *fiber.Ctx, repo UserRepository) error {
 	// Get user UUID from context (set by middleware)
 	userUUID, ok := middleware.GetUserUUID(c)
 	if !ok {
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: "Unauthorized access attempt: User UUID not found in context",
 			Level:   sentry.LevelError,
 			Tags: map[string]string{
@@ -44,7 +44,7 @@ func UserInfoHandlerWithRepo(c *fiber.Ctx, repo UserRepository) error {
 	// Get user information from database
 	user, err := repo.GetUserByUUID(userUUID)
 	if err != nil {
-		sentry.CaptureEvent(&sentry.Event{
+		middleware.CaptureEvent(c, &sentry.Event{
 			Message: "Failed to retrieve user information",
 			Level:   sentry.LevelError,
 			Extra: map[string]interface{}{
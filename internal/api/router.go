@@ -4,20 +4,22 @@ import (
 	"github.com/getsentry/sentry-go"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
-	"github.com/tot0p/env"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/api/handlers"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/api/handlers/Board"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/api/handlers/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/api/handlers/Source"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/api/handlers/auth"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/config"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/metrics"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/middleware"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/apiKey"
 )
 
-func SetupRoutes(app *fiber.App) error {
+func SetupRoutes(app *fiber.App, cfg *config.Config) error {
 	// setup sentry error tracking
 	err := sentry.Init(sentry.ClientOptions{
-		Dsn:              env.Get("SENTRY_DSN"),
-		Environment:      env.Get("ENV"),
+		Dsn:              cfg.SentryDSN,
+		Environment:      cfg.Env,
 		EnableTracing:    true,
 		TracesSampleRate: 1.0, // Adjust the sample rate as needed
 		SendDefaultPII:   true,
@@ -33,22 +35,21 @@ func SetupRoutes(app *fiber.App) error {
 	})
 	app.Use(sentryHandler)
 
-	// use logger middleware
-	app.Use(logger.New(logger.Config{
-		Format:     "[${time}] ${status} - ${method} ${path}\n",
-		TimeFormat: "02-Jan-2006 15:04:05",
-		TimeZone:   "Local",
-	}))
-	allowedOrigins := env.Get("ALLOWED_ORIGINS")
-	if allowedOrigins == "" {
-		allowedOrigins = "*"
-	}
+	// Accept or generate the X-Request-ID, after Sentry to tag its scope
+	app.Use(middleware.RequestID())
+
+	// Count and time the requests by route for /metrics
+	app.Use(middleware.Metrics())
+
+	// Log a line per request, tagged with its request ID
+	app.Use(middleware.AccessLog())
 
 	// use CORS middleware
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: allowedOrigins,
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
-		AllowMethods: "GET, POST, PUT, DELETE, OPTIONS",
+		AllowOrigins:  cfg.AllowedOrigins,
+		AllowHeaders:  "Origin, Content-Type, Accept, Authorization, X-API-Key, Idempotency-Key, X-Request-ID",
+		ExposeHeaders: "X-Request-ID, Retry-After, RateLimit-Policy, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset",
+		AllowMethods:  "GET, POST, PUT, PATCH, DELETE, OPTIONS",
 	}))
 
 	app.All("/foo", func(c *fiber.Ctx) error {
@@ -56,13 +57,19 @@ func SetupRoutes(app *fiber.App) error {
 	})
 
 	app.Get("/ping", handlers.PingHandler) // done
+	// Probes of the load balancer, see health for the checks
+	app.Get("/healthz", handlers.HealthzHandler)
+	app.Get("/readyz", handlers.ReadyzHandler)
+	// Prometheus scrape endpoint
+	app.Get("/metrics", metrics.Handler())
 
 	api := app.Group("/api")
 
 	// Authentication routes
 	authGrp := api.Group("/auth")
-	authGrp.Post("/login", auth.LoginHandler)
-	authGrp.Post("/register", auth.RegisterHandler)
+	authLimit := middleware.RateLimit("auth", cfg.RateLimits.Auth, middleware.KeyByIP)
+	authGrp.Post("/login", authLimit, auth.LoginHandler)
+	authGrp.Post("/register", authLimit, auth.RegisterHandler)
 	authGrp.Get("/user", middleware.AuthRequired(), auth.UserInfoHandler)
 
 	// Protected routes (require authentication)
@@ -70,12 +77,73 @@ func SetupRoutes(app *fiber.App) error {
 
 	// Feedback routes
 	feedbackGrp := api.Group("/feedbacks")
-	feedbackGrp.Get("/", Feedback.GetAllFeedbacksHandler)
-	feedbackGrp.Post("/upload", middleware.AuthRequired(), Feedback.UploadFeedbackFileHandler)
-	feedbackGrp.Post("/fetch", middleware.AuthRequired(), Feedback.FetchFeedbackHandler)
+	// The routes calling the analyzer share a limit, the bulk action and the update only when they analyze
+	analyzeLimit := middleware.RateLimit("analyze", cfg.RateLimits.Analyze, middleware.KeyByClient)
+	feedbackGrp.Get("/", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackRead), Feedback.GetAllFeedbacksHandler)
+	feedbackGrp.Post("/", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackWrite), analyzeLimit, Feedback.CreateFeedbackHandler)
+	feedbackGrp.Post("/upload", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackWrite), middleware.RateLimit("upload", cfg.RateLimits.Upload, middleware.KeyByClient), Feedback.UploadFeedbackFileHandler)
+	feedbackGrp.Get("/imports", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackRead), Feedback.GetImportJobsHandler)
+	feedbackGrp.Get("/imports/:id", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackRead), Feedback.GetImportJobHandler)
+	feedbackGrp.Post("/fetch", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackWrite), middleware.RateLimit("fetch", cfg.RateLimits.Fetch, middleware.KeyByClient), Feedback.FetchFeedbackHandler)
+	feedbackGrp.Get("/generate", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackRead), Feedback.GenerateFeedbackHandler)
 	feedbackGrp.Get("/analyses", middleware.AuthRequired(), Feedback.GetFeedbacksByUserIdHandler)
+	feedbackGrp.Post("/bulk", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackWrite), middleware.RateLimitWhen("analyze", cfg.RateLimits.Analyze, middleware.KeyByClient, Feedback.ReanalyzeRequested), Feedback.BulkFeedbacksHandler)
+	feedbackGrp.Post("/triage", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackWrite), Feedback.BulkTriageHandler)
+	feedbackGrp.Patch("/:id/triage", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackWrite), Feedback.TriageFeedbackHandler)
+	feedbackGrp.Get("/:id", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackRead), Feedback.GetFeedbackHandler)
+	feedbackGrp.Patch("/:id", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackWrite), middleware.RateLimitWhen("analyze", cfg.RateLimits.Analyze, middleware.KeyByClient, Feedback.TextPatched), Feedback.UpdateFeedbackHandler)
+	feedbackGrp.Delete("/:id", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackWrite), Feedback.DeleteFeedbackHandler)
+	feedbackGrp.Get("/:id/transitions", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackRead), Feedback.GetFeedbackTransitionsHandler)
+	feedbackGrp.Get("/:id/comments", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackRead), Feedback.GetCommentsHandler)
+	// Comments have an author, they are written from user sessions only
+	feedbackGrp.Post("/:id/comments", middleware.AuthRequired(), Feedback.CreateCommentHandler)
+	feedbackGrp.Patch("/:id/comments/:commentId", middleware.AuthRequired(), Feedback.UpdateCommentHandler)
+	feedbackGrp.Delete("/:id/comments/:commentId", middleware.AuthRequired(), Feedback.DeleteCommentHandler)
+
+	// Customer routes, the authors of the board's feedbacks
+	customerGrp := api.Group("/customers", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackRead))
+	customerGrp.Get("/", Feedback.GetCustomersHandler)
+	customerGrp.Get("/:id", Feedback.GetCustomerHandler)
+	customerGrp.Get("/:id/feedbacks", Feedback.GetCustomerFeedbacksHandler)
+	customerGrp.Get("/:id/sentiment", Feedback.GetCustomerSentimentHandler)
+
+	// Tag routes, the labels members put on the board's feedbacks
+	tagGrp := api.Group("/tags")
+	tagGrp.Get("/", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackRead), Feedback.GetTagsHandler)
+	tagGrp.Post("/", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackWrite), Feedback.CreateTagHandler)
+	tagGrp.Post("/apply", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackWrite), Feedback.ApplyTagsHandler)
+	tagGrp.Post("/remove", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackWrite), Feedback.RemoveTagsHandler)
+	tagGrp.Patch("/:id", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackWrite), Feedback.UpdateTagHandler)
+	tagGrp.Delete("/:id", middleware.AuthOrAPIKey(apiKey.ScopeFeedbackWrite), Feedback.DeleteTagHandler)
 
 	boardGrp := api.Group("/board")
-	boardGrp.Get("/metrics", middleware.AuthRequired(), Board.BoardMetricsHandler)
+	boardGrp.Get("/metrics", middleware.AuthOrAPIKey(apiKey.ScopeMetricsRead), Board.BoardMetricsHandler)
+	boardGrp.Get("/api-keys", middleware.AuthRequired(), Board.GetAPIKeysHandler)
+	boardGrp.Post("/api-keys", middleware.AuthRequired(), Board.CreateAPIKeyHandler)
+	boardGrp.Delete("/api-keys/:id", middleware.AuthRequired(), Board.RevokeAPIKeyHandler)
+	boardGrp.Get("/webhook", middleware.AuthRequired(), Board.GetWebhookHandler)
+	boardGrp.Post("/webhook/rotate", middleware.AuthRequired(), Board.RotateWebhookHandler)
+	boardGrp.Get("/metadata-fields", middleware.AuthRequired(), Board.GetMetadataFieldsHandler)
+	boardGrp.Put("/metadata-fields", middleware.AuthRequired(), Board.SaveMetadataFieldHandler)
+	boardGrp.Delete("/metadata-fields/:key", middleware.AuthRequired(), Board.DeleteMetadataFieldHandler)
+	boardGrp.Delete("/:id", middleware.AuthRequired(), Board.DeleteBoardHandler)
+
+	// Trash routes, deleted boards and feedbacks are kept until the purge
+	trashGrp := api.Group("/trash", middleware.AuthRequired())
+	trashGrp.Get("/", Board.GetTrashHandler)
+	trashGrp.Post("/boards/:id/restore", Board.RestoreBoardHandler)
+	trashGrp.Post("/feedbacks/:id/restore", Board.RestoreFeedbackHandler)
+
+	// Inbound webhook, authenticated by the board token and the payload signature
+	ingestGrp := api.Group("/ingest")
+	ingestGrp.Post("/webhook/:board_token", middleware.RateLimit("analyze", cfg.RateLimits.Analyze, middleware.KeyByBoardToken), Feedback.WebhookHandler)
+
+	// Feedback source routes
+	sourceGrp := api.Group("/sources", middleware.AuthRequired())
+	sourceGrp.Get("/", Source.GetSourcesHandler)
+	sourceGrp.Post("/", Source.CreateSourceHandler)
+	sourceGrp.Delete("/:id", Source.DeleteSourceHandler)
+	sourceGrp.Get("/:id/runs", Source.GetSyncRunsHandler)
+	sourceGrp.Post("/:id/sync", Source.SyncSourceHandler)
 	return nil
 }
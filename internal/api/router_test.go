@@ -5,12 +5,13 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/config"
 )
 
 func TestSetupRoutes(t *testing.T) {
 	t.Run("Setup routes successfully", func(t *testing.T) {
 		app := fiber.New()
-		err := SetupRoutes(app)
+		err := SetupRoutes(app, &config.Config{AllowedOrigins: "*"})
 		assert.NoError(t, err)
 
 		// Test that the app was properly configured
@@ -0,0 +1,269 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/tot0p/env"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/logging"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/rateLimit"
+)
+
+// Keys of the settings which cannot have a default
+const (
+	KeySecretKey     = "SECRET_KEY"
+	KeyMistralAPIKey = "MISTRAL_API_KEY"
+)
+
+// redacted replaces the value of the secrets in String
+const redacted = "[REDACTED]"
+
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// Database holds the connection settings of Postgres
+type Database struct {
+	User     string
+	Password string
+	Name     string
+	Host     string
+	Port     int
+	SSLMode  string
+}
+
+// RateLimits are the limits of the route groups, see middleware.RateLimit
+type RateLimits struct {
+	// Auth limits the logins and registrations by IP
+	Auth rateLimit.Policy
+	// Upload and Fetch limit the imports calling the analyzer by API key or user
+	Upload rateLimit.Policy
+	Fetch  rateLimit.Policy
+	// Analyze limits the other routes calling the analyzer by API key or user, by board token for the webhook
+	Analyze rateLimit.Policy
+}
+
+// Config is the configuration of the API and the CLI, loaded once at startup
+type Config struct {
+	Port     int
+	Env      string
+	Database Database
+	// RedisURL is optional, the cache is disabled without it
+	RedisURL        string
+	SecretKey       string
+	SessionDuration time.Duration
+	MistralAPIKey   string
+	EmailPassword   string
+	SentryDSN       string
+	AllowedOrigins  string
+	TrashRetention  time.Duration
+	LogLevel        string
+	LogFormat       string
+	RateLimits      RateLimits
+	// ProxyHeader holds the client IP set by the load balancer, such as X-Forwarded-For, empty when exposed directly
+	ProxyHeader string
+	// ShutdownTimeout bounds the draining of the requests and the background work on SIGTERM
+	ShutdownTimeout time.Duration
+}
+
+// ValidationError lists every missing or invalid setting
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Load reads the configuration from the .env file, when there is one, and the environment, then validates it.
+// The database settings are always required, required lists the other keys the caller cannot run without.
+func Load(required ...string) (*Config, error) {
+	if err := env.Load(); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read .env: %w", err)
+	}
+	return load(os.Getenv, required)
+}
+
+func load(get func(key string) string, required []string) (*Config, error) {
+	l := loader{get: get}
+	cfg := &Config{
+		Port: l.int("PORT", 3000),
+		Env:  l.string("ENV", "development"),
+		Database: Database{
+			User:     l.required("DB_USER"),
+			Password: l.string("DB_PASSWORD", ""),
+			Name:     l.required("DB_NAME"),
+			Host:     l.required("DB_HOST"),
+			Port:     l.int("DB_PORT", 5432),
+			SSLMode:  l.sslMode(),
+		},
+		RedisURL:        l.redisURL(),
+		SecretKey:       l.string(KeySecretKey, ""),
+		SessionDuration: l.duration("SESSION_DURATION", 3*time.Hour),
+		MistralAPIKey:   l.string(KeyMistralAPIKey, ""),
+		EmailPassword:   l.string("EMAIL_PASSWORD", ""),
+		SentryDSN:       l.string("SENTRY_DSN", ""),
+		AllowedOrigins:  l.string("ALLOWED_ORIGINS", "*"),
+		TrashRetention:  time.Duration(l.int("TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour,
+		LogLevel:        l.oneOf("LOG_LEVEL", "info", logging.Levels),
+		LogFormat:       l.oneOf("LOG_FORMAT", logging.FormatJSON, logging.Formats),
+		RateLimits: RateLimits{
+			Auth:    l.rateLimit("RATE_LIMIT_AUTH", "10/1m"),
+			Upload:  l.rateLimit("RATE_LIMIT_UPLOAD", "20/1h"),
+			Fetch:   l.rateLimit("RATE_LIMIT_FETCH", "30/1h"),
+			Analyze: l.rateLimit("RATE_LIMIT_ANALYZE", "120/1h,apikey:600/1h,board:600/1h"),
+		},
+		ProxyHeader:     l.string("PROXY_HEADER", ""),
+		ShutdownTimeout: l.duration("SHUTDOWN_TIMEOUT", 25*time.Second),
+	}
+	for _, key := range required {
+		l.required(key)
+	}
+
+	if len(l.problems) > 0 {
+		return nil, &ValidationError{Problems: l.problems}
+	}
+	return cfg, nil
+}
+
+// String lists the settings with the secrets redacted, for the startup logs
+func (c *Config) String() string {
+	settings := []struct{ key, value string }{
+		{"PORT", strconv.Itoa(c.Port)},
+		{"ENV", c.Env},
+		{"DB_USER", c.Database.User},
+		{"DB_PASSWORD", redact(c.Database.Password)},
+		{"DB_NAME", c.Database.Name},
+		{"DB_HOST", c.Database.Host},
+		{"DB_PORT", strconv.Itoa(c.Database.Port)},
+		{"DB_SSL_MODE", c.Database.SSLMode},
+		{"REDIS_URL", redactURL(c.RedisURL)},
+		{"SECRET_KEY", redact(c.SecretKey)},
+		{"SESSION_DURATION", c.SessionDuration.String()},
+		{"MISTRAL_API_KEY", redact(c.MistralAPIKey)},
+		{"EMAIL_PASSWORD", redact(c.EmailPassword)},
+		{"SENTRY_DSN", redact(c.SentryDSN)},
+		{"ALLOWED_ORIGINS", c.AllowedOrigins},
+		{"TRASH_RETENTION_DAYS", strconv.Itoa(int(c.TrashRetention / (24 * time.Hour)))},
+		{"LOG_LEVEL", c.LogLevel},
+		{"LOG_FORMAT", c.LogFormat},
+		{"RATE_LIMIT_AUTH", c.RateLimits.Auth.String()},
+		{"RATE_LIMIT_UPLOAD", c.RateLimits.Upload.String()},
+		{"RATE_LIMIT_FETCH", c.RateLimits.Fetch.String()},
+		{"RATE_LIMIT_ANALYZE", c.RateLimits.Analyze.String()},
+		{"PROXY_HEADER", c.ProxyHeader},
+		{"SHUTDOWN_TIMEOUT", c.ShutdownTimeout.String()},
+	}
+	parts := make([]string, len(settings))
+	for i, setting := range settings {
+		parts[i] = setting.key + "=" + setting.value
+	}
+	return strings.Join(parts, " ")
+}
+
+// loader reads the settings and collects their problems
+type loader struct {
+	get      func(key string) string
+	problems []string
+}
+
+func (l *loader) problem(format string, args ...interface{}) {
+	l.problems = append(l.problems, fmt.Sprintf(format, args...))
+}
+
+func (l *loader) string(key, def string) string {
+	if value := strings.TrimSpace(l.get(key)); value != "" {
+		return value
+	}
+	return def
+}
+
+func (l *loader) required(key string) string {
+	value := l.string(key, "")
+	if value == "" && !slices.Contains(l.problems, key+" is required") {
+		l.problem("%s is required", key)
+	}
+	return value
+}
+
+func (l *loader) int(key string, def int) int {
+	value := l.string(key, "")
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		l.problem("%s must be a positive number, got %q", key, value)
+		return def
+	}
+	return n
+}
+
+func (l *loader) duration(key string, def time.Duration) time.Duration {
+	value := l.string(key, "")
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		l.problem("%s must be a positive duration such as 3h, got %q", key, value)
+		return def
+	}
+	return d
+}
+
+func (l *loader) rateLimit(key, def string) rateLimit.Policy {
+	policy, err := rateLimit.ParsePolicy(l.string(key, def))
+	if err != nil {
+		l.problem("%s: %v", key, err)
+	}
+	return policy
+}
+
+func (l *loader) oneOf(key, def string, allowed []string) string {
+	value := l.string(key, def)
+	if !slices.Contains(allowed, value) {
+		l.problem("%s must be one of %s, got %q", key, strings.Join(allowed, ", "), value)
+	}
+	return value
+}
+
+// sslMode reads DB_SSL_MODE, DB_SSLMODE being its former name
+func (l *loader) sslMode() string {
+	return l.oneOf("DB_SSL_MODE", l.string("DB_SSLMODE", "disable"), sslModes)
+}
+
+func (l *loader) redisURL() string {
+	value := l.string("REDIS_URL", "")
+	if value == "" {
+		return ""
+	}
+	// The parse error is not reported, it may quote the password
+	if _, err := redis.ParseURL(value); err != nil {
+		l.problem("REDIS_URL must be a redis:// or rediss:// URL")
+	}
+	return value
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redacted
+}
+
+// redactURL hides the password of a URL, the URL is redacted altogether when it cannot be parsed
+func redactURL(value string) string {
+	if value == "" {
+		return ""
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return redacted
+	}
+	return u.Redacted()
+}
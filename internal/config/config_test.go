@@ -0,0 +1,104 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/rateLimit"
+)
+
+func lookup(values map[string]string) func(string) string {
+	return func(key string) string { return values[key] }
+}
+
+func validValues() map[string]string {
+	return map[string]string{
+		"DB_USER":         "feedpulse",
+		"DB_PASSWORD":     "db-secret",
+		"DB_NAME":         "feedpulse",
+		"DB_HOST":         "localhost",
+		"SECRET_KEY":      "jwt-secret",
+		"MISTRAL_API_KEY": "mistral-secret",
+		"REDIS_URL":       "redis://:redis-secret@localhost:6379/0",
+	}
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, err := load(lookup(validValues()), []string{KeySecretKey, KeyMistralAPIKey})
+	assert.NoError(t, err)
+	assert.Equal(t, 3000, cfg.Port)
+	assert.Equal(t, 5432, cfg.Database.Port)
+	assert.Equal(t, "disable", cfg.Database.SSLMode)
+	assert.Equal(t, 3*time.Hour, cfg.SessionDuration)
+	assert.Equal(t, "*", cfg.AllowedOrigins)
+	assert.Equal(t, 30*24*time.Hour, cfg.TrashRetention)
+	assert.Equal(t, 25*time.Second, cfg.ShutdownTimeout)
+	assert.Equal(t, "info", cfg.LogLevel)
+	assert.Equal(t, "json", cfg.LogFormat)
+	assert.Equal(t, rateLimit.Rule{Limit: 10, Window: time.Minute}, cfg.RateLimits.Auth.Default)
+	assert.Equal(t, rateLimit.Rule{Limit: 20, Window: time.Hour}, cfg.RateLimits.Upload.Default)
+	assert.Equal(t, rateLimit.Rule{Limit: 120, Window: time.Hour}, cfg.RateLimits.Analyze.Rule("user:uuid"))
+	assert.Equal(t, rateLimit.Rule{Limit: 600, Window: time.Hour}, cfg.RateLimits.Analyze.Rule("apikey:4"))
+}
+
+func TestLoad_SSLModeAlias(t *testing.T) {
+	values := validValues()
+	values["DB_SSLMODE"] = "require"
+	cfg, err := load(lookup(values), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "require", cfg.Database.SSLMode)
+
+	// DB_SSL_MODE wins over its former name
+	values["DB_SSL_MODE"] = "verify-full"
+	cfg, err = load(lookup(values), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "verify-full", cfg.Database.SSLMode)
+}
+
+func TestLoad_ListsEveryProblem(t *testing.T) {
+	values := map[string]string{
+		"DB_HOST":              "localhost",
+		"DB_PORT":              "postgres",
+		"DB_SSL_MODE":          "sometimes",
+		"REDIS_URL":            "http://:redis-secret@localhost",
+		"TRASH_RETENTION_DAYS": "-1",
+		"SESSION_DURATION":     "forever",
+		"SHUTDOWN_TIMEOUT":     "0s",
+		"LOG_FORMAT":           "xml",
+		"RATE_LIMIT_FETCH":     "30",
+	}
+	_, err := load(lookup(values), []string{KeySecretKey})
+
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.ElementsMatch(t, []string{
+		"DB_USER is required",
+		"DB_NAME is required",
+		`DB_PORT must be a positive number, got "postgres"`,
+		`DB_SSL_MODE must be one of disable, allow, prefer, require, verify-ca, verify-full, got "sometimes"`,
+		"REDIS_URL must be a redis:// or rediss:// URL",
+		`SESSION_DURATION must be a positive duration such as 3h, got "forever"`,
+		`TRASH_RETENTION_DAYS must be a positive number, got "-1"`,
+		`SHUTDOWN_TIMEOUT must be a positive duration such as 3h, got "0s"`,
+		`LOG_FORMAT must be one of json, text, got "xml"`,
+		`RATE_LIMIT_FETCH: invalid rate limit "30": expected a limit and a window such as 10/1m, or off`,
+		"SECRET_KEY is required",
+	}, validationErr.Problems)
+	assert.NotContains(t, err.Error(), "redis-secret")
+}
+
+func TestConfig_String(t *testing.T) {
+	cfg, err := load(lookup(validValues()), nil)
+	assert.NoError(t, err)
+
+	s := cfg.String()
+	for _, secret := range []string{"db-secret", "jwt-secret", "mistral-secret", "redis-secret"} {
+		assert.NotContains(t, s, secret)
+	}
+	assert.Contains(t, s, "DB_PASSWORD=[REDACTED]")
+	assert.Contains(t, s, "REDIS_URL=redis://:xxxxx@localhost:6379/0")
+	assert.Contains(t, s, "EMAIL_PASSWORD= ")
+	assert.Contains(t, s, "DB_HOST=localhost")
+}
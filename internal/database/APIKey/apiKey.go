@@ -0,0 +1,63 @@
+package APIKey
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/APIKey"
+	"gorm.io/gorm"
+)
+
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// CreateAPIKey creates a new API key
+func CreateAPIKey(key APIKey.APIKey) (APIKey.APIKey, error) {
+	result := database.DB.Create(&key)
+	if result.Error != nil {
+		return APIKey.APIKey{}, result.Error
+	}
+	return key, nil
+}
+
+// GetAPIKeysByBoardID returns all API keys of a board, revoked ones included
+func GetAPIKeysByBoardID(boardID int) ([]APIKey.APIKey, error) {
+	var keys []APIKey.APIKey
+	result := database.DB.Where("board_id = ?", boardID).Order("id").Find(&keys)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return keys, nil
+}
+
+// GetAPIKeyByPrefix returns the API key with the given lookup prefix, keys of boards in the trash are not found
+func GetAPIKeyByPrefix(prefix string) (APIKey.APIKey, error) {
+	var key APIKey.APIKey
+	result := database.DB.Where("prefix = ? AND board_id IN (SELECT id FROM boards WHERE deleted_at IS NULL)", prefix).First(&key)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return APIKey.APIKey{}, ErrAPIKeyNotFound
+		}
+		return APIKey.APIKey{}, result.Error
+	}
+	return key, nil
+}
+
+// RevokeAPIKey revokes an API key of a board
+func RevokeAPIKey(boardID, id int) error {
+	result := database.DB.Model(&APIKey.APIKey{}).
+		Where("id = ? AND board_id = ? AND revoked_at IS NULL", id, boardID).
+		Update("revoked_at", time.Now().UTC())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// TouchAPIKey records the last use of an API key
+func TouchAPIKey(id int) error {
+	return database.DB.Model(&APIKey.APIKey{}).Where("id = ?", id).Update("last_used_at", time.Now().UTC()).Error
+}
@@ -0,0 +1,83 @@
+package APIKey
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/APIKey"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// setupTest creates a mock database connection for testing
+func setupTest(t *testing.T) sqlmock.Sqlmock {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:                 db,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	database.DB = gormDB
+	return mock
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	mock := setupTest(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "api_keys"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	key, err := CreateAPIKey(APIKey.APIKey{Name: "ci", Prefix: "fp_1234abcd", Hash: "hash", Scopes: []string{"feedback:write"}, BoardID: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, key.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAPIKeyByPrefix(t *testing.T) {
+	mock := setupTest(t)
+
+	mock.ExpectQuery(`SELECT (.+) FROM "api_keys" WHERE prefix = \$1`).
+		WithArgs("fp_1234abcd", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "prefix", "scopes", "board_id"}).AddRow(1, "fp_1234abcd", `["metrics:read"]`, 2))
+
+	key, err := GetAPIKeyByPrefix("fp_1234abcd")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"metrics:read"}, key.Scopes)
+	assert.Equal(t, 2, key.BoardID)
+
+	mock.ExpectQuery(`SELECT (.+) FROM "api_keys" WHERE prefix = \$1`).
+		WithArgs("fp_unknown", 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err = GetAPIKeyByPrefix("fp_unknown")
+	assert.True(t, errors.Is(err, ErrAPIKeyNotFound))
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	mock := setupTest(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "api_keys" SET "revoked_at"=\$1,"updated_at"=\$2 WHERE id = \$3 AND board_id = \$4 AND revoked_at IS NULL`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	assert.NoError(t, RevokeAPIKey(2, 1))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "api_keys" SET (.+) WHERE id = \$3 AND board_id = \$4`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	assert.True(t, errors.Is(RevokeAPIKey(2, 99), ErrAPIKeyNotFound))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -98,7 +98,7 @@ func UpdateAnalysis(analysis Analysis.Analysis) (Analysis.CleanAnalysis, error)
 	return cleanAnalysis, nil
 }
 
-// DeleteAnalysis deletes an analysis by its ID
+// DeleteAnalysis permanently deletes an analysis by its ID so that its feedback can be analyzed again
 func DeleteAnalysis(id int) error {
 	var analysis Analysis.Analysis
 	result := database.DB.Where("id = ?", id).First(&analysis)
@@ -109,7 +109,7 @@ func DeleteAnalysis(id int) error {
 		return result.Error
 	}
 
-	result = database.DB.Delete(&analysis)
+	result = database.DB.Unscoped().Delete(&analysis)
 	if result.Error != nil {
 		return result.Error
 	}
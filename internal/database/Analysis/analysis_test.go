@@ -161,14 +161,14 @@ func TestAddAnalysis(t *testing.T) {
 		AddRow(1, time.Now(), time.Now(), time.Now(), "email", "Test feedback")
 
 	// The AddAnalysis function first checks if the feedback exists with the FeedbackID
-	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE id = \$1 ORDER BY "feedbacks"."id" LIMIT \$2`).
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE id = \$1 AND "feedbacks"."deleted_at" IS NULL ORDER BY "feedbacks"."id" LIMIT \$2`).
 		WithArgs(1, 1).
 		WillReturnRows(feedbackRows)
 
 	// Setup expectations for the create operation
 	mock.ExpectBegin()
 	mock.ExpectQuery(`INSERT INTO "analyses"`).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 0.8, "Product", 1).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 0.8, "Product", 1, nil).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 	mock.ExpectCommit()
 
@@ -182,7 +182,7 @@ func TestAddAnalysis(t *testing.T) {
 	assert.Equal(t, testAnalysis.SentimentScore, createdAnalysis.SentimentScore)
 
 	// Test with feedback not found error
-	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE id = \$1 ORDER BY "feedbacks"."id" LIMIT \$2`).
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE id = \$1 AND "feedbacks"."deleted_at" IS NULL ORDER BY "feedbacks"."id" LIMIT \$2`).
 		WithArgs(1, 1).
 		WillReturnError(gorm.ErrRecordNotFound)
 
@@ -191,13 +191,13 @@ func TestAddAnalysis(t *testing.T) {
 	assert.Contains(t, err.Error(), "feedback not found")
 
 	// Test with database error during analysis creation
-	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE id = \$1 ORDER BY "feedbacks"."id" LIMIT \$2`).
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE id = \$1 AND "feedbacks"."deleted_at" IS NULL ORDER BY "feedbacks"."id" LIMIT \$2`).
 		WithArgs(1, 1).
 		WillReturnRows(feedbackRows)
 
 	mock.ExpectBegin()
 	mock.ExpectQuery(`INSERT INTO "analyses"`).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 0.8, "Product", 1).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 0.8, "Product", 1, nil).
 		WillReturnError(errors.New("database error"))
 	mock.ExpectRollback()
 
@@ -1,7 +1,13 @@
 package Board
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Analysis"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/User"
 
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
@@ -60,7 +66,10 @@ func UpdateBoard(board Board.Board) (Board.Board, error) {
 	return board, nil
 }
 
-// DeleteBoard deletes a board by its ID and its associated feedbacks
+var ErrBoardNotInTrash = errors.New("board not found in the trash")
+
+// DeleteBoard puts a board in the trash along with its feedbacks and their analyses, all stamped with the same time.
+// Its members are kept so that they can list and restore it, see RestoreBoard.
 func DeleteBoard(id int) error {
 	var board Board.Board
 	result := database.DB.Where("id = ?", id).First(&board)
@@ -71,41 +80,71 @@ func DeleteBoard(id int) error {
 		return result.Error
 	}
 
-	// Delete associated data in a transaction to ensure atomicity
-	err := database.DB.Transaction(func(tx *gorm.DB) error {
-		// First, get all feedbacks related to this board
-		var feedbacks []Feedback.Feedback
-		if err := tx.Where("board_id = ?", id).Find(&feedbacks).Error; err != nil {
+	deletedAt := time.Now().UTC().Truncate(time.Microsecond)
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Analysis.Analysis{}).
+			Where("feedback_id IN (?)", tx.Model(&Feedback.Feedback{}).Select("id").Where("board_id = ?", id)).
+			Update("deleted_at", deletedAt).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&Feedback.Feedback{}).Where("board_id = ?", id).Update("deleted_at", deletedAt).Error; err != nil {
 			return err
 		}
+		return tx.Model(&board).Update("deleted_at", deletedAt).Error
+	})
+}
 
-		// For each feedback, delete its analyses and then the feedback itself
-		for _, feedback := range feedbacks {
-			// Delete analyses related to this feedback
-			if err := tx.Exec("DELETE FROM analyses WHERE feedback_id = ?", feedback.BaseModel.Id).Error; err != nil {
-				return err
-			}
-
-			// Delete the feedback
-			if err := tx.Delete(&feedback).Error; err != nil {
-				return err
-			}
+// GetDeletedBoardsByUserID returns the boards of a user that are in the trash, most recently deleted first
+func GetDeletedBoardsByUserID(userID int) ([]Board.Board, error) {
+	boards := make([]Board.Board, 0)
+	result := database.DB.Unscoped().
+		Joins("JOIN user_boards ON boards.id = user_boards.board_id").
+		Where("user_boards.user_id = ? AND boards.deleted_at IS NOT NULL", userID).
+		Order("boards.deleted_at DESC").
+		Find(&boards)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return boards, nil
+}
+
+// RestoreBoard takes a board of a user out of the trash along with the feedbacks and analyses deleted with it.
+// Feedbacks deleted before the board stay in the trash.
+func RestoreBoard(userID, id int) (Board.Board, error) {
+	var board Board.Board
+	result := database.DB.Unscoped().
+		Joins("JOIN user_boards ON boards.id = user_boards.board_id").
+		Where("boards.id = ? AND user_boards.user_id = ? AND boards.deleted_at IS NOT NULL", id, userID).
+		First(&board)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return Board.Board{}, ErrBoardNotInTrash
 		}
+		return Board.Board{}, result.Error
+	}
 
-		// Remove associations with users (from junction table)
-		if err := tx.Exec("DELETE FROM user_boards WHERE board_id = ?", id).Error; err != nil {
+	deletedAt := board.DeletedAt.Time
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&Analysis.Analysis{}).
+			Where("deleted_at = ? AND feedback_id IN (?)", deletedAt, tx.Unscoped().Model(&Feedback.Feedback{}).Select("id").Where("board_id = ?", id)).
+			Update("deleted_at", nil).Error
+		if err != nil {
 			return err
 		}
-
-		// Finally delete the board itself
-		if err := tx.Delete(&board).Error; err != nil {
+		err = tx.Unscoped().Model(&Feedback.Feedback{}).
+			Where("board_id = ? AND deleted_at = ?", id, deletedAt).
+			Update("deleted_at", nil).Error
+		if err != nil {
 			return err
 		}
-
-		return nil
+		return tx.Unscoped().Model(&board).Update("deleted_at", nil).Error
 	})
-
-	return err
+	if err != nil {
+		return Board.Board{}, err
+	}
+	board.DeletedAt = gorm.DeletedAt{}
+	return board, nil
 }
 
 // GetBoardByName returns boards that match the given name (exact match)
@@ -131,10 +170,10 @@ func GetBoardsWithUsers(id int) (Board.Board, error) {
 	return board, nil
 }
 
-// GetBoardsWithFeedbacks returns a board with its associated feedbacks
+// GetBoardsWithFeedbacks returns a board with its associated feedbacks and their tags
 func GetBoardsWithFeedbacks(id int) (Board.Board, error) {
 	var board Board.Board
-	result := database.DB.Preload("Feedbacks").Where("id = ?", id).First(&board)
+	result := database.DB.Preload("Feedbacks.Tags").Where("id = ?", id).First(&board)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return Board.Board{}, errors.New("board not found")
@@ -176,6 +215,27 @@ func GetBoardsByUserUUID(userUUID string) ([]Board.Board, error) {
 	return boards, nil
 }
 
+// ErrNoUserBoard is returned by GetFirstBoardByUserUUID when the user has no board
+var ErrNoUserBoard = errors.New("no boards found for this user")
+
+// GetFirstBoardByUserUUID returns the oldest board the user has access to,
+// it is the board used by the endpoints working on "the board of the user"
+func GetFirstBoardByUserUUID(userUUID string) (Board.Board, error) {
+	var board Board.Board
+	err := database.DB.Joins("JOIN user_boards ON boards.id = user_boards.board_id").
+		Joins("JOIN users ON user_boards.user_id = users.id").
+		Where("users.uuid = ?", userUUID).
+		Order("boards.id ASC").
+		Take(&board).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Board.Board{}, ErrNoUserBoard
+		}
+		return Board.Board{}, err
+	}
+	return board, nil
+}
+
 // AssociateBoardUser associates a user with a board in the junction table
 func AssociateBoardUser(boardID int, userID int) error {
 	// Check if the board exists
@@ -197,3 +257,72 @@ func AssociateBoardUser(boardID int, userID int) error {
 
 	return nil
 }
+
+// GetBoardNotificationEmail returns the email used for the negative feedback alerts of a board
+func GetBoardNotificationEmail(boardID int) (string, error) {
+	board, err := GetBoardsWithUsers(boardID)
+	if err != nil {
+		return "", err
+	}
+	if len(board.Users) == 0 {
+		return "", fmt.Errorf("board with ID %d has no members", boardID)
+	}
+	return board.Users[0].Email, nil
+}
+
+// GetBoardByWebhookToken returns the board owning the given webhook token
+func GetBoardByWebhookToken(token string) (Board.Board, error) {
+	var board Board.Board
+	result := database.DB.Where("webhook_token = ?", token).First(&board)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return Board.Board{}, errors.New("board not found")
+		}
+		return Board.Board{}, result.Error
+	}
+	return board, nil
+}
+
+// EnsureWebhookCredentials returns the board with its webhook credentials, generating them if missing
+func EnsureWebhookCredentials(boardID int) (Board.Board, error) {
+	board, err := GetBoardByID(boardID)
+	if err != nil {
+		return Board.Board{}, err
+	}
+	if board.WebhookToken != nil && *board.WebhookToken != "" && board.WebhookSecret != "" {
+		return board, nil
+	}
+	return RotateWebhookCredentials(boardID)
+}
+
+// RotateWebhookCredentials replaces the webhook token and secret of a board
+func RotateWebhookCredentials(boardID int) (Board.Board, error) {
+	token, err := randomHex(16)
+	if err != nil {
+		return Board.Board{}, err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return Board.Board{}, err
+	}
+
+	result := database.DB.Model(&Board.Board{}).Where("id = ?", boardID).Updates(map[string]interface{}{
+		"webhook_token":  token,
+		"webhook_secret": secret,
+	})
+	if result.Error != nil {
+		return Board.Board{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return Board.Board{}, errors.New("board not found")
+	}
+	return GetBoardByID(boardID)
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
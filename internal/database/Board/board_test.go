@@ -145,7 +145,7 @@ func TestCreateBoard(t *testing.T) {
 	// Setup expectations for the create operation
 	mock.ExpectBegin()
 	mock.ExpectQuery(`INSERT INTO "boards"`).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "New Test Board").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "New Test Board", nil).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 	mock.ExpectCommit()
 
@@ -159,7 +159,7 @@ func TestCreateBoard(t *testing.T) {
 	// Test with database error
 	mock.ExpectBegin()
 	mock.ExpectQuery(`INSERT INTO "boards"`).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "New Test Board").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "New Test Board", nil).
 		WillReturnError(errors.New("database error"))
 	mock.ExpectRollback()
 
@@ -249,42 +249,17 @@ func TestDeleteBoard(t *testing.T) {
 	// Setup expectations for transaction
 	mock.ExpectBegin()
 
-	// Expect retrieval of related feedbacks
-	feedbackRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "date", "channel", "text", "board_id"}).
-		AddRow(1, time.Now(), time.Now(), time.Now(), "email", "Test feedback 1", 1).
-		AddRow(2, time.Now(), time.Now(), time.Now(), "web", "Test feedback 2", 1)
-
-	mock.ExpectQuery(`SELECT (.+) FROM "feedbacks" WHERE (.+)`).
-		WithArgs(1).
-		WillReturnRows(feedbackRows)
-
-	// Expect deletion of analyses for each feedback
-	mock.ExpectExec(`DELETE FROM analyses WHERE feedback_id = \$1`).
-		WithArgs(1).
-		WillReturnResult(sqlmock.NewResult(0, 1))
-
-	mock.ExpectExec(`DELETE FROM "feedbacks"`).
-		WithArgs(1).
-		WillReturnResult(sqlmock.NewResult(0, 1))
-
-	mock.ExpectExec(`DELETE FROM analyses WHERE feedback_id = \$1`).
-		WithArgs(2).
+	// The analyses, feedbacks and board go to the trash with the same time, the members are kept
+	mock.ExpectExec(`UPDATE "analyses" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE feedback_id IN \(SELECT "id" FROM "feedbacks" WHERE board_id = \$3 AND "feedbacks"."deleted_at" IS NULL\) AND "analyses"."deleted_at" IS NULL`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`UPDATE "feedbacks" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE board_id = \$3 AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`UPDATE "boards" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE "boards"."deleted_at" IS NULL AND "id" = \$3`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	mock.ExpectExec(`DELETE FROM "feedbacks"`).
-		WithArgs(2).
-		WillReturnResult(sqlmock.NewResult(0, 1))
-
-	// Expect deletion of user associations
-	mock.ExpectExec(`DELETE FROM user_boards WHERE board_id = \$1`).
-		WithArgs(1).
-		WillReturnResult(sqlmock.NewResult(0, 2)) // 2 user associations deleted
-
-	// Expect deletion of board
-	mock.ExpectExec(`DELETE FROM "boards"`).
-		WithArgs(1).
-		WillReturnResult(sqlmock.NewResult(0, 1)) // 1 board deleted
-
 	mock.ExpectCommit()
 
 	// Call the function we're testing
@@ -302,14 +277,14 @@ func TestDeleteBoard(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Equal(t, "board not found", err.Error())
 
-	// Test transaction error (e.g., during feedback retrieval)
+	// Test transaction error (e.g., while trashing the analyses)
 	mock.ExpectQuery(`SELECT (.+) FROM "boards" WHERE (.+)`).
 		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
 
 	mock.ExpectBegin()
-	mock.ExpectQuery(`SELECT (.+) FROM "feedbacks" WHERE (.+)`).
-		WithArgs(2).
+	mock.ExpectExec(`UPDATE "analyses" SET (.+) WHERE (.+)`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 2).
 		WillReturnError(errors.New("transaction error"))
 	mock.ExpectRollback()
 
@@ -382,7 +357,7 @@ func TestGetBoardsWithUsers(t *testing.T) {
 		AddRow(testBoard.Id, testBoard.CreatedAt, testBoard.UpdatedAt, testBoard.Name)
 
 	// First query: Get the board - Note that we need to match 2 arguments (id and LIMIT)
-	mock.ExpectQuery(`SELECT (.+) FROM "boards" WHERE id = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+	mock.ExpectQuery(`SELECT (.+) FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 		WithArgs(1, 1). // GORM sends id=1 and LIMIT 1
 		WillReturnRows(boardRows)
 
@@ -414,7 +389,7 @@ func TestGetBoardsWithUsers(t *testing.T) {
 	assert.Equal(t, 2, len(board.Users)) // Check that we have the expected number of users
 
 	// Test not found error
-	mock.ExpectQuery(`SELECT (.+) FROM "boards" WHERE id = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+	mock.ExpectQuery(`SELECT (.+) FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 		WithArgs(999, 1).
 		WillReturnError(gorm.ErrRecordNotFound)
 
@@ -423,7 +398,7 @@ func TestGetBoardsWithUsers(t *testing.T) {
 	assert.Equal(t, "board not found", err.Error())
 
 	// Test other error
-	mock.ExpectQuery(`SELECT (.+) FROM "boards" WHERE id = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+	mock.ExpectQuery(`SELECT (.+) FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 		WithArgs(2, 1).
 		WillReturnError(errors.New("database error"))
 
@@ -511,7 +486,7 @@ func TestGetBoardsByUserID(t *testing.T) {
 	}
 
 	// Expect a JOIN query on user_boards and a WHERE condition for the user ID
-	mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id WHERE user_boards.user_id = \$1`).
+	mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id WHERE user_boards.user_id = \$1 AND "boards"."deleted_at" IS NULL`).
 		WithArgs(10). // User ID 10 for testing
 		WillReturnRows(rows)
 
@@ -529,7 +504,7 @@ func TestGetBoardsByUserID(t *testing.T) {
 	}
 
 	// Test database error scenario
-	mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id WHERE user_boards.user_id = \$1`).
+	mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id WHERE user_boards.user_id = \$1 AND "boards"."deleted_at" IS NULL`).
 		WithArgs(11). // Different user ID for error test
 		WillReturnError(errors.New("database error"))
 
@@ -563,7 +538,7 @@ func TestGetBoardsByUserUUID(t *testing.T) {
 	}
 
 	// Expect a JOIN query on user_boards and a WHERE condition for the user UUID
-	mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id JOIN users ON user_boards.user_id = users.id WHERE users.uuid = \$1`).
+	mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id JOIN users ON user_boards.user_id = users.id WHERE users.uuid = \$1 AND "boards"."deleted_at" IS NULL`).
 		WithArgs("uuid-123"). // Example UUID for testing
 		WillReturnRows(rows)
 
@@ -581,7 +556,7 @@ func TestGetBoardsByUserUUID(t *testing.T) {
 	}
 
 	// Test database error scenario
-	mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id JOIN users ON user_boards.user_id = users.id WHERE users.uuid = \$1`).
+	mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id JOIN users ON user_boards.user_id = users.id WHERE users.uuid = \$1 AND "boards"."deleted_at" IS NULL`).
 		WithArgs("uuid-456"). // Different UUID for error test
 		WillReturnError(errors.New("database error"))
 
@@ -605,7 +580,7 @@ func TestAssociateBoardUser(t *testing.T) {
 		boardRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name"}).
 			AddRow(1, time.Now(), time.Now(), "Test Board")
 
-		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE "boards"."id" = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE "boards"."id" = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 			WithArgs(boardID, 1).
 			WillReturnRows(boardRows)
 
@@ -636,7 +611,7 @@ func TestAssociateBoardUser(t *testing.T) {
 			t.Fatalf("Error setting up test: %v", err)
 		}
 
-		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE "boards"."id" = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE "boards"."id" = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 			WithArgs(999, 1).
 			WillReturnError(gorm.ErrRecordNotFound)
 
@@ -654,7 +629,7 @@ func TestAssociateBoardUser(t *testing.T) {
 		boardRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name"}).
 			AddRow(1, time.Now(), time.Now(), "Test Board")
 
-		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE "boards"."id" = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE "boards"."id" = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(boardRows)
 
@@ -682,7 +657,7 @@ func TestAssociateBoardUser(t *testing.T) {
 		userRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "uuid", "username", "email", "password"}).
 			AddRow(10, time.Now(), time.Now(), "uuid-123", "testuser", "test@example.com", "password")
 
-		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE "boards"."id" = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE "boards"."id" = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 			WithArgs(boardID, 1).
 			WillReturnRows(boardRows)
 
@@ -698,3 +673,125 @@ func TestAssociateBoardUser(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+
+func TestGetBoardNotificationEmail(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	mock.ExpectQuery(`SELECT (.+) FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Board"))
+	mock.ExpectQuery(`SELECT (.+) FROM "user_boards" WHERE "user_boards"."board_id" = \$1`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"board_id", "user_id"}).AddRow(1, 10))
+	mock.ExpectQuery(`SELECT (.+) FROM "users" WHERE "users"."id" = \$1`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(10, "owner@example.com"))
+
+	email, err := GetBoardNotificationEmail(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "owner@example.com", email)
+
+	// Board without members
+	mock.ExpectQuery(`SELECT (.+) FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
+		WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "Empty"))
+	mock.ExpectQuery(`SELECT (.+) FROM "user_boards" WHERE "user_boards"."board_id" = \$1`).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"board_id", "user_id"}))
+
+	_, err = GetBoardNotificationEmail(2)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "has no members")
+}
+
+func TestGetBoardByWebhookToken(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	mock.ExpectQuery(`SELECT (.+) FROM "boards" WHERE webhook_token = \$1 AND "boards"."deleted_at" IS NULL`).
+		WithArgs("tok", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "webhook_token", "webhook_secret"}).AddRow(3, "Board", "tok", "secret"))
+
+	board, err := GetBoardByWebhookToken("tok")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, board.Id)
+	assert.Equal(t, "secret", board.WebhookSecret)
+
+	mock.ExpectQuery(`SELECT (.+) FROM "boards" WHERE webhook_token = \$1 AND "boards"."deleted_at" IS NULL`).
+		WithArgs("unknown", 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err = GetBoardByWebhookToken("unknown")
+	assert.EqualError(t, err, "board not found")
+}
+
+func TestEnsureWebhookCredentials(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	// Existing credentials are returned as is
+	mock.ExpectQuery(`SELECT (.+) FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL`).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "webhook_token", "webhook_secret"}).AddRow(1, "Board", "tok", "secret"))
+
+	board, err := EnsureWebhookCredentials(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "tok", *board.WebhookToken)
+
+	// Missing credentials are generated
+	mock.ExpectQuery(`SELECT (.+) FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL`).
+		WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "webhook_token", "webhook_secret"}).AddRow(2, "Board", nil, ""))
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "boards" SET (.+) WHERE id = \$\d+ AND "boards"."deleted_at" IS NULL`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT (.+) FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL`).
+		WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "webhook_token", "webhook_secret"}).AddRow(2, "Board", "new-tok", "new-secret"))
+
+	board, err = EnsureWebhookCredentials(2)
+	assert.NoError(t, err)
+	assert.Equal(t, "new-tok", *board.WebhookToken)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetFirstBoardByUserUUID(t *testing.T) {
+	t.Run("returns the oldest board", func(t *testing.T) {
+		mock, err := setupTest()
+		if err != nil {
+			t.Fatalf("Error setting up test: %v", err)
+		}
+
+		mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards ON boards.id = user_boards.board_id JOIN users ON user_boards.user_id = users.id WHERE users.uuid = \$1 AND "boards"."deleted_at" IS NULL ORDER BY boards.id ASC LIMIT \$2`).
+			WithArgs("uuid-123", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "Oldest board"))
+
+		board, err := GetFirstBoardByUserUUID("uuid-123")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, board.Id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user without board", func(t *testing.T) {
+		mock, err := setupTest()
+		if err != nil {
+			t.Fatalf("Error setting up test: %v", err)
+		}
+
+		mock.ExpectQuery(`SELECT (.+) FROM "boards" JOIN user_boards (.+) ORDER BY boards.id ASC LIMIT \$2`).
+			WithArgs("uuid-123", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err = GetFirstBoardByUserUUID("uuid-123")
+		assert.ErrorIs(t, err, ErrNoUserBoard)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,45 @@
+package Board
+
+import (
+	"errors"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Board"
+	"gorm.io/gorm/clause"
+)
+
+var ErrMetadataFieldNotFound = errors.New("metadata field not found")
+
+// GetMetadataSchema returns the metadata fields declared on a board
+func GetMetadataSchema(boardID int) (Board.MetadataSchema, error) {
+	var fields []Board.MetadataField
+	result := database.DB.Where("board_id = ?", boardID).Order("key").Find(&fields)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return fields, nil
+}
+
+// SaveMetadataField declares a metadata field on a board, replacing the type and allowed values of an existing key
+func SaveMetadataField(field Board.MetadataField) (Board.MetadataField, error) {
+	result := database.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "board_id"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"type", "allowed_values", "updated_at"}),
+	}).Create(&field)
+	if result.Error != nil {
+		return Board.MetadataField{}, result.Error
+	}
+	return field, nil
+}
+
+// DeleteMetadataField removes the declaration of a metadata field, the values stored on feedbacks are kept
+func DeleteMetadataField(boardID int, key string) error {
+	result := database.DB.Where("board_id = ? AND key = ?", boardID, key).Delete(&Board.MetadataField{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrMetadataFieldNotFound
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package Board
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Board"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+)
+
+func TestSaveMetadataField(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "metadata_fields" (.+) ON CONFLICT \("board_id","key"\) DO UPDATE SET "type"="excluded"."type","allowed_values"="excluded"."allowed_values"`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 3, "plan", "string", `["free","pro"]`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectCommit()
+
+	field, err := SaveMetadataField(Board.MetadataField{BoardID: 3, Key: "plan", Type: "string", AllowedValues: []string{"free", "pro"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 7, field.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteMetadataField_NotFound(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "metadata_fields" WHERE board_id = \$1 AND key = \$2`).
+		WithArgs(3, "plan").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	assert.ErrorIs(t, DeleteMetadataField(3, "plan"), ErrMetadataFieldNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMetadataSchema_Validate(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	mock.ExpectQuery(`SELECT \* FROM "metadata_fields" WHERE board_id = \$1 ORDER BY key`).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "key", "type", "allowed_values"}).
+			AddRow(1, 3, "beta", "boolean", nil).
+			AddRow(2, 3, "plan", "string", `["free","pro"]`).
+			AddRow(3, 3, "seats", "number", nil).
+			AddRow(4, 3, "renewal", "date", nil))
+
+	schema, err := GetMetadataSchema(3)
+	assert.NoError(t, err)
+	assert.Len(t, schema, 4)
+
+	// Text values are converted to the declared types, undeclared keys are kept as is
+	metadata, err := schema.Validate(Feedback.Metadata{"beta": "true", "plan": "pro", "seats": "12", "renewal": "2025-01-31", "region": "eu"})
+	assert.NoError(t, err)
+	assert.Equal(t, Feedback.Metadata{"beta": true, "plan": "pro", "seats": float64(12), "renewal": "2025-01-31", "region": "eu"}, metadata)
+
+	tests := []struct {
+		name     string
+		metadata Feedback.Metadata
+		contains string
+	}{
+		{"Not allowed", Feedback.Metadata{"plan": "enterprise"}, `metadata "plan" must be one of free, pro`},
+		{"Not a number", Feedback.Metadata{"seats": "many"}, `metadata "seats" must be a number`},
+		{"Not a boolean", Feedback.Metadata{"beta": float64(1)}, `metadata "beta" must be a boolean`},
+		{"Not a date", Feedback.Metadata{"renewal": "soon"}, `metadata "renewal" must be a date`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := schema.Validate(tt.metadata)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.contains)
+		})
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
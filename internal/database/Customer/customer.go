@@ -0,0 +1,262 @@
+package Customer
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	customerModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Customer"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"gorm.io/gorm"
+)
+
+var ErrCustomerNotFound = errors.New("customer not found")
+
+// Intervals accepted by GetCustomerSentimentTimeline
+var Intervals = []string{"day", "week", "month"}
+
+// Sighting is a customer seen as the author of a feedback at the date of that feedback
+type Sighting struct {
+	Customer customerModel.CustomerJson
+	SeenAt   time.Time
+}
+
+// ResolveCustomers upserts the customers of a board seen on ingestion and returns their IDs in the same order.
+// A customer is matched on its external ID, then on its email; the name and attributes of a known customer are updated.
+// A sighting with neither an external ID nor an email does not identify anyone and resolves to nil.
+func ResolveCustomers(tx *gorm.DB, boardID int, sightings []Sighting) ([]*int, error) {
+	ids := make([]*int, len(sightings))
+
+	externalIDs := make([]string, 0)
+	emails := make([]string, 0)
+	for i := range sightings {
+		author := &sightings[i].Customer
+		normalize(author)
+		if author.ExternalID != "" && !slices.Contains(externalIDs, author.ExternalID) {
+			externalIDs = append(externalIDs, author.ExternalID)
+		}
+		if author.Email != "" && !slices.Contains(emails, author.Email) {
+			emails = append(emails, author.Email)
+		}
+	}
+	if len(externalIDs) == 0 && len(emails) == 0 {
+		return ids, nil
+	}
+
+	// Load the stored customers matching the batch
+	var existing []customerModel.Customer
+	query := tx.Where("board_id = ?", boardID)
+	switch {
+	case len(externalIDs) > 0 && len(emails) > 0:
+		query = query.Where("external_id IN ? OR email IN ?", externalIDs, emails)
+	case len(externalIDs) > 0:
+		query = query.Where("external_id IN ?", externalIDs)
+	default:
+		query = query.Where("email IN ?", emails)
+	}
+	if err := query.Find(&existing).Error; err != nil {
+		return nil, err
+	}
+
+	byExternalID := make(map[string]*customerModel.Customer)
+	byEmail := make(map[string]*customerModel.Customer)
+	index := func(customer *customerModel.Customer) {
+		if customer.ExternalID != nil {
+			byExternalID[*customer.ExternalID] = customer
+		}
+		if customer.Email != nil {
+			byEmail[*customer.Email] = customer
+		}
+	}
+	for i := range existing {
+		index(&existing[i])
+	}
+
+	resolved := make([]*customerModel.Customer, len(sightings))
+	created := make([]*customerModel.Customer, 0)
+	changed := make(map[*customerModel.Customer]bool)
+	for i, sighting := range sightings {
+		author := sighting.Customer
+		if author.ExternalID == "" && author.Email == "" {
+			continue
+		}
+
+		var customer *customerModel.Customer
+		if author.ExternalID != "" {
+			customer = byExternalID[author.ExternalID]
+		}
+		if customer == nil && author.Email != "" {
+			customer = byEmail[author.Email]
+		}
+
+		if customer == nil {
+			customer = &customerModel.Customer{
+				BoardID:     boardID,
+				Name:        author.Name,
+				Attributes:  make(map[string]string, len(author.Attributes)),
+				FirstSeenAt: sighting.SeenAt,
+				LastSeenAt:  sighting.SeenAt,
+			}
+			for key, value := range author.Attributes {
+				customer.Attributes[key] = value
+			}
+			if author.ExternalID != "" {
+				externalID := author.ExternalID
+				customer.ExternalID = &externalID
+			}
+			if author.Email != "" {
+				email := author.Email
+				customer.Email = &email
+			}
+			created = append(created, customer)
+		} else if merge(customer, author, sighting.SeenAt, byExternalID, byEmail) && customer.Id != 0 {
+			changed[customer] = true
+		}
+		index(customer)
+		resolved[i] = customer
+	}
+
+	if len(created) > 0 {
+		toCreate := make([]customerModel.Customer, len(created))
+		for i, customer := range created {
+			toCreate[i] = *customer
+		}
+		if err := tx.Create(&toCreate).Error; err != nil {
+			return nil, err
+		}
+		for i := range created {
+			created[i].Id = toCreate[i].Id
+		}
+	}
+	for i := range existing {
+		if changed[&existing[i]] {
+			if err := tx.Save(&existing[i]).Error; err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for i, customer := range resolved {
+		if customer != nil {
+			id := customer.Id
+			ids[i] = &id
+		}
+	}
+	return ids, nil
+}
+
+// normalize trims the identifiers of a customer, emails are compared case-insensitively
+func normalize(customer *customerModel.CustomerJson) {
+	customer.ExternalID = strings.TrimSpace(customer.ExternalID)
+	customer.Email = strings.ToLower(strings.TrimSpace(customer.Email))
+	customer.Name = strings.TrimSpace(customer.Name)
+}
+
+// merge updates a customer with what a new sighting tells about it and reports whether it changed
+func merge(customer *customerModel.Customer, author customerModel.CustomerJson, seenAt time.Time,
+	byExternalID, byEmail map[string]*customerModel.Customer) bool {
+	changed := false
+	// Only fill in identifiers that do not already belong to another customer
+	if author.ExternalID != "" && customer.ExternalID == nil && byExternalID[author.ExternalID] == nil {
+		externalID := author.ExternalID
+		customer.ExternalID = &externalID
+		changed = true
+	}
+	if author.Email != "" && customer.Email == nil && byEmail[author.Email] == nil {
+		email := author.Email
+		customer.Email = &email
+		changed = true
+	}
+	if author.Name != "" && author.Name != customer.Name {
+		customer.Name = author.Name
+		changed = true
+	}
+	for key, value := range author.Attributes {
+		if customer.Attributes == nil {
+			customer.Attributes = map[string]string{}
+		}
+		if customer.Attributes[key] != value {
+			customer.Attributes[key] = value
+			changed = true
+		}
+	}
+	if seenAt.Before(customer.FirstSeenAt) {
+		customer.FirstSeenAt = seenAt
+		changed = true
+	}
+	if seenAt.After(customer.LastSeenAt) {
+		customer.LastSeenAt = seenAt
+		changed = true
+	}
+	return changed
+}
+
+// GetCustomersByBoardID returns the customers of a board with a summary of their feedbacks, most recently seen first.
+// search filters on the name, email or external ID.
+func GetCustomersByBoardID(boardID int, search string, limit, offset int) ([]customerModel.CustomerWithStats, error) {
+	customers := make([]customerModel.CustomerWithStats, 0)
+	query := database.DB.Table("customers").
+		Select("customers.*, COUNT(feedbacks.id) AS feedback_count, AVG(analyses.sentiment_score) AS average_sentiment").
+		Joins("LEFT JOIN feedbacks ON feedbacks.customer_id = customers.id AND feedbacks.deleted_at IS NULL").
+		Joins("LEFT JOIN analyses ON analyses.feedback_id = feedbacks.id").
+		Where("customers.board_id = ?", boardID)
+	if search != "" {
+		pattern := "%" + strings.ToLower(search) + "%"
+		query = query.Where("LOWER(customers.name) LIKE ? OR customers.email LIKE ? OR LOWER(customers.external_id) LIKE ?", pattern, pattern, pattern)
+	}
+	query = query.Group("customers.id").Order("customers.last_seen_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+	if err := query.Scan(&customers).Error; err != nil {
+		return nil, err
+	}
+	return customers, nil
+}
+
+// GetCustomer returns a customer of a board
+func GetCustomer(boardID, id int) (customerModel.Customer, error) {
+	var customer customerModel.Customer
+	result := database.DB.Where("id = ? AND board_id = ?", id, boardID).First(&customer)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return customerModel.Customer{}, ErrCustomerNotFound
+		}
+		return customerModel.Customer{}, result.Error
+	}
+	return customer, nil
+}
+
+// GetCustomerFeedbacks returns the feedback history of a customer with its analyses, newest first
+func GetCustomerFeedbacks(customerID int) ([]feedbackModel.FeedbackWithAnalysis, error) {
+	feedbacks := make([]feedbackModel.FeedbackWithAnalysis, 0)
+	err := database.DB.Table("feedbacks").
+		Select("feedbacks.id AS feedback_id, feedbacks.date, feedbacks.channel, feedbacks.text, feedbacks.board_id, "+
+			"COALESCE(analyses.sentiment_score, 0) AS sentiment_score, COALESCE(analyses.topic, '') AS topic").
+		Joins("LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id").
+		Where("feedbacks.customer_id = ? AND feedbacks.deleted_at IS NULL", customerID).
+		Order("feedbacks.date DESC").
+		Scan(&feedbacks).Error
+	if err != nil {
+		return nil, err
+	}
+	return feedbacks, nil
+}
+
+// GetCustomerSentimentTimeline returns the average sentiment of a customer's feedbacks per day, week or month
+func GetCustomerSentimentTimeline(customerID int, interval string) ([]customerModel.SentimentPoint, error) {
+	points := make([]customerModel.SentimentPoint, 0)
+	err := database.DB.Table("feedbacks").
+		Select("date_trunc(?, feedbacks.date) AS period, COUNT(feedbacks.id) AS feedback_count, AVG(analyses.sentiment_score) AS average_sentiment", interval).
+		Joins("LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id").
+		Where("feedbacks.customer_id = ? AND feedbacks.deleted_at IS NULL", customerID).
+		Group("period").
+		Order("period").
+		Scan(&points).Error
+	if err != nil {
+		return nil, err
+	}
+	return points, nil
+}
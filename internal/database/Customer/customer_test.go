@@ -0,0 +1,125 @@
+package Customer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	customerModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Customer"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// setupTest creates a mock database connection for testing
+func setupTest(t *testing.T) sqlmock.Sqlmock {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:                 db,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	database.DB = gormDB
+	return mock
+}
+
+func TestResolveCustomers(t *testing.T) {
+	mock := setupTest(t)
+
+	first := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	later := first.Add(48 * time.Hour)
+	sightings := []Sighting{
+		{Customer: customerModel.CustomerJson{Name: "Alice", Email: " Alice@Example.com "}, SeenAt: later},
+		{Customer: customerModel.CustomerJson{ExternalID: "u-2", Name: "Bob"}, SeenAt: first},
+		{Customer: customerModel.CustomerJson{Name: "Anonymous"}, SeenAt: first},
+		{Customer: customerModel.CustomerJson{ExternalID: "u-2", Attributes: map[string]string{"plan": "pro"}}, SeenAt: later},
+	}
+
+	// Alice is known by her email, Bob is new
+	mock.ExpectQuery(`SELECT \* FROM "customers" WHERE board_id = \$1 AND \(external_id IN \(\$2\) OR email IN \(\$3\)\)`).
+		WithArgs(1, "u-2", "alice@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "email", "name", "first_seen_at", "last_seen_at"}).
+			AddRow(5, 1, "alice@example.com", "Alice", first, first))
+	mock.ExpectQuery(`INSERT INTO "customers"`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1, "u-2", nil, "Bob", `{"plan":"pro"}`, first, later).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(6))
+	mock.ExpectExec(`UPDATE "customers" SET (.+) WHERE "id" = \$(\d+)`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ids, err := ResolveCustomers(database.DB, 1, sightings)
+	assert.NoError(t, err)
+	assert.Len(t, ids, 4)
+	assert.Equal(t, 5, *ids[0])
+	assert.Equal(t, 6, *ids[1])
+	assert.Nil(t, ids[2])
+	assert.Equal(t, 6, *ids[3])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResolveCustomers_Anonymous(t *testing.T) {
+	mock := setupTest(t)
+
+	ids, err := ResolveCustomers(database.DB, 1, []Sighting{{Customer: customerModel.CustomerJson{Name: "Nobody"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []*int{nil}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMerge(t *testing.T) {
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	other := &customerModel.Customer{}
+	customer := &customerModel.Customer{Name: "Alice", FirstSeenAt: date, LastSeenAt: date}
+
+	// An email owned by another customer is not taken over
+	changed := merge(customer, customerModel.CustomerJson{Email: "bob@example.com"}, date,
+		map[string]*customerModel.Customer{}, map[string]*customerModel.Customer{"bob@example.com": other})
+	assert.False(t, changed)
+	assert.Nil(t, customer.Email)
+
+	changed = merge(customer, customerModel.CustomerJson{Name: "Alice", Attributes: map[string]string{"country": "FR"}}, date.Add(-time.Hour),
+		map[string]*customerModel.Customer{}, map[string]*customerModel.Customer{})
+	assert.True(t, changed)
+	assert.Equal(t, "FR", customer.Attributes["country"])
+	assert.Equal(t, date.Add(-time.Hour), customer.FirstSeenAt)
+	assert.Equal(t, date, customer.LastSeenAt)
+}
+
+func TestGetCustomer_NotFound(t *testing.T) {
+	mock := setupTest(t)
+
+	mock.ExpectQuery(`SELECT (.+) FROM "customers" WHERE id = \$1 AND board_id = \$2`).
+		WithArgs(7, 1, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := GetCustomer(1, 7)
+	assert.True(t, errors.Is(err, ErrCustomerNotFound))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCustomerSentimentTimeline(t *testing.T) {
+	mock := setupTest(t)
+
+	period := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT date_trunc\(\$1, feedbacks.date\) AS period, (.+) FROM "feedbacks" LEFT JOIN analyses (.+) WHERE feedbacks.customer_id = \$2 AND feedbacks.deleted_at IS NULL GROUP BY "period" ORDER BY period`).
+		WithArgs("week", 6).
+		WillReturnRows(sqlmock.NewRows([]string{"period", "feedback_count", "average_sentiment"}).
+			AddRow(period, 2, 0.4).
+			AddRow(period.AddDate(0, 0, 7), 1, nil))
+
+	points, err := GetCustomerSentimentTimeline(6, "week")
+	assert.NoError(t, err)
+	assert.Len(t, points, 2)
+	assert.Equal(t, 2, points[0].FeedbackCount)
+	assert.InDelta(t, 0.4, *points[0].AverageSentiment, 1e-9)
+	assert.Nil(t, points[1].AverageSentiment)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
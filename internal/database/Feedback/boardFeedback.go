@@ -0,0 +1,167 @@
+package Feedback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	Analysis2 "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Analysis"
+	boardDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Analysis"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidFeedback = errors.New("invalid feedback")
+
+// GetFeedbacksByBoardID returns the feedbacks of a board with their tags, oldest first.
+// The tags are loaded like GetBoardFeedback does since both fill the same cache entry.
+func GetFeedbacksByBoardID(boardID int) ([]Feedback.Feedback, error) {
+	feedbacks := make([]Feedback.Feedback, 0)
+	result := database.DB.Preload("Tags").Where("board_id = ?", boardID).Order("id").Find(&feedbacks)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return feedbacks, nil
+}
+
+// GetBoardFeedback returns a feedback of a board with its tags, from the cache when possible
+func GetBoardFeedback(boardID, id int) (Feedback.Feedback, error) {
+	if cached, err := GetFeedbackFromCache(id); err == nil && cached.BoardID == boardID {
+		return cached, nil
+	}
+
+	var feedback Feedback.Feedback
+	result := database.DB.Preload("Tags").Where("id = ? AND board_id = ?", id, boardID).First(&feedback)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return Feedback.Feedback{}, ErrFeedbackNotFound
+		}
+		return Feedback.Feedback{}, result.Error
+	}
+	_ = SetFeedbackToCache(feedback)
+	return feedback, nil
+}
+
+// PatchFeedback changes the content of a feedback of a board.
+// A feedback whose text changes is analyzed again, its negative alert going to userEmail.
+// ErrInvalidFeedback wraps validation failures and ErrDuplicateFeedback is returned when the new content is already stored on the board.
+func PatchFeedback(ctx context.Context, boardID, id int, patch Feedback.FeedbackPatch, userEmail string) (Feedback.Feedback, error) {
+	var feedback Feedback.Feedback
+	result := database.DB.Where("id = ? AND board_id = ?", id, boardID).First(&feedback)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return Feedback.Feedback{}, ErrFeedbackNotFound
+		}
+		return Feedback.Feedback{}, result.Error
+	}
+
+	updates := make(map[string]interface{})
+	if patch.Date != nil {
+		feedback.Date = *patch.Date
+		updates["date"] = feedback.Date
+	}
+	if patch.Channel != nil {
+		if strings.TrimSpace(*patch.Channel) == "" {
+			return Feedback.Feedback{}, fmt.Errorf("%w: channel must not be empty", ErrInvalidFeedback)
+		}
+		feedback.Channel = *patch.Channel
+		updates["channel"] = feedback.Channel
+	}
+	textChanged := false
+	if patch.Text != nil {
+		if strings.TrimSpace(*patch.Text) == "" {
+			return Feedback.Feedback{}, fmt.Errorf("%w: text must not be empty", ErrInvalidFeedback)
+		}
+		textChanged = *patch.Text != feedback.Text
+		feedback.Text = *patch.Text
+		updates["text"] = feedback.Text
+	}
+	if patch.Metadata != nil {
+		schema, err := boardDB.GetMetadataSchema(boardID)
+		if err != nil {
+			return Feedback.Feedback{}, err
+		}
+		metadata, err := schema.Validate(*patch.Metadata)
+		if err != nil {
+			return Feedback.Feedback{}, fmt.Errorf("%w: %v", ErrInvalidFeedback, err)
+		}
+		feedback.Metadata = metadata
+		updates["metadata"] = feedback.Metadata
+	}
+	if patch.RatingScale != nil && patch.Rating == nil {
+		return Feedback.Feedback{}, fmt.Errorf("%w: rating_scale requires a rating", ErrInvalidFeedback)
+	}
+	if patch.Rating != nil {
+		ratingScale, err := Feedback.ValidateRating(patch.Rating, patch.RatingScale)
+		if err != nil {
+			return Feedback.Feedback{}, fmt.Errorf("%w: %v", ErrInvalidFeedback, err)
+		}
+		feedback.Rating = patch.Rating
+		feedback.RatingScale = ratingScale
+		updates["rating"] = *feedback.Rating
+		updates["rating_scale"] = feedback.RatingScale
+	}
+	if len(updates) == 0 {
+		return feedback, nil
+	}
+
+	// The content hash follows the date, channel and text
+	hash := ContentHash(feedback)
+	if feedback.ContentHash == nil || *feedback.ContentHash != hash {
+		var duplicates int64
+		// Feedbacks in the trash still hold their content hash
+		err := database.DB.Unscoped().Model(&Feedback.Feedback{}).
+			Where("board_id = ? AND content_hash = ? AND id <> ?", boardID, hash, id).
+			Count(&duplicates).Error
+		if err != nil {
+			return Feedback.Feedback{}, err
+		}
+		if duplicates > 0 {
+			return Feedback.Feedback{}, ErrDuplicateFeedback
+		}
+		feedback.ContentHash = &hash
+		updates["content_hash"] = hash
+	}
+
+	// The Mistral call is made before the transaction so it does not hold a connection while waiting
+	var analysis Analysis.Analysis
+	if textChanged {
+		var err error
+		analysis, err = analyzeSentiment(ctx, feedback, userEmail)
+		if err != nil {
+			return Feedback.Feedback{}, err
+		}
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Feedback.Feedback{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+			return err
+		}
+		if !textChanged {
+			return nil
+		}
+		if err := tx.Unscoped().Where("feedback_id = ?", id).Delete(&Analysis.Analysis{}).Error; err != nil {
+			return err
+		}
+		_, err := Analysis2.AddAnalysis(analysis, tx)
+		return err
+	})
+	if err != nil {
+		return Feedback.Feedback{}, err
+	}
+
+	_ = DeleteFeedbackFromCache(id)
+	_ = DeleteFeedbackWithAnalysisFromCache(id)
+	return feedback, nil
+}
+
+// DeleteBoardFeedback puts a feedback of a board in the trash, see DeleteFeedback
+func DeleteBoardFeedback(boardID, id int) error {
+	if err := checkFeedbackOnBoard(database.DB, boardID, id); err != nil {
+		return err
+	}
+	return DeleteFeedback(id)
+}
@@ -0,0 +1,122 @@
+package Feedback
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Analysis"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+)
+
+func TestPatchFeedback_Metadata(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	date := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	hash := ContentHash(Feedback.Feedback{Date: date, Channel: "email", Text: "Great"})
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE \(id = \$1 AND board_id = \$2\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(10, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "date", "channel", "text", "content_hash"}).
+			AddRow(10, 1, date, "email", "Great", hash))
+	mock.ExpectQuery(`SELECT \* FROM "metadata_fields" WHERE board_id = \$1`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "key", "type"}).AddRow(1, 1, "seats", "number"))
+	// The content is unchanged, the text is not analyzed again
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "feedbacks" SET "metadata"=\$1,"updated_at"=\$2 WHERE id = \$3 AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(`{"seats":12}`, sqlmock.AnyArg(), 10).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	feedback, err := PatchFeedback(context.Background(), 1, 10, Feedback.FeedbackPatch{Metadata: &Feedback.Metadata{"seats": "12"}}, "owner@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(12), feedback.Metadata["seats"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPatchFeedback_Duplicate(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	date := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE \(id = \$1 AND board_id = \$2\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(10, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "date", "channel", "text"}).
+			AddRow(10, 1, date, "email", "Great"))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "feedbacks" WHERE board_id = \$1 AND content_hash = \$2 AND id <> \$3`).
+		WithArgs(1, sqlmock.AnyArg(), 10).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	channel := "web"
+	_, err = PatchFeedback(context.Background(), 1, 10, Feedback.FeedbackPatch{Channel: &channel}, "owner@example.com")
+	assert.ErrorIs(t, err, ErrDuplicateFeedback)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPatchFeedback_Text(t *testing.T) {
+	date := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	expectPatchedFeedback := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE \(id = \$1 AND board_id = \$2\) AND "feedbacks"."deleted_at" IS NULL`).
+			WithArgs(10, 1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "date", "channel", "text"}).
+				AddRow(10, 1, date, "email", "Great"))
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "feedbacks" WHERE board_id = \$1 AND content_hash = \$2 AND id <> \$3`).
+			WithArgs(1, sqlmock.AnyArg(), 10).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	}
+	text := "Too slow"
+
+	t.Run("analyzed before the transaction", func(t *testing.T) {
+		mock, err := setupTest()
+		if err != nil {
+			t.Fatalf("Error setting up test: %v", err)
+		}
+		stubSentimentAnalysis(t)
+
+		expectPatchedFeedback(mock)
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "feedbacks" SET (.+) WHERE id = \$\d+ AND "feedbacks"."deleted_at" IS NULL`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM "analyses" WHERE feedback_id = \$1`).
+			WithArgs(10).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE id = \$1 AND "feedbacks"."deleted_at" IS NULL`).
+			WithArgs(10, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "board_id"}).AddRow(10, 1))
+		mock.ExpectQuery(`INSERT INTO "analyses"`).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 0.5, "Performance", 10, nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectCommit()
+
+		feedback, err := PatchFeedback(context.Background(), 1, 10, Feedback.FeedbackPatch{Text: &text}, "owner@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, text, feedback.Text)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("failed analysis leaves the feedback untouched", func(t *testing.T) {
+		mock, err := setupTest()
+		if err != nil {
+			t.Fatalf("Error setting up test: %v", err)
+		}
+		previous := analyzeSentiment
+		analyzeSentiment = func(context.Context, Feedback.Feedback, string) (Analysis.Analysis, error) {
+			return Analysis.Analysis{}, errors.New("mistral unavailable")
+		}
+		t.Cleanup(func() { analyzeSentiment = previous })
+
+		expectPatchedFeedback(mock)
+
+		_, err = PatchFeedback(context.Background(), 1, 10, Feedback.FeedbackPatch{Text: &text}, "owner@example.com")
+		assert.EqualError(t, err, "mistral unavailable")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
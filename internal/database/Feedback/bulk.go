@@ -0,0 +1,182 @@
+package Feedback
+
+import (
+	"context"
+	"time"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	Analysis2 "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Analysis"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Analysis"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Tag"
+	"gorm.io/gorm"
+)
+
+// FindFeedbackIDs returns the IDs of the feedbacks of a board matching the filter, oldest first
+func FindFeedbackIDs(boardID int, filter Filter) ([]int, error) {
+	ids := make([]int, 0)
+	query := filter.apply(database.DB.Model(&Feedback.Feedback{}).Where("feedbacks.board_id = ?", boardID))
+	if err := query.Order("feedbacks.id").Pluck("feedbacks.id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// FindBoardFeedbackIDs returns the IDs among ids that are feedbacks of the board, oldest first
+func FindBoardFeedbackIDs(boardID int, ids []int) ([]int, error) {
+	found := make([]int, 0, len(ids))
+	err := database.DB.Model(&Feedback.Feedback{}).
+		Where("board_id = ? AND id IN ?", boardID, ids).
+		Order("id").
+		Pluck("id", &found).Error
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// DeleteFeedbacks puts feedbacks of a board and their analyses in the trash in a single transaction.
+// The number of deleted feedbacks is returned.
+func DeleteFeedbacks(boardID int, ids []int) (int, error) {
+	boardIDs := make([]int, 0, len(ids))
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Feedback.Feedback{}).Where("board_id = ? AND id IN ?", boardID, ids).Pluck("id", &boardIDs).Error; err != nil {
+			return err
+		}
+		if len(boardIDs) == 0 {
+			return nil
+		}
+		return trashFeedbacks(tx, boardIDs, time.Now().UTC())
+	})
+	if err != nil {
+		return 0, err
+	}
+	InvalidateFeedbackCache(ids)
+	return len(boardIDs), nil
+}
+
+// ReanalyzeFeedbacks replaces the analyses of feedbacks of a board in a single transaction,
+// negative alerts go to userEmail. The number of analyzed feedbacks is returned.
+func ReanalyzeFeedbacks(ctx context.Context, boardID int, ids []int, userEmail string) (int, error) {
+	var feedbacks []Feedback.Feedback
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("board_id = ? AND id IN ?", boardID, ids).Order("id").Find(&feedbacks).Error; err != nil {
+			return err
+		}
+		for _, feedback := range feedbacks {
+			if err := tx.Unscoped().Where("feedback_id = ?", feedback.Id).Delete(&Analysis.Analysis{}).Error; err != nil {
+				return err
+			}
+			analysis, err := analyzeSentiment(ctx, feedback, userEmail)
+			if err != nil {
+				return err
+			}
+			if _, err := Analysis2.AddAnalysis(analysis, tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	InvalidateFeedbackCache(ids)
+	return len(feedbacks), nil
+}
+
+// MoveFeedbacks moves feedbacks of a board to the target board in a single transaction.
+// Feedbacks whose external ID or content is already stored on the target board, trash included, are left in place and returned as skipped.
+// The tags, customer and assignee of the source board are cleared from the moved feedbacks, their analyses, comments and triage history follow them.
+func MoveFeedbacks(boardID, targetBoardID int, ids []int) (moved []int, skipped []int, err error) {
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		var feedbacks []Feedback.Feedback
+		if err := tx.Where("board_id = ? AND id IN ?", boardID, ids).Order("id").Find(&feedbacks).Error; err != nil {
+			return err
+		}
+		if len(feedbacks) == 0 {
+			return nil
+		}
+
+		hashes := make([]string, 0, len(feedbacks))
+		externalIDs := make([]string, 0, len(feedbacks))
+		for _, feedback := range feedbacks {
+			if feedback.ContentHash != nil {
+				hashes = append(hashes, *feedback.ContentHash)
+			}
+			if feedback.ExternalID != nil {
+				externalIDs = append(externalIDs, *feedback.ExternalID)
+			}
+		}
+		var existing []Feedback.Feedback
+		err := tx.Unscoped().Select("content_hash", "external_id").
+			Where("board_id = ? AND (content_hash IN ? OR external_id IN ?)", targetBoardID, nonEmpty(hashes), nonEmpty(externalIDs)).
+			Find(&existing).Error
+		if err != nil {
+			return err
+		}
+		taken := make(map[string]bool, 2*len(existing))
+		for _, feedback := range existing {
+			if feedback.ContentHash != nil {
+				taken["hash:"+*feedback.ContentHash] = true
+			}
+			if feedback.ExternalID != nil {
+				taken["external:"+*feedback.ExternalID] = true
+			}
+		}
+
+		for _, feedback := range feedbacks {
+			if (feedback.ContentHash != nil && taken["hash:"+*feedback.ContentHash]) ||
+				(feedback.ExternalID != nil && taken["external:"+*feedback.ExternalID]) {
+				skipped = append(skipped, feedback.Id)
+				continue
+			}
+			// Two moved feedbacks may collide with each other as well
+			if feedback.ContentHash != nil {
+				taken["hash:"+*feedback.ContentHash] = true
+			}
+			if feedback.ExternalID != nil {
+				taken["external:"+*feedback.ExternalID] = true
+			}
+			moved = append(moved, feedback.Id)
+		}
+		if len(moved) == 0 {
+			return nil
+		}
+
+		if err := tx.Where("feedback_id IN ?", moved).Delete(&Tag.FeedbackTag{}).Error; err != nil {
+			return err
+		}
+		err = tx.Model(&Feedback.Feedback{}).Where("id IN ?", moved).Updates(map[string]interface{}{
+			"board_id":    targetBoardID,
+			"customer_id": nil,
+		}).Error
+		if err != nil {
+			return err
+		}
+		// Assignees who are not members of the target board are unassigned
+		return tx.Model(&Feedback.Feedback{}).
+			Where("id IN ? AND assignee_id NOT IN (SELECT user_id FROM user_boards WHERE board_id = ?)", moved, targetBoardID).
+			Update("assignee_id", nil).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	InvalidateFeedbackCache(moved)
+	return moved, skipped, nil
+}
+
+// InvalidateFeedbackCache drops the cached copies of feedbacks, once the transaction changing them is committed
+func InvalidateFeedbackCache(ids []int) {
+	for _, id := range ids {
+		_ = DeleteFeedbackFromCache(id)
+		_ = DeleteFeedbackWithAnalysisFromCache(id)
+	}
+}
+
+// nonEmpty keeps an IN clause valid when no value is given
+func nonEmpty(values []string) []string {
+	if len(values) == 0 {
+		return []string{""}
+	}
+	return values
+}
@@ -0,0 +1,83 @@
+package Feedback
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindFeedbackIDs_Filter(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	mock.ExpectQuery(`SELECT "feedbacks"."id" FROM "feedbacks" WHERE feedbacks.board_id = \$1 AND feedbacks.channel = \$2 AND feedbacks.status IN \(\$3\) AND "feedbacks"."deleted_at" IS NULL ORDER BY feedbacks.id`).
+		WithArgs(1, "email", "new").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(7))
+
+	ids, err := FindFeedbackIDs(1, Filter{Channel: "email", Statuses: []string{"new"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 7}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteFeedbacks(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	// Feedback 9 belongs to another board, it is left untouched
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT "id" FROM "feedbacks" WHERE \(board_id = \$1 AND id IN \(\$2,\$3,\$4\)\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(1, 3, 7, 9).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(7))
+	mock.ExpectExec(`UPDATE "analyses" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE feedback_id IN \(\$3,\$4\) AND "analyses"."deleted_at" IS NULL`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 3, 7).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`UPDATE "feedbacks" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE id IN \(\$3,\$4\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 3, 7).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	deleted, err := DeleteFeedbacks(1, []int{3, 7, 9})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMoveFeedbacks_SkipsDuplicates(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE \(board_id = \$1 AND id IN \(\$2,\$3\)\) AND "feedbacks"."deleted_at" IS NULL ORDER BY id`).
+		WithArgs(1, 3, 7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "content_hash"}).
+			AddRow(3, 1, "hash-3").
+			AddRow(7, 1, "hash-7"))
+	// The content of feedback 7 is already stored on the target board
+	mock.ExpectQuery(`SELECT "content_hash","external_id" FROM "feedbacks" WHERE board_id = \$1 AND \(content_hash IN \(\$2,\$3\) OR external_id IN \(\$4\)\)`).
+		WithArgs(2, "hash-3", "hash-7", "").
+		WillReturnRows(sqlmock.NewRows([]string{"content_hash", "external_id"}).AddRow("hash-7", nil))
+	mock.ExpectExec(`DELETE FROM "feedback_tags" WHERE feedback_id IN \(\$1\)`).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "feedbacks" SET "board_id"=\$1,"customer_id"=\$2,"updated_at"=\$3 WHERE id IN \(\$4\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(2, nil, sqlmock.AnyArg(), 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "feedbacks" SET "assignee_id"=\$1,"updated_at"=\$2 WHERE \(id IN \(\$3\) AND assignee_id NOT IN \(SELECT user_id FROM user_boards WHERE board_id = \$4\)\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(nil, sqlmock.AnyArg(), 3, 2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	moved, skipped, err := MoveFeedbacks(1, 2, []int{3, 7})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3}, moved)
+	assert.Equal(t, []int{7}, skipped)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,210 @@
+package Feedback
+
+import (
+	"errors"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/User"
+	"gorm.io/gorm"
+)
+
+var ErrCommentNotFound = errors.New("comment not found")
+var ErrNotCommentAuthor = errors.New("only the author can change a comment")
+
+// GetFeedbackComments returns the comment threads of a feedback of a board, oldest first
+func GetFeedbackComments(boardID, feedbackID int) ([]Feedback.Comment, error) {
+	if err := checkFeedbackOnBoard(database.DB, boardID, feedbackID); err != nil {
+		return nil, err
+	}
+
+	var comments []Feedback.Comment
+	err := database.DB.Table("feedback_comments").
+		Select("feedback_comments.*, users.username AS author").
+		Joins("LEFT JOIN users ON users.id = feedback_comments.author_id").
+		Where("feedback_comments.feedback_id = ?", feedbackID).
+		Order("feedback_comments.created_at, feedback_comments.id").
+		Scan(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Gather the replies under the first comment of their thread
+	threads := make([]Feedback.Comment, 0)
+	index := make(map[int]int)
+	for _, comment := range comments {
+		if comment.ParentID == nil {
+			index[comment.Id] = len(threads)
+			threads = append(threads, comment)
+		}
+	}
+	for _, comment := range comments {
+		if comment.ParentID == nil {
+			continue
+		}
+		if i, ok := index[*comment.ParentID]; ok {
+			threads[i].Replies = append(threads[i].Replies, comment)
+		}
+	}
+	return threads, nil
+}
+
+// CreateComment adds a comment on a feedback of a board, a reply to a reply joins the thread of its parent.
+// The board members mentioned in the body are recorded on the comment and returned to be notified.
+func CreateComment(boardID int, comment Feedback.Comment) (Feedback.Comment, []User.User, error) {
+	var mentioned []User.User
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := checkFeedbackOnBoard(tx, boardID, comment.FeedbackID); err != nil {
+			return err
+		}
+		if comment.ParentID != nil {
+			var parent Feedback.Comment
+			err := tx.Where("id = ? AND feedback_id = ?", *comment.ParentID, comment.FeedbackID).First(&parent).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return ErrCommentNotFound
+				}
+				return err
+			}
+			if parent.ParentID != nil {
+				comment.ParentID = parent.ParentID
+			}
+		}
+
+		var err error
+		mentioned, err = findMentionedMembers(tx, boardID, comment.Body)
+		if err != nil {
+			return err
+		}
+		comment.Mentions = userIDs(mentioned)
+		return tx.Create(&comment).Error
+	})
+	if err != nil {
+		return Feedback.Comment{}, nil, err
+	}
+
+	_ = DeleteFeedbackWithAnalysisFromCache(comment.FeedbackID)
+	return comment, excludeUser(mentioned, *comment.AuthorID), nil
+}
+
+// UpdateComment changes the body of a comment, only its author can edit it.
+// The members mentioned for the first time are returned to be notified.
+func UpdateComment(boardID, feedbackID, commentID, authorID int, body string) (Feedback.Comment, []User.User, error) {
+	var comment Feedback.Comment
+	var newlyMentioned []User.User
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		comment, err = findComment(tx, boardID, feedbackID, commentID, authorID)
+		if err != nil {
+			return err
+		}
+
+		mentioned, err := findMentionedMembers(tx, boardID, body)
+		if err != nil {
+			return err
+		}
+		previous := make(map[int]bool, len(comment.Mentions))
+		for _, id := range comment.Mentions {
+			previous[id] = true
+		}
+		for _, member := range mentioned {
+			if !previous[member.Id] {
+				newlyMentioned = append(newlyMentioned, member)
+			}
+		}
+
+		comment.Body = body
+		comment.Mentions = userIDs(mentioned)
+		return tx.Model(&comment).Select("body", "mentions", "updated_at").Updates(&comment).Error
+	})
+	if err != nil {
+		return Feedback.Comment{}, nil, err
+	}
+	return comment, excludeUser(newlyMentioned, authorID), nil
+}
+
+// DeleteComment deletes a comment and its replies, only its author can delete it
+func DeleteComment(boardID, feedbackID, commentID, authorID int) error {
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if _, err := findComment(tx, boardID, feedbackID, commentID, authorID); err != nil {
+			return err
+		}
+		return tx.Where("id = ? OR parent_id = ?", commentID, commentID).Delete(&Feedback.Comment{}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	_ = DeleteFeedbackWithAnalysisFromCache(feedbackID)
+	return nil
+}
+
+// findComment loads a comment of a feedback of a board written by the author
+func findComment(tx *gorm.DB, boardID, feedbackID, commentID, authorID int) (Feedback.Comment, error) {
+	if err := checkFeedbackOnBoard(tx, boardID, feedbackID); err != nil {
+		return Feedback.Comment{}, err
+	}
+	var comment Feedback.Comment
+	err := tx.Where("id = ? AND feedback_id = ?", commentID, feedbackID).First(&comment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Feedback.Comment{}, ErrCommentNotFound
+		}
+		return Feedback.Comment{}, err
+	}
+	if comment.AuthorID == nil || *comment.AuthorID != authorID {
+		return Feedback.Comment{}, ErrNotCommentAuthor
+	}
+	return comment, nil
+}
+
+// checkFeedbackOnBoard returns ErrFeedbackNotFound when the feedback is not on the board
+func checkFeedbackOnBoard(tx *gorm.DB, boardID, feedbackID int) error {
+	var count int64
+	err := tx.Model(&Feedback.Feedback{}).Where("id = ? AND board_id = ?", feedbackID, boardID).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrFeedbackNotFound
+	}
+	return nil
+}
+
+// findMentionedMembers returns the board members mentioned with @username in a comment body
+func findMentionedMembers(tx *gorm.DB, boardID int, body string) ([]User.User, error) {
+	usernames := Feedback.MentionedUsernames(body)
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+	var members []User.User
+	err := tx.Model(&User.User{}).
+		Select("users.id, users.username, users.email").
+		Joins("JOIN user_boards ON user_boards.user_id = users.id").
+		Where("user_boards.board_id = ? AND users.username IN ?", boardID, usernames).
+		Order("users.id").
+		Scan(&members).Error
+	if err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func userIDs(users []User.User) []int {
+	ids := make([]int, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.Id)
+	}
+	return ids
+}
+
+// excludeUser drops a user from a list, authors are not notified of their own mentions
+func excludeUser(users []User.User, id int) []User.User {
+	kept := make([]User.User, 0, len(users))
+	for _, user := range users {
+		if user.Id != id {
+			kept = append(kept, user)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,103 @@
+package Feedback
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+)
+
+func TestCreateComment_MentionsAndThread(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	parentID, authorID := 8, 2
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "feedbacks" WHERE \(id = \$1 AND board_id = \$2\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(10, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	// The parent is itself a reply, the comment joins the thread started by comment 5
+	mock.ExpectQuery(`SELECT \* FROM "feedback_comments" WHERE id = \$1 AND feedback_id = \$2`).
+		WithArgs(8, 10, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "feedback_id", "parent_id", "author_id", "body"}).
+			AddRow(8, 10, 5, 3, "First reply"))
+	mock.ExpectQuery(`SELECT users.id, users.username, users.email FROM "users" JOIN user_boards ON user_boards.user_id = users.id WHERE user_boards.board_id = \$1 AND users.username IN \(\$2,\$3,\$4\)`).
+		WithArgs(1, "alice", "bob", "stranger").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email"}).
+			AddRow(2, "alice", "alice@example.com").
+			AddRow(3, "bob", "bob@example.com"))
+	mock.ExpectQuery(`INSERT INTO "feedback_comments" \("created_at","updated_at","feedback_id","parent_id","author_id","body","mentions"\)`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 10, 5, 2, "@alice @bob, @stranger: see this.", "[2,3]").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+	mock.ExpectCommit()
+
+	comment, mentioned, err := CreateComment(1, Feedback.Comment{
+		FeedbackID: 10,
+		ParentID:   &parentID,
+		AuthorID:   &authorID,
+		Body:       "@alice @bob, @stranger: see this.",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 9, comment.Id)
+	assert.Equal(t, 5, *comment.ParentID)
+	assert.Equal(t, []int{2, 3}, comment.Mentions)
+	// The author is not notified of their own mention
+	if assert.Len(t, mentioned, 1) {
+		assert.Equal(t, "bob@example.com", mentioned[0].Email)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateComment_NotAuthor(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "feedbacks" WHERE \(id = \$1 AND board_id = \$2\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(10, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT \* FROM "feedback_comments" WHERE id = \$1 AND feedback_id = \$2`).
+		WithArgs(9, 10, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "feedback_id", "author_id", "body"}).AddRow(9, 10, 3, "Hello"))
+	mock.ExpectRollback()
+
+	_, _, err = UpdateComment(1, 10, 9, 2, "Edited")
+	assert.ErrorIs(t, err, ErrNotCommentAuthor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetFeedbackComments(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	now := time.Now()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "feedbacks" WHERE \(id = \$1 AND board_id = \$2\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(10, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT feedback_comments.\*, users.username AS author FROM "feedback_comments" LEFT JOIN users ON users.id = feedback_comments.author_id WHERE feedback_comments.feedback_id = \$1 ORDER BY feedback_comments.created_at, feedback_comments.id`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "feedback_id", "parent_id", "author_id", "author", "body", "mentions"}).
+			AddRow(5, now, 10, nil, 2, "alice", "Is this a bug?", "[]").
+			AddRow(6, now, 10, nil, 3, "bob", "Duplicate of #12", "[]").
+			AddRow(7, now, 10, 5, 3, "bob", "@alice yes", "[2]"))
+
+	threads, err := GetFeedbackComments(1, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, threads, 2) {
+		assert.Equal(t, "alice", threads[0].Author)
+		if assert.Len(t, threads[0].Replies, 1) {
+			assert.Equal(t, 7, threads[0].Replies[0].Id)
+			assert.Equal(t, []int{2}, threads[0].Replies[0].Mentions)
+		}
+		assert.Empty(t, threads[1].Replies)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
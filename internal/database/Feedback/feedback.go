@@ -1,6 +1,7 @@
 package Feedback
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,13 +9,19 @@ import (
 
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
 	Analysis2 "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Analysis"
+	boardDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/metrics"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Analysis"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Board"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Tag"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/sentimentAnalysis"
 	"gorm.io/gorm"
 )
 
+// analyzeSentiment scores and classifies a feedback, replaced by the tests
+var analyzeSentiment = sentimentAnalysis.SentimentAnalysis
+
 // GetAllFeedbacks returns all feedbacks
 func GetAllFeedbacks() ([]Feedback.Feedback, error) {
 	var feedbacks []Feedback.Feedback
@@ -38,25 +45,48 @@ func GetFeedbackByID(id int) (Feedback.Feedback, error) {
 	return feedback, nil
 }
 
-// CreateFeedback create a new feedback
-func CreateFeedback(feedback Feedback.Feedback, userEmail string) (Feedback.Feedback, error) {
+// CreateFeedback create a new feedback and analyzes it, the outcome tells whether it replaced a feedback with the same external ID.
+// ErrInvalidFeedback wraps validation failures and ErrDuplicateFeedback is returned when the feedback is already stored on its board.
+func CreateFeedback(ctx context.Context, feedback Feedback.Feedback, userEmail string) (Feedback.Feedback, Outcome, error) {
 	// Check if the referenced board exists in the database
 	var board Board.Board
 	result := database.DB.Where("id = ?", feedback.BoardID).First(&board)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return Feedback.Feedback{}, errors.New("board not found: the referenced board_id does not exist")
+			return Feedback.Feedback{}, 0, errors.New("board not found: the referenced board_id does not exist")
 		}
-		return Feedback.Feedback{}, result.Error
+		return Feedback.Feedback{}, 0, result.Error
 	}
+	// Check the custom fields against the ones declared on the board
+	if len(feedback.Metadata) > 0 {
+		schema, err := boardDB.GetMetadataSchema(feedback.BoardID)
+		if err != nil {
+			return Feedback.Feedback{}, 0, err
+		}
+		if feedback.Metadata, err = schema.Validate(feedback.Metadata); err != nil {
+			return Feedback.Feedback{}, 0, fmt.Errorf("%w: %v", ErrInvalidFeedback, err)
+		}
+	}
+	ratingScale, err := Feedback.ValidateRating(feedback.Rating, feedback.RatingScale)
+	if err != nil {
+		return Feedback.Feedback{}, 0, fmt.Errorf("%w: %v", ErrInvalidFeedback, err)
+	}
+	feedback.RatingScale = ratingScale
+	var outcome Outcome
 	// start a transaction
-	err := database.DB.Transaction(func(tx *gorm.DB) error {
-		// Create the feedback
-		result = tx.Create(&feedback)
-		if result.Error != nil {
-			return result.Error
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// Create the feedback, or update it when its external ID is known
+		feedbacks := []Feedback.Feedback{feedback}
+		outcomes, err := UpsertFeedbacks(tx, feedbacks)
+		if err != nil {
+			return err
 		}
-		analysis, err := sentimentAnalysis.SentimentAnalysis(feedback, userEmail)
+		outcome = outcomes[0]
+		if outcome == OutcomeSkipped {
+			return ErrDuplicateFeedback
+		}
+		feedback = feedbacks[0]
+		analysis, err := analyzeSentiment(ctx, feedback, userEmail)
 		if err != nil {
 			return err
 		}
@@ -67,10 +97,13 @@ func CreateFeedback(feedback Feedback.Feedback, userEmail string) (Feedback.Feed
 		return nil
 	})
 	if err != nil {
-		return Feedback.Feedback{}, err
+		return Feedback.Feedback{}, 0, err
+	}
+	if outcome == OutcomeUpdated {
+		InvalidateFeedbackCache([]int{feedback.Id})
 	}
 
-	return feedback, nil
+	return feedback, outcome, nil
 }
 
 // UpdateFeedback update an existing feedback
@@ -91,7 +124,7 @@ func UpdateFeedback(feedback Feedback.Feedback) (Feedback.Feedback, error) {
 	return feedback, nil
 }
 
-// DeleteFeedback delete a feedback by its ID and its associated analyses
+// DeleteFeedback puts a feedback and its analysis in the trash, see RestoreFeedback and PurgeDeleted
 func DeleteFeedback(id int) error {
 	var feedback Feedback.Feedback
 	result := database.DB.Where("id = ?", id).First(&feedback)
@@ -102,23 +135,41 @@ func DeleteFeedback(id int) error {
 		return result.Error
 	}
 
-	// Delete associated analyses first
-	// We use a transaction to ensure atomicity
 	err := database.DB.Transaction(func(tx *gorm.DB) error {
-		// Delete all analyses related to this feedback
-		if err := tx.Where("feedback_id = ?", id).Delete(&Analysis.Analysis{}).Error; err != nil {
-			return err
-		}
+		return trashFeedbacks(tx, []int{id}, time.Now().UTC())
+	})
+	if err != nil {
+		return err
+	}
 
-		// Delete the feedback itself
-		if err := tx.Delete(&feedback).Error; err != nil {
-			return err
-		}
+	_ = DeleteFeedbackFromCache(id)
+	_ = DeleteFeedbackWithAnalysisFromCache(id)
+	return nil
+}
 
-		return nil
-	})
+// trashFeedbacks soft deletes feedbacks and their analyses at the given time
+func trashFeedbacks(tx *gorm.DB, ids []int, deletedAt time.Time) error {
+	err := tx.Model(&Analysis.Analysis{}).Where("feedback_id IN ?", ids).Update("deleted_at", deletedAt).Error
+	if err != nil {
+		return err
+	}
+	return tx.Model(&Feedback.Feedback{}).Where("id IN ?", ids).Update("deleted_at", deletedAt).Error
+}
 
-	return err
+// deleteFeedbackDependents permanently deletes the analyses, tag links, comments and transitions of feedbacks
+func deleteFeedbackDependents(tx *gorm.DB, ids []int) error {
+	dependents := []interface{}{
+		&Analysis.Analysis{},
+		&Tag.FeedbackTag{},
+		&Feedback.Comment{},
+		&Feedback.Transition{},
+	}
+	for _, dependent := range dependents {
+		if err := tx.Unscoped().Where("feedback_id IN ?", ids).Delete(dependent).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // GetFeedbacksByChannel returns feedbacks by channel
@@ -155,6 +206,7 @@ func GetFeedbackFromCache(id int) (Feedback.Feedback, error) {
 		return Feedback.Feedback{}, errors.New("Redis client is not initialized")
 	}
 	val, err := database.RedisClient.Get(ctx, key).Result()
+	metrics.ObserveCacheLookup("feedback", err)
 	if err != nil {
 		return Feedback.Feedback{}, err
 	}
@@ -198,6 +250,7 @@ func GetFeedbackWithAnalysisFromCache(id int) (Feedback.FeedbackWithAnalysis, er
 		return Feedback.FeedbackWithAnalysis{}, errors.New("Redis client is not initialized")
 	}
 	val, err := database.RedisClient.Get(ctx, key).Result()
+	metrics.ObserveCacheLookup("feedbackWithAnalysis", err)
 	if err != nil {
 		return Feedback.FeedbackWithAnalysis{}, err
 	}
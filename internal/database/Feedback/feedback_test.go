@@ -1,22 +1,30 @@
 package Feedback
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
 
-	"github.com/tot0p/env"
-	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/sentimentAnalysis"
-
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Analysis"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/BaseModel"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
+// stubSentimentAnalysis replaces the Mistral analysis with a fixed result for the duration of the test
+func stubSentimentAnalysis(t *testing.T) {
+	previous := analyzeSentiment
+	analyzeSentiment = func(_ context.Context, feedback Feedback.Feedback, _ string) (Analysis.Analysis, error) {
+		return Analysis.Analysis{FeedbackID: feedback.Id, Topic: "Performance", SentimentScore: 0.5}, nil
+	}
+	t.Cleanup(func() { analyzeSentiment = previous })
+}
+
 // setupTest creates a mock database connection for testing
 func setupTest() (sqlmock.Sqlmock, error) {
 	// Create a mock database connection
@@ -155,9 +163,7 @@ func TestCreateFeedback(t *testing.T) {
 		t.Fatalf("Error setting up test: %v", err)
 	}
 
-	//init the mixtral client
-	_ = env.LoadPath("../../../.env")
-	sentimentAnalysis.InitSentimentAnalysis(env.Get("MISTRAL_API_KEY"), env.Get("EMAIL_PASSWORD"))
+	stubSentimentAnalysis(t)
 
 	// Define test data
 	testDate := time.Now()
@@ -173,26 +179,27 @@ func TestCreateFeedback(t *testing.T) {
 		AddRow(1, time.Now(), time.Now(), "Test Board 1")
 
 	// The CreateFeedback function first checks if the board exists with the BoardID
-	mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+	mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 		WithArgs(1, 1).
 		WillReturnRows(boardRows)
 
 	// Setup expectations for the create operation
 	mock.ExpectBegin()
+	expectNoStoredDuplicate(mock)
 	mock.ExpectQuery(`INSERT INTO "feedbacks"`).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), testDate, "email", "The application is great!", 1).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), testDate, "email", "The application is great!", 1, nil, sqlmock.AnyArg(), nil, nil, nil, nil, "new", nil, nil, nil).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 	mock.ExpectQuery(`SELECT (.+) FROM "feedbacks" WHERE (.+)`).
 		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "date", "channel", "text", "board_id"}).
 			AddRow(1, time.Now(), time.Now(), testDate, "email", "The application is great!", 1))
 	mock.ExpectQuery(`INSERT INTO "analyses"`).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 	mock.ExpectCommit()
 	mock.ExpectCommit()
 	// Call the function we're testing
-	createdFeedback, err := CreateFeedback(testFeedback, "dummyemail@example.com")
+	createdFeedback, _, err := CreateFeedback(context.Background(), testFeedback, "dummyemail@example.com")
 
 	// Assert expectations
 	assert.Nil(t, err)
@@ -202,12 +209,13 @@ func TestCreateFeedback(t *testing.T) {
 
 	// Test with database error
 	mock.ExpectBegin()
+	expectNoStoredDuplicate(mock)
 	mock.ExpectQuery(`INSERT INTO "feedbacks"`).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), testDate, "email", "The application is great!", 1).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), testDate, "email", "The application is great!", 1, nil, sqlmock.AnyArg(), nil, nil, nil, nil, "new", nil, nil, nil).
 		WillReturnError(errors.New("database error"))
 	mock.ExpectRollback()
 
-	_, err = CreateFeedback(testFeedback, "dummyemail@example.com")
+	_, _, err = CreateFeedback(context.Background(), testFeedback, "dummyemail@example.com")
 	assert.NotNil(t, err)
 }
 
@@ -227,12 +235,12 @@ func TestCreateFeedback_BoardNotFound(t *testing.T) {
 	}
 
 	// Setup expectations for checking if board exists
-	mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+	mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 		WithArgs(999, 1).
 		WillReturnError(gorm.ErrRecordNotFound)
 
 	// Call the function we're testing
-	_, err = CreateFeedback(testFeedback, "test@example.com")
+	_, _, err = CreateFeedback(context.Background(), testFeedback, "test@example.com")
 	assert.NotNil(t, err)
 	assert.Equal(t, "board not found: the referenced board_id does not exist", err.Error())
 }
@@ -324,15 +332,13 @@ func TestDeleteFeedback(t *testing.T) {
 	// Setup expectations for transaction
 	mock.ExpectBegin()
 
-	// Expect deletion of associated analyses
-	mock.ExpectExec(`DELETE FROM "analyses" WHERE (.+)`).
-		WithArgs(1).
-		WillReturnResult(sqlmock.NewResult(0, 2)) // Assume 2 analyses were deleted
-
-	// Expect deletion of feedback
-	mock.ExpectExec(`DELETE FROM "feedbacks" WHERE (.+)`).
-		WithArgs(1).
-		WillReturnResult(sqlmock.NewResult(0, 1)) // 1 feedback deleted
+	// The feedback and its analysis go to the trash, tags, comments and transitions are kept
+	mock.ExpectExec(`UPDATE "analyses" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE feedback_id IN \(\$3\) AND "analyses"."deleted_at" IS NULL`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "feedbacks" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE id IN \(\$3\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	mock.ExpectCommit()
 
@@ -357,8 +363,8 @@ func TestDeleteFeedback(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
 
 	mock.ExpectBegin()
-	mock.ExpectExec(`DELETE FROM "analyses" WHERE (.+)`).
-		WithArgs(2).
+	mock.ExpectExec(`UPDATE "analyses" SET (.+) WHERE (.+)`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 2).
 		WillReturnError(errors.New("delete error"))
 	mock.ExpectRollback()
 
@@ -1,6 +1,7 @@
 package Feedback
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -13,21 +14,23 @@ import (
 // FetchAndSaveFeedbacks saves multiple feedbacks to the database from any source
 // Returns:
 //   - int: count of successfully saved feedbacks
+//   - int: count of feedbacks skipped because they were already stored
 //   - []string: list of error messages that occurred during processing
 //   - error: any critical error that prevented the overall operation
-func FetchAndSaveFeedbacks(feedbacks []feedbackModel.Feedback, userEmail string) (int, []string, error) {
+func FetchAndSaveFeedbacks(ctx context.Context, feedbacks []feedbackModel.Feedback, userEmail string) (int, int, []string, error) {
 	successCount := 0
+	skippedCount := 0
 	errorMessages := make([]string, 0)
 
 	// Early return if no feedbacks to process
 	if len(feedbacks) == 0 {
-		return 0, errorMessages, nil
+		return 0, 0, errorMessages, nil
 	}
 
 	// Use a transaction to ensure data integrity
 	tx := database.DB.Begin()
 	if tx.Error != nil {
-		return 0, nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+		return 0, 0, nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
 	}
 
 	// Create a map to track which board IDs we've already verified
@@ -48,14 +51,18 @@ func FetchAndSaveFeedbacks(feedbacks []feedbackModel.Feedback, userEmail string)
 				}
 				// Database error, rollback transaction
 				tx.Rollback()
-				return successCount, errorMessages, fmt.Errorf("database error while checking board: %w", result.Error)
+				return successCount, skippedCount, errorMessages, fmt.Errorf("database error while checking board: %w", result.Error)
 			}
 			// Mark this board as verified
 			verifiedBoards[feedback.BoardID] = true
 		}
 
 		// Create the feedback
-		_, err := CreateFeedback(feedback, userEmail)
+		_, _, err := CreateFeedback(ctx, feedback, userEmail)
+		if errors.Is(err, ErrDuplicateFeedback) {
+			skippedCount++
+			continue
+		}
 		if err != nil {
 			errorMsg := fmt.Sprintf("Feedback #%d: %s", i+1, err.Error())
 			errorMessages = append(errorMessages, errorMsg)
@@ -67,11 +74,11 @@ func FetchAndSaveFeedbacks(feedbacks []feedbackModel.Feedback, userEmail string)
 	// Commit transaction if there are successful records
 	if successCount > 0 {
 		if err := tx.Commit().Error; err != nil {
-			return successCount, errorMessages, fmt.Errorf("failed to commit transaction: %w", err)
+			return successCount, skippedCount, errorMessages, fmt.Errorf("failed to commit transaction: %w", err)
 		}
 	} else {
 		tx.Rollback()
 	}
 
-	return successCount, errorMessages, nil
+	return successCount, skippedCount, errorMessages, nil
 }
@@ -1,9 +1,8 @@
 package Feedback
 
 import (
+	"context"
 	"errors"
-	"github.com/tot0p/env"
-	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/sentimentAnalysis"
 	"testing"
 	"time"
 
@@ -17,9 +16,7 @@ import (
 
 func TestFetchAndSaveFeedbacks(t *testing.T) {
 
-	//init the mixtral client
-	_ = env.LoadPath("../../../.env")
-	sentimentAnalysis.InitSentimentAnalysis(env.Get("MISTRAL_API_KEY"), env.Get("EMAIL_PASSWORD"))
+	stubSentimentAnalysis(t)
 
 	// Create fresh mock for each test
 	t.Run("Successfully fetch and save multiple feedbacks", func(t *testing.T) {
@@ -78,6 +75,7 @@ func TestFetchAndSaveFeedbacks(t *testing.T) {
 
 		// Then expect the insert for the first feedback
 		mock.ExpectBegin()
+		expectNoStoredDuplicate(mock)
 		mock.ExpectQuery(`INSERT INTO "feedbacks"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 		mock.ExpectQuery(`SELECT (.+) FROM "feedbacks" WHERE (.+)`).
@@ -85,7 +83,7 @@ func TestFetchAndSaveFeedbacks(t *testing.T) {
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "date", "channel", "text", "board_id"}).
 				AddRow(1, time.Now(), time.Now(), testDate, "email", "The application is great!", 1))
 		mock.ExpectQuery(`INSERT INTO "analyses"`).
-			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 		mock.ExpectCommit()
 
@@ -98,6 +96,7 @@ func TestFetchAndSaveFeedbacks(t *testing.T) {
 
 		// Then expect the insert for the second feedback
 		mock.ExpectBegin()
+		expectNoStoredDuplicate(mock)
 		mock.ExpectQuery(`INSERT INTO "feedbacks"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
 		mock.ExpectQuery(`SELECT (.+) FROM "feedbacks" WHERE (.+)`).
@@ -105,14 +104,14 @@ func TestFetchAndSaveFeedbacks(t *testing.T) {
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "date", "channel", "text", "board_id"}).
 				AddRow(1, time.Now(), time.Now(), testDate, "email", "The application is great!", 1))
 		mock.ExpectQuery(`INSERT INTO "analyses"`).
-			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
 		mock.ExpectCommit()
 		mock.ExpectCommit()
 
 		// Execute the function being tested
-		successCount, errs, err := FetchAndSaveFeedbacks(testFeedbacks, "dummyemail@example.com")
+		successCount, _, errs, err := FetchAndSaveFeedbacks(context.Background(), testFeedbacks, "dummyemail@example.com")
 
 		// Assert results
 		assert.Nil(t, err)
@@ -158,7 +157,7 @@ func TestFetchAndSaveFeedbacks(t *testing.T) {
 		mock.ExpectBegin().WillReturnError(errors.New("transaction begin error"))
 
 		// Execute the function being tested
-		successCount, dbErrors, err := FetchAndSaveFeedbacks(testFeedbacks, "dummyemail@example.com")
+		successCount, _, dbErrors, err := FetchAndSaveFeedbacks(context.Background(), testFeedbacks, "dummyemail@example.com")
 
 		// Assert results
 		assert.Error(t, err)
@@ -212,7 +211,7 @@ func TestFetchAndSaveFeedbacks(t *testing.T) {
 		mock.ExpectRollback()
 
 		// Execute the function being tested
-		successCount, dbErrors, err := FetchAndSaveFeedbacks(testFeedbacks, "dummyemail@example.com")
+		successCount, _, dbErrors, err := FetchAndSaveFeedbacks(context.Background(), testFeedbacks, "dummyemail@example.com")
 
 		// Assert results
 		assert.Nil(t, err)
@@ -249,7 +248,7 @@ func TestFetchAndSaveFeedbacks(t *testing.T) {
 		var testFeedbacks []Feedback.Feedback
 
 		// Execute the function being tested
-		successCount, dbErrors, err := FetchAndSaveFeedbacks(testFeedbacks, "dummyemail@example.com")
+		successCount, _, dbErrors, err := FetchAndSaveFeedbacks(context.Background(), testFeedbacks, "dummyemail@example.com")
 
 		// Assert results
 		assert.Nil(t, err)
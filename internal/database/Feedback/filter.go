@@ -0,0 +1,65 @@
+package Feedback
+
+import (
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Filter narrows a feedback listing, zero values do not filter
+type Filter struct {
+	Channel string
+	// Metadata holds the expected text value of metadata keys
+	Metadata map[string]string
+	// Statuses keeps the feedbacks having one of the triage statuses
+	Statuses []string
+	Priority string
+	// AssigneeID keeps the feedbacks assigned to a user, 0 for the unassigned ones
+	AssigneeID *int
+	// Tags keeps the feedbacks carrying at least one of the tag names
+	Tags []string
+	// Search keeps the feedbacks whose text contains it, case insensitive
+	Search string
+}
+
+// apply adds the conditions of the filter to a query on the feedbacks table
+func (f Filter) apply(query *gorm.DB) *gorm.DB {
+	if f.Channel != "" {
+		query = query.Where("feedbacks.channel = ?", f.Channel)
+	}
+	if len(f.Statuses) > 0 {
+		query = query.Where("feedbacks.status IN ?", f.Statuses)
+	}
+	if f.Priority != "" {
+		query = query.Where("feedbacks.priority = ?", f.Priority)
+	}
+	if f.AssigneeID != nil {
+		if *f.AssigneeID == 0 {
+			query = query.Where("feedbacks.assignee_id IS NULL")
+		} else {
+			query = query.Where("feedbacks.assignee_id = ?", *f.AssigneeID)
+		}
+	}
+	if len(f.Tags) > 0 {
+		query = query.Where("feedbacks.id IN (SELECT feedback_tags.feedback_id FROM feedback_tags JOIN tags ON tags.id = feedback_tags.tag_id WHERE tags.name IN ?)", f.Tags)
+	}
+	if f.Search != "" {
+		query = query.Where("LOWER(feedbacks.text) LIKE ?", "%"+strings.ToLower(f.Search)+"%")
+	}
+	keys := make([]string, 0, len(f.Metadata))
+	for key := range f.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		query = query.Where("feedbacks.metadata ->> ? = ?", key, f.Metadata[key])
+	}
+	return query
+}
+
+// Empty reports whether the filter keeps every feedback
+func (f Filter) Empty() bool {
+	return f.Channel == "" && len(f.Metadata) == 0 && len(f.Statuses) == 0 && f.Priority == "" &&
+		f.AssigneeID == nil && len(f.Tags) == 0 && f.Search == ""
+}
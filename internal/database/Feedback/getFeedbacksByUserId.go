@@ -6,13 +6,14 @@ import (
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/User"
+	"gorm.io/gorm"
 )
 
 var ErrUserNotFound = errors.New("user not found")
 var ErrBoardNotFound = errors.New("no boards found for this user")
 
-// GetFeedbacksWithAnalysesByUserId retrieves feedbacks with their analyses for a given user ID.
-func GetFeedbacksWithAnalysesByUserId(userUUID, channel string) ([]Feedback.FeedbackWithAnalysis, error) {
+// GetFeedbacksWithAnalysesByUserId retrieves feedbacks with their analyses for a given user ID, narrowed by the filter.
+func GetFeedbacksWithAnalysesByUserId(userUUID string, filter Filter) ([]Feedback.FeedbackWithAnalysis, error) {
 	// Check if the user exists
 	var userCount int64
 	err := database.DB.Model(&User.User{}).Where("uuid = ?", userUUID).Count(&userCount).Error
@@ -45,37 +46,78 @@ func GetFeedbacksWithAnalysesByUserId(userUUID, channel string) ([]Feedback.Feed
 		return nil, ErrBoardNotFound
 	}
 
-	// If a channel is specified, filter the feedbacks by channel
-	if channel != "" {
-		var feedbacks []Feedback.FeedbackWithAnalysis
-		for _, boardId := range boardsId {
-			var feedbacksForBoard []Feedback.FeedbackWithAnalysis
-			err = database.DB.Table("feedbacks").
-				Select("feedbacks.*, analyses.*").
-				Joins("LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id").
-				Where("feedbacks.board_id = ? AND feedbacks.channel = ?", boardId, channel).
-				Scan(&feedbacksForBoard).Error
-			if err != nil {
-				return nil, err
-			}
-			feedbacks = append(feedbacks, feedbacksForBoard...)
-		}
-		return feedbacks, nil
-	}
-
-	// If no channel is specified, retrieve all feedbacks for the boards
 	var feedbacks []Feedback.FeedbackWithAnalysis
 	for _, boardId := range boardsId {
 		var feedbacksForBoard []Feedback.FeedbackWithAnalysis
-		err = database.DB.Table("feedbacks").
-			Select("feedbacks.*, analyses.*").
-			Joins("LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id").
-			Where("feedbacks.board_id = ?", boardId).
-			Scan(&feedbacksForBoard).Error
+		err = filter.apply(boardFeedbacksQuery(boardId)).Scan(&feedbacksForBoard).Error
 		if err != nil {
 			return nil, err
 		}
 		feedbacks = append(feedbacks, feedbacksForBoard...)
 	}
+
+	if err := attachTagNames(feedbacks); err != nil {
+		return nil, err
+	}
+	return feedbacks, nil
+}
+
+// GetBoardFeedbacksWithAnalyses retrieves the feedbacks of a board with their analyses, narrowed by the filter and ordered by date
+func GetBoardFeedbacksWithAnalyses(boardID int, filter Filter) ([]Feedback.FeedbackWithAnalysis, error) {
+	feedbacks := make([]Feedback.FeedbackWithAnalysis, 0)
+	err := filter.apply(boardFeedbacksQuery(boardID)).Order("feedbacks.date, feedbacks.id").Scan(&feedbacks).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := attachTagNames(feedbacks); err != nil {
+		return nil, err
+	}
 	return feedbacks, nil
 }
+
+// boardFeedbacksQuery selects the live feedbacks of a board along with their analysis and comment count.
+// The ID comes from the feedback since the ones not analyzed yet have no analysis row.
+func boardFeedbacksQuery(boardID int) *gorm.DB {
+	return database.DB.Table("feedbacks").
+		Select("feedbacks.id AS feedback_id, feedbacks.date, feedbacks.channel, feedbacks.text, feedbacks.board_id, "+
+			"feedbacks.metadata, feedbacks.rating, feedbacks.rating_scale, feedbacks.status, feedbacks.assignee_id, feedbacks.priority, "+
+			"COALESCE(analyses.sentiment_score, 0) AS sentiment_score, COALESCE(analyses.topic, '') AS topic, "+
+			"(SELECT COUNT(*) FROM feedback_comments WHERE feedback_comments.feedback_id = feedbacks.id) AS comment_count").
+		Joins("LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id").
+		Where("feedbacks.board_id = ?", boardID).
+		Where("feedbacks.deleted_at IS NULL")
+}
+
+// attachTagNames fills the tag names of listed feedbacks in a single query
+func attachTagNames(feedbacks []Feedback.FeedbackWithAnalysis) error {
+	if len(feedbacks) == 0 {
+		return nil
+	}
+	ids := make([]int, 0, len(feedbacks))
+	for _, feedback := range feedbacks {
+		ids = append(ids, feedback.FeedbackID)
+	}
+
+	var rows []struct {
+		FeedbackID int
+		Name       string
+	}
+	err := database.DB.Table("feedback_tags").
+		Select("feedback_tags.feedback_id, tags.name").
+		Joins("JOIN tags ON tags.id = feedback_tags.tag_id").
+		Where("feedback_tags.feedback_id IN ?", ids).
+		Order("tags.name").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	names := make(map[int][]string)
+	for _, row := range rows {
+		names[row.FeedbackID] = append(names[row.FeedbackID], row.Name)
+	}
+	for i := range feedbacks {
+		feedbacks[i].Tags = names[feedbacks[i].FeedbackID]
+	}
+	return nil
+}
@@ -56,7 +56,7 @@ func TestGetFeedbacksWithAnalysesByUserId(t *testing.T) {
 		WithArgs(userUUID).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 
-	feedbacks, err := GetFeedbacksWithAnalysesByUserId(userUUID, "")
+	feedbacks, err := GetFeedbacksWithAnalysesByUserId(userUUID, Filter{})
 	assert.Nil(t, feedbacks)
 	assert.Equal(t, ErrUserNotFound, err)
 
@@ -86,7 +86,7 @@ func TestGetFeedbacksWithAnalysesByUserId(t *testing.T) {
 		AddRow(1, testDate, "email", "Great service", 1, 0.8, "Service").
 		AddRow(2, testDate, "web", "Could be better", 1, 0.3, "Quality")
 
-	mock.ExpectQuery(`SELECT feedbacks\.\*, analyses\.\* FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1`).
+	mock.ExpectQuery(`SELECT feedbacks\.id AS feedback_id, (.+), \(SELECT COUNT\(\*\) FROM feedback_comments WHERE feedback_comments.feedback_id = feedbacks.id\) AS comment_count FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1 AND feedbacks.deleted_at IS NULL`).
 		WithArgs(1).
 		WillReturnRows(mockFeedbacks1)
 
@@ -96,11 +96,18 @@ func TestGetFeedbacksWithAnalysesByUserId(t *testing.T) {
 		"sentiment_score", "topic"}).
 		AddRow(3, testDate, "email", "Excellent", 2, 0.9, "Service")
 
-	mock.ExpectQuery(`SELECT feedbacks\.\*, analyses\.\* FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1`).
+	mock.ExpectQuery(`SELECT feedbacks\.id AS feedback_id, (.+), \(SELECT COUNT\(\*\) FROM feedback_comments WHERE feedback_comments.feedback_id = feedbacks.id\) AS comment_count FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1 AND feedbacks.deleted_at IS NULL`).
 		WithArgs(2).
 		WillReturnRows(mockFeedbacks2)
 
-	feedbacks, err = GetFeedbacksWithAnalysesByUserId(userUUID, "")
+	mock.ExpectQuery(`SELECT feedback_tags.feedback_id, tags.name FROM "feedback_tags" JOIN tags ON tags.id = feedback_tags.tag_id WHERE feedback_tags.feedback_id IN \(\$1,\$2,\$3\) ORDER BY tags.name`).
+		WithArgs(1, 2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"feedback_id", "name"}).
+			AddRow(1, "bug").
+			AddRow(1, "urgent").
+			AddRow(3, "praise"))
+
+	feedbacks, err = GetFeedbacksWithAnalysesByUserId(userUUID, Filter{})
 	assert.Nil(t, err)
 	assert.Len(t, feedbacks, 3)
 	if len(feedbacks) >= 3 {
@@ -109,8 +116,11 @@ func TestGetFeedbacksWithAnalysesByUserId(t *testing.T) {
 		assert.Equal(t, "Great service", feedbacks[0].Text)
 		assert.Equal(t, 0.8, feedbacks[0].SentimentScore)
 		assert.Equal(t, "Service", feedbacks[0].Topic)
+		assert.Equal(t, []string{"bug", "urgent"}, feedbacks[0].Tags)
+		assert.Nil(t, feedbacks[1].Tags)
 
 		assert.Equal(t, 3, feedbacks[2].FeedbackID)
+		assert.Equal(t, []string{"praise"}, feedbacks[2].Tags)
 		assert.Equal(t, "email", feedbacks[2].Channel)
 		assert.Equal(t, "Excellent", feedbacks[2].Text)
 		assert.Equal(t, 2, feedbacks[2].BoardID)
@@ -122,7 +132,7 @@ func TestGetFeedbacksWithAnalysesByUserId(t *testing.T) {
 		WithArgs(userUUID).
 		WillReturnError(errors.New("database error"))
 
-	feedbacks, err = GetFeedbacksWithAnalysesByUserId(userUUID, "")
+	feedbacks, err = GetFeedbacksWithAnalysesByUserId(userUUID, Filter{})
 	assert.Nil(t, feedbacks)
 	assert.NotNil(t, err)
 
@@ -141,7 +151,7 @@ func TestGetFeedbacksWithAnalysesByUserId(t *testing.T) {
 		WithArgs(1).
 		WillReturnError(errors.New("database error"))
 
-	feedbacks, err = GetFeedbacksWithAnalysesByUserId(userUUID, "")
+	feedbacks, err = GetFeedbacksWithAnalysesByUserId(userUUID, Filter{})
 	assert.Nil(t, feedbacks)
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "database error")
@@ -161,11 +171,11 @@ func TestGetFeedbacksWithAnalysesByUserId(t *testing.T) {
 		WithArgs(1).
 		WillReturnRows(sqlmock.NewRows([]string{"board_id"}).AddRow(3))
 
-	mock.ExpectQuery(`SELECT feedbacks\.\*, analyses\.\* FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1`).
+	mock.ExpectQuery(`SELECT feedbacks\.id AS feedback_id, (.+), \(SELECT COUNT\(\*\) FROM feedback_comments WHERE feedback_comments.feedback_id = feedbacks.id\) AS comment_count FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1 AND feedbacks.deleted_at IS NULL`).
 		WithArgs(3).
 		WillReturnError(errors.New("database error"))
 
-	feedbacks, err = GetFeedbacksWithAnalysesByUserId(userUUID, "")
+	feedbacks, err = GetFeedbacksWithAnalysesByUserId(userUUID, Filter{})
 	assert.Nil(t, feedbacks)
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "database error")
@@ -183,7 +193,7 @@ func TestGetFeedbacksWithAnalysesByUserId(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"board_id"}).AddRow(1).AddRow(2))
 
 	// Expect query for board 1 with email filter
-	mock.ExpectQuery(`SELECT feedbacks\.\*, analyses\.\* FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1 AND feedbacks.channel = \$2`).
+	mock.ExpectQuery(`SELECT feedbacks\.id AS feedback_id, (.+), \(SELECT COUNT\(\*\) FROM feedback_comments WHERE feedback_comments.feedback_id = feedbacks.id\) AS comment_count FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1 AND feedbacks.deleted_at IS NULL AND feedbacks.channel = \$2`).
 		WithArgs(1, "email").
 		WillReturnRows(sqlmock.NewRows([]string{
 			"feedback_id", "date", "channel", "text", "board_id",
@@ -191,14 +201,17 @@ func TestGetFeedbacksWithAnalysesByUserId(t *testing.T) {
 			AddRow(1, testDate, "email", "Great service", 1, 0.8, "Service"))
 
 	// Expect query for board 2 with email filter
-	mock.ExpectQuery(`SELECT feedbacks\.\*, analyses\.\* FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1 AND feedbacks.channel = \$2`).
+	mock.ExpectQuery(`SELECT feedbacks\.id AS feedback_id, (.+), \(SELECT COUNT\(\*\) FROM feedback_comments WHERE feedback_comments.feedback_id = feedbacks.id\) AS comment_count FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1 AND feedbacks.deleted_at IS NULL AND feedbacks.channel = \$2`).
 		WithArgs(2, "email").
 		WillReturnRows(sqlmock.NewRows([]string{
 			"feedback_id", "date", "channel", "text", "board_id",
 			"sentiment_score", "topic"}).
 			AddRow(3, testDate, "email", "Excellent", 2, 0.9, "Service"))
+	mock.ExpectQuery(`SELECT feedback_tags.feedback_id, tags.name FROM "feedback_tags" JOIN tags ON tags.id = feedback_tags.tag_id WHERE feedback_tags.feedback_id IN \(\$1,\$2\)`).
+		WithArgs(1, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"feedback_id", "name"}))
 
-	feedbacks, err = GetFeedbacksWithAnalysesByUserId(userUUID, "email")
+	feedbacks, err = GetFeedbacksWithAnalysesByUserId(userUUID, Filter{Channel: "email"})
 	assert.Nil(t, err)
 	assert.Len(t, feedbacks, 2)
 	if len(feedbacks) >= 2 {
@@ -212,7 +225,114 @@ func TestGetFeedbacksWithAnalysesByUserId(t *testing.T) {
 		assert.Equal(t, "Excellent", feedbacks[1].Text)
 		assert.Equal(t, 2, feedbacks[1].BoardID)
 	}
+
+	// Test case 8: channel and metadata filters applied
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "users" WHERE uuid = \$1`).
+		WithArgs(userUUID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT "id" FROM "users" WHERE uuid = \$1`).
+		WithArgs(userUUID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(`SELECT board_id FROM "user_boards" WHERE user_id = \$1`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"board_id"}).AddRow(1))
+	mock.ExpectQuery(`SELECT feedbacks\.id AS feedback_id, (.+), \(SELECT COUNT\(\*\) FROM feedback_comments WHERE feedback_comments.feedback_id = feedbacks.id\) AS comment_count FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1 AND feedbacks.deleted_at IS NULL AND feedbacks.channel = \$2 AND feedbacks.metadata ->> \$3 = \$4 AND feedbacks.metadata ->> \$5 = \$6`).
+		WithArgs(1, "email", "app_version", "2.1", "plan", "pro").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"feedback_id", "date", "channel", "text", "board_id", "metadata",
+			"sentiment_score", "topic"}).
+			AddRow(1, testDate, "email", "Great service", 1, `{"plan":"pro","app_version":"2.1"}`, 0.8, "Service"))
+	mock.ExpectQuery(`SELECT feedback_tags.feedback_id, tags.name FROM "feedback_tags" JOIN tags ON tags.id = feedback_tags.tag_id WHERE feedback_tags.feedback_id IN \(\$1\)`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"feedback_id", "name"}))
+
+	feedbacks, err = GetFeedbacksWithAnalysesByUserId(userUUID, Filter{
+		Channel:  "email",
+		Metadata: map[string]string{"plan": "pro", "app_version": "2.1"},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, feedbacks, 1)
+	if len(feedbacks) == 1 {
+		assert.Equal(t, "pro", feedbacks[0].Metadata["plan"])
+	}
+
+	// Test case 9: tag and text search filters applied
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "users" WHERE uuid = \$1`).
+		WithArgs(userUUID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT "id" FROM "users" WHERE uuid = \$1`).
+		WithArgs(userUUID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(`SELECT board_id FROM "user_boards" WHERE user_id = \$1`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"board_id"}).AddRow(1))
+	mock.ExpectQuery(`SELECT feedbacks\.id AS feedback_id, (.+), \(SELECT COUNT\(\*\) FROM feedback_comments WHERE feedback_comments.feedback_id = feedbacks.id\) AS comment_count FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1 AND feedbacks.deleted_at IS NULL AND feedbacks.id IN \(SELECT feedback_tags.feedback_id FROM feedback_tags JOIN tags ON tags.id = feedback_tags.tag_id WHERE tags.name IN \(\$2,\$3\)\) AND LOWER\(feedbacks.text\) LIKE \$4`).
+		WithArgs(1, "bug", "urgent", "%crash%").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"feedback_id", "date", "channel", "text", "board_id",
+			"sentiment_score", "topic", "comment_count"}).
+			AddRow(4, testDate, "web", "App Crash on login", 1, 0.1, "Stability", 2))
+	mock.ExpectQuery(`SELECT feedback_tags.feedback_id, tags.name FROM "feedback_tags" JOIN tags ON tags.id = feedback_tags.tag_id WHERE feedback_tags.feedback_id IN \(\$1\)`).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"feedback_id", "name"}).AddRow(4, "bug"))
+
+	feedbacks, err = GetFeedbacksWithAnalysesByUserId(userUUID, Filter{Tags: []string{"bug", "urgent"}, Search: "Crash"})
+	assert.Nil(t, err)
+	assert.Len(t, feedbacks, 1)
+	if len(feedbacks) == 1 {
+		assert.Equal(t, []string{"bug"}, feedbacks[0].Tags)
+		assert.Equal(t, 2, feedbacks[0].CommentCount)
+	}
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestGetBoardFeedbacksWithAnalyses(t *testing.T) {
+	mock, err := setupTestGetFeedback()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	testDate := time.Now()
+	mock.ExpectQuery(`SELECT feedbacks\.id AS feedback_id, (.+) FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1 AND feedbacks.deleted_at IS NULL AND feedbacks.channel = \$2 ORDER BY feedbacks.date, feedbacks.id`).
+		WithArgs(3, "email").
+		WillReturnRows(sqlmock.NewRows([]string{"feedback_id", "date", "channel", "text", "board_id", "sentiment_score", "topic"}).
+			AddRow(7, testDate, "email", "Great service", 3, 0.8, "Service"))
+	mock.ExpectQuery(`SELECT feedback_tags.feedback_id, tags.name FROM "feedback_tags" (.+) WHERE feedback_tags.feedback_id IN \(\$1\)`).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"feedback_id", "name"}).AddRow(7, "praise"))
+
+	feedbacks, err := GetBoardFeedbacksWithAnalyses(3, Filter{Channel: "email"})
+	assert.NoError(t, err)
+	assert.Len(t, feedbacks, 1)
+	assert.Equal(t, []string{"praise"}, feedbacks[0].Tags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBoardFeedbacksWithAnalyses_NotAnalyzed(t *testing.T) {
+	mock, err := setupTestGetFeedback()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	// Feedback 8 has no analysis yet, its ID still comes from the feedback
+	testDate := time.Now()
+	mock.ExpectQuery(`SELECT feedbacks\.id AS feedback_id, (.+) FROM "feedbacks" LEFT JOIN analyses ON feedbacks.id = analyses.feedback_id WHERE feedbacks.board_id = \$1 AND feedbacks.deleted_at IS NULL ORDER BY feedbacks.date, feedbacks.id`).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"feedback_id", "date", "channel", "text", "board_id", "sentiment_score", "topic"}).
+			AddRow(7, testDate, "email", "Great service", 3, 0.8, "Service").
+			AddRow(8, testDate, "web", "Just imported", 3, 0, ""))
+	mock.ExpectQuery(`SELECT feedback_tags.feedback_id, tags.name FROM "feedback_tags" (.+) WHERE feedback_tags.feedback_id IN \(\$1,\$2\)`).
+		WithArgs(7, 8).
+		WillReturnRows(sqlmock.NewRows([]string{"feedback_id", "name"}).AddRow(8, "bug"))
+
+	feedbacks, err := GetBoardFeedbacksWithAnalyses(3, Filter{})
+	assert.NoError(t, err)
+	if assert.Len(t, feedbacks, 2) {
+		assert.Equal(t, 8, feedbacks[1].FeedbackID)
+		assert.Equal(t, "", feedbacks[1].Topic)
+		assert.Equal(t, []string{"bug"}, feedbacks[1].Tags)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
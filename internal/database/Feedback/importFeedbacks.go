@@ -0,0 +1,48 @@
+package Feedback
+
+import (
+	"context"
+	"time"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	Analysis2 "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Analysis"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"gorm.io/gorm"
+)
+
+// CreateFeedbacks inserts feedbacks with a single statement, without analyzing them.
+// The generated IDs are set on the given slice.
+func CreateFeedbacks(tx *gorm.DB, feedbacks []Feedback.Feedback) error {
+	if len(feedbacks) == 0 {
+		return nil
+	}
+	return tx.Create(&feedbacks).Error
+}
+
+// AnalyzeFeedback runs the sentiment analysis of a saved feedback and stores the result
+func AnalyzeFeedback(ctx context.Context, feedback Feedback.Feedback, userEmail string) error {
+	analysis, err := analyzeSentiment(ctx, feedback, userEmail)
+	if err != nil {
+		return err
+	}
+	if _, err = Analysis2.AddAnalysis(analysis, database.DB); err != nil {
+		return err
+	}
+	// The cached feedback was stored before its analysis existed
+	_ = DeleteFeedbackWithAnalysisFromCache(feedback.Id)
+	return nil
+}
+
+// GetUnanalyzedFeedbacks returns the feedbacks of a board saved since a time which have no analysis, oldest first
+func GetUnanalyzedFeedbacks(boardID int, since time.Time) ([]Feedback.Feedback, error) {
+	var feedbacks []Feedback.Feedback
+	err := database.DB.
+		Where("board_id = ? AND updated_at >= ?", boardID, since).
+		Where("NOT EXISTS (SELECT 1 FROM analyses WHERE analyses.feedback_id = feedbacks.id)").
+		Order("id").
+		Find(&feedbacks).Error
+	if err != nil {
+		return nil, err
+	}
+	return feedbacks, nil
+}
@@ -0,0 +1,153 @@
+package Feedback
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/APIKey"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Analysis"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Board"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Customer"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/ImportJob"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Source"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Tag"
+	"gorm.io/gorm"
+)
+
+// purgeChunkSize is the number of feedbacks permanently deleted per statement
+const purgeChunkSize = 500
+
+var ErrFeedbackNotInTrash = errors.New("feedback not found in the trash")
+
+// PurgeResult counts the rows permanently deleted by PurgeDeleted
+type PurgeResult struct {
+	Boards    int
+	Feedbacks int
+}
+
+// GetDeletedFeedbacks returns the feedbacks of a board that are in the trash, most recently deleted first
+func GetDeletedFeedbacks(boardID int) ([]Feedback.Feedback, error) {
+	feedbacks := make([]Feedback.Feedback, 0)
+	result := database.DB.Unscoped().
+		Where("board_id = ? AND deleted_at IS NOT NULL", boardID).
+		Order("deleted_at DESC").
+		Find(&feedbacks)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return feedbacks, nil
+}
+
+// RestoreFeedback takes a feedback of a board and its analysis out of the trash.
+// The content of a feedback in the trash keeps its place on the board, so restoring it never creates a duplicate.
+func RestoreFeedback(boardID, id int) (Feedback.Feedback, error) {
+	var feedback Feedback.Feedback
+	result := database.DB.Unscoped().Where("id = ? AND board_id = ? AND deleted_at IS NOT NULL", id, boardID).First(&feedback)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return Feedback.Feedback{}, ErrFeedbackNotInTrash
+		}
+		return Feedback.Feedback{}, result.Error
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&Analysis.Analysis{}).Where("feedback_id = ?", id).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Model(&Feedback.Feedback{}).Where("id = ?", id).Update("deleted_at", nil).Error
+	})
+	if err != nil {
+		return Feedback.Feedback{}, err
+	}
+	feedback.DeletedAt = gorm.DeletedAt{}
+	return feedback, nil
+}
+
+// PurgeDeleted permanently deletes the boards and feedbacks put in the trash before the given time.
+// A purged board takes all its data with it: feedbacks, tags, customers, metadata fields, sources, API keys and import jobs.
+func PurgeDeleted(before time.Time) (PurgeResult, error) {
+	var purged PurgeResult
+
+	boardIDs := make([]int, 0)
+	err := database.DB.Unscoped().Model(&Board.Board{}).Where("deleted_at < ?", before).Pluck("id", &boardIDs).Error
+	if err != nil {
+		return purged, err
+	}
+	for _, boardID := range boardIDs {
+		feedbacks, err := purgeBoard(boardID)
+		if err != nil {
+			return purged, err
+		}
+		purged.Boards++
+		purged.Feedbacks += feedbacks
+	}
+
+	feedbackIDs := make([]int, 0)
+	err = database.DB.Unscoped().Model(&Feedback.Feedback{}).Where("deleted_at < ?", before).Order("id").Pluck("id", &feedbackIDs).Error
+	if err != nil {
+		return purged, err
+	}
+	for start := 0; start < len(feedbackIDs); start += purgeChunkSize {
+		chunk := feedbackIDs[start:min(start+purgeChunkSize, len(feedbackIDs))]
+		err := database.DB.Transaction(func(tx *gorm.DB) error {
+			return purgeFeedbacks(tx, chunk)
+		})
+		if err != nil {
+			return purged, err
+		}
+		purged.Feedbacks += len(chunk)
+	}
+	return purged, nil
+}
+
+// purgeBoard permanently deletes a board and everything attached to it, the number of deleted feedbacks is returned
+func purgeBoard(boardID int) (int, error) {
+	feedbackIDs := make([]int, 0)
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&Feedback.Feedback{}).Where("board_id = ?", boardID).Pluck("id", &feedbackIDs).Error; err != nil {
+			return err
+		}
+		for start := 0; start < len(feedbackIDs); start += purgeChunkSize {
+			if err := purgeFeedbacks(tx, feedbackIDs[start:min(start+purgeChunkSize, len(feedbackIDs))]); err != nil {
+				return err
+			}
+		}
+
+		sources := tx.Model(&Source.Source{}).Select("id").Where("board_id = ?", boardID)
+		if err := tx.Where("source_id IN (?)", sources).Delete(&Source.SyncRun{}).Error; err != nil {
+			return err
+		}
+		boardData := []interface{}{
+			&Tag.Tag{},
+			&Customer.Customer{},
+			&Board.MetadataField{},
+			&Source.Source{},
+			&APIKey.APIKey{},
+			&ImportJob.ImportJob{},
+		}
+		for _, data := range boardData {
+			if err := tx.Where("board_id = ?", boardID).Delete(data).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Exec("DELETE FROM user_boards WHERE board_id = ?", boardID).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&Board.Board{}, boardID).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	InvalidateFeedbackCache(feedbackIDs)
+	return len(feedbackIDs), nil
+}
+
+// purgeFeedbacks permanently deletes feedbacks along with their dependents
+func purgeFeedbacks(tx *gorm.DB, ids []int) error {
+	if err := deleteFeedbackDependents(tx, ids); err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("id IN ?", ids).Delete(&Feedback.Feedback{}).Error
+}
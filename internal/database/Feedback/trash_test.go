@@ -0,0 +1,90 @@
+package Feedback
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDeletedFeedbacks(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	deletedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE board_id = \$1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "text", "deleted_at"}).AddRow(4, 1, "Gone", deletedAt))
+
+	feedbacks, err := GetDeletedFeedbacks(1)
+	assert.NoError(t, err)
+	assert.Len(t, feedbacks, 1)
+	assert.Equal(t, deletedAt, feedbacks[0].DeletedAt.Time)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestoreFeedback(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE id = \$1 AND board_id = \$2 AND deleted_at IS NOT NULL ORDER BY "feedbacks"."id" LIMIT \$3`).
+		WithArgs(4, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "deleted_at"}).AddRow(4, 1, time.Now()))
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "analyses" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE feedback_id = \$3`).
+		WithArgs(nil, sqlmock.AnyArg(), 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "feedbacks" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE id = \$3`).
+		WithArgs(nil, sqlmock.AnyArg(), 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	feedback, err := RestoreFeedback(1, 4)
+	assert.NoError(t, err)
+	assert.False(t, feedback.DeletedAt.Valid)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// A feedback which is not in the trash of the board cannot be restored
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE id = \$1 AND board_id = \$2 AND deleted_at IS NOT NULL`).
+		WithArgs(5, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err = RestoreFeedback(1, 5)
+	assert.ErrorIs(t, err, ErrFeedbackNotInTrash)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeDeleted(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	before := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT "id" FROM "boards" WHERE deleted_at < \$1`).
+		WithArgs(before).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(`SELECT "id" FROM "feedbacks" WHERE deleted_at < \$1 ORDER BY id`).
+		WithArgs(before).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(4))
+	mock.ExpectBegin()
+	for _, table := range []string{"analyses", "feedback_tags", "feedback_comments", "feedback_transitions"} {
+		mock.ExpectExec(`DELETE FROM "`+table+`" WHERE feedback_id IN \(\$1,\$2\)`).
+			WithArgs(3, 4).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectExec(`DELETE FROM "feedbacks" WHERE id IN \(\$1,\$2\)`).
+		WithArgs(3, 4).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	purged, err := PurgeDeleted(before)
+	assert.NoError(t, err)
+	assert.Equal(t, PurgeResult{Boards: 0, Feedbacks: 2}, purged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
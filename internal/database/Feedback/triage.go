@@ -0,0 +1,149 @@
+package Feedback
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"gorm.io/gorm"
+)
+
+var ErrFeedbackNotFound = errors.New("feedback not found")
+var ErrAssigneeNotMember = errors.New("the assignee is not a member of the board")
+
+// TriageFeedbacks applies a triage update to feedbacks of a board and records a transition for every changed field.
+// ErrFeedbackNotFound is returned when one of the IDs is not a feedback of the board, nothing is changed then.
+// userID is the board member making the change, nil for an API key. The updated feedbacks are returned.
+func TriageFeedbacks(boardID int, ids []int, update Feedback.TriageUpdate, userID *int) ([]Feedback.Feedback, error) {
+	var feedbacks []Feedback.Feedback
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("board_id = ? AND id IN ?", boardID, ids).Order("id").Find(&feedbacks).Error; err != nil {
+			return err
+		}
+		if len(feedbacks) != countDistinct(ids) {
+			return ErrFeedbackNotFound
+		}
+
+		// The assignee must be a member of the board
+		if update.AssigneeID != nil && *update.AssigneeID != 0 {
+			var members int64
+			err := tx.Table("user_boards").
+				Where("board_id = ? AND user_id = ?", boardID, *update.AssigneeID).
+				Count(&members).Error
+			if err != nil {
+				return err
+			}
+			if members == 0 {
+				return ErrAssigneeNotMember
+			}
+		}
+
+		updates := make(map[string]interface{})
+		transitions := make([]Feedback.Transition, 0)
+		for i := range feedbacks {
+			feedback := &feedbacks[i]
+			if update.Status != nil && *update.Status != feedback.Status {
+				from := feedback.Status
+				transitions = append(transitions, newTransition(feedback.Id, Feedback.TriageFieldStatus, &from, update.Status, userID))
+				feedback.Status = *update.Status
+				updates["status"] = *update.Status
+			}
+			if update.AssigneeID != nil && !sameAssignee(feedback.AssigneeID, *update.AssigneeID) {
+				var assignee *int
+				if *update.AssigneeID != 0 {
+					assignee = update.AssigneeID
+				}
+				transitions = append(transitions, newTransition(feedback.Id, Feedback.TriageFieldAssignee, itoa(feedback.AssigneeID), itoa(assignee), userID))
+				feedback.AssigneeID = assignee
+				updates["assignee_id"] = assignee
+			}
+			if update.Priority != nil && !samePriority(feedback.Priority, *update.Priority) {
+				var priority *string
+				if *update.Priority != "" {
+					priority = update.Priority
+				}
+				transitions = append(transitions, newTransition(feedback.Id, Feedback.TriageFieldPriority, feedback.Priority, priority, userID))
+				feedback.Priority = priority
+				updates["priority"] = priority
+			}
+		}
+		if len(transitions) == 0 {
+			return nil
+		}
+
+		// Feedbacks already in the requested state are updated to the same values
+		if err := tx.Model(&Feedback.Feedback{}).Where("board_id = ? AND id IN ?", boardID, ids).Updates(updates).Error; err != nil {
+			return err
+		}
+		return tx.Create(&transitions).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, feedback := range feedbacks {
+		_ = DeleteFeedbackFromCache(feedback.Id)
+		_ = DeleteFeedbackWithAnalysisFromCache(feedback.Id)
+	}
+	return feedbacks, nil
+}
+
+// GetFeedbackTransitions returns the triage history of a feedback of a board, oldest first
+func GetFeedbackTransitions(boardID, feedbackID int) ([]Feedback.Transition, error) {
+	var count int64
+	err := database.DB.Model(&Feedback.Feedback{}).Where("id = ? AND board_id = ?", feedbackID, boardID).Count(&count).Error
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, ErrFeedbackNotFound
+	}
+
+	transitions := make([]Feedback.Transition, 0)
+	result := database.DB.Where("feedback_id = ?", feedbackID).Order("created_at, id").Find(&transitions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return transitions, nil
+}
+
+func newTransition(feedbackID int, field string, from, to *string, userID *int) Feedback.Transition {
+	return Feedback.Transition{
+		FeedbackID: feedbackID,
+		Field:      field,
+		From:       from,
+		To:         to,
+		UserID:     userID,
+	}
+}
+
+func sameAssignee(current *int, assignee int) bool {
+	if current == nil {
+		return assignee == 0
+	}
+	return *current == assignee
+}
+
+func samePriority(current *string, priority string) bool {
+	if current == nil {
+		return priority == ""
+	}
+	return *current == priority
+}
+
+func itoa(value *int) *string {
+	if value == nil {
+		return nil
+	}
+	text := strconv.Itoa(*value)
+	return &text
+}
+
+func countDistinct(ids []int) int {
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = true
+	}
+	return len(seen)
+}
@@ -0,0 +1,80 @@
+package Feedback
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+)
+
+func TestTriageFeedbacks(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	status := Feedback.StatusInProgress
+	assignee := 5
+	actor := 2
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE \(board_id = \$1 AND id IN \(\$2,\$3\)\) AND "feedbacks"."deleted_at" IS NULL ORDER BY id`).
+		WithArgs(1, 10, 11).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "status", "assignee_id"}).
+			AddRow(10, 1, "new", nil).
+			AddRow(11, 1, "in_progress", 5))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "user_boards" WHERE board_id = \$1 AND user_id = \$2`).
+		WithArgs(1, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec(`UPDATE "feedbacks" SET "assignee_id"=\$1,"status"=\$2,"updated_at"=\$3 WHERE \(board_id = \$4 AND id IN \(\$5,\$6\)\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(5, "in_progress", sqlmock.AnyArg(), 1, 10, 11).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	// Only the first feedback changes, once for its status and once for its assignee
+	mock.ExpectQuery(`INSERT INTO "feedback_transitions" \("feedback_id","field","from","to","user_id","created_at"\)`).
+		WithArgs(10, "status", "new", "in_progress", 2, sqlmock.AnyArg(),
+			10, "assignee_id", nil, "5", 2, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectCommit()
+
+	feedbacks, err := TriageFeedbacks(1, []int{10, 11}, Feedback.TriageUpdate{Status: &status, AssigneeID: &assignee}, &actor)
+	assert.NoError(t, err)
+	assert.Len(t, feedbacks, 2)
+	assert.Equal(t, "in_progress", feedbacks[0].Status)
+	assert.Equal(t, 5, *feedbacks[0].AssigneeID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTriageFeedbacks_Errors(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	// A feedback of another board is not found
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE \(board_id = \$1 AND id IN \(\$2,\$3\)\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(1, 10, 12).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "status"}).AddRow(10, 1, "new"))
+	mock.ExpectRollback()
+
+	priority := Feedback.PriorityHigh
+	_, err = TriageFeedbacks(1, []int{10, 12}, Feedback.TriageUpdate{Priority: &priority}, nil)
+	assert.True(t, errors.Is(err, ErrFeedbackNotFound))
+
+	// The assignee must be a member of the board
+	assignee := 9
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE \(board_id = \$1 AND id IN \(\$2\)\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(1, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "status"}).AddRow(10, 1, "new"))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "user_boards"`).
+		WithArgs(1, 9).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectRollback()
+
+	_, err = TriageFeedbacks(1, []int{10}, Feedback.TriageUpdate{AssigneeID: &assignee}, nil)
+	assert.True(t, errors.Is(err, ErrAssigneeNotMember))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,6 +1,7 @@
 package Feedback
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -15,7 +16,7 @@ import (
 //   - int: count of successfully saved feedbacks
 //   - []string: list of error messages that occurred during processing
 //   - error: any critical error that prevented the overall operation
-func UploadFeedbacksFromFile(feedbacks []feedbackModel.Feedback, userEmail string) (int, []string, error) {
+func UploadFeedbacksFromFile(ctx context.Context, feedbacks []feedbackModel.Feedback, userEmail string) (int, []string, error) {
 	successCount := 0
 	errorMessages := make([]string, 0)
 
@@ -55,7 +56,7 @@ func UploadFeedbacksFromFile(feedbacks []feedbackModel.Feedback, userEmail strin
 		}
 
 		// Create the feedback
-		_, err := CreateFeedback(feedback, userEmail)
+		_, _, err := CreateFeedback(ctx, feedback, userEmail)
 		if err != nil {
 			errorMsg := fmt.Sprintf("Feedback #%d: %s", i+1, err.Error())
 			errorMessages = append(errorMessages, errorMsg)
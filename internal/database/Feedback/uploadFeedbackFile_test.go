@@ -1,12 +1,10 @@
 package Feedback
 
 import (
+	"context"
 	"testing"
 	"time"
 
-	"github.com/tot0p/env"
-	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/sentimentAnalysis"
-
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
@@ -17,9 +15,7 @@ import (
 
 func TestUploadFeedbacksFromFile(t *testing.T) {
 
-	//init the mixtral client
-	_ = env.LoadPath("../../../.env")
-	sentimentAnalysis.InitSentimentAnalysis(env.Get("MISTRAL_API_KEY"), env.Get("EMAIL_PASSWORD"))
+	stubSentimentAnalysis(t)
 
 	// Test successful upload of feedbacks
 	t.Run("Successfully upload and save multiple feedbacks", func(t *testing.T) {
@@ -78,6 +74,7 @@ func TestUploadFeedbacksFromFile(t *testing.T) {
 
 		// Then expect the insert for the first feedback
 		mock.ExpectBegin()
+		expectNoStoredDuplicate(mock)
 		mock.ExpectQuery(`INSERT INTO "feedbacks"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 		mock.ExpectQuery(`SELECT (.+) FROM "feedbacks" WHERE (.+)`).
@@ -85,7 +82,7 @@ func TestUploadFeedbacksFromFile(t *testing.T) {
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "date", "channel", "text", "board_id"}).
 				AddRow(1, time.Now(), time.Now(), testDate, "email", "The application is great!", 1))
 		mock.ExpectQuery(`INSERT INTO "analyses"`).
-			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 		mock.ExpectCommit()
 
@@ -98,6 +95,7 @@ func TestUploadFeedbacksFromFile(t *testing.T) {
 
 		// Then expect the insert for the second feedback
 		mock.ExpectBegin()
+		expectNoStoredDuplicate(mock)
 		mock.ExpectQuery(`INSERT INTO "feedbacks"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
 		mock.ExpectQuery(`SELECT (.+) FROM "feedbacks" WHERE (.+)`).
@@ -105,14 +103,14 @@ func TestUploadFeedbacksFromFile(t *testing.T) {
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "date", "channel", "text", "board_id"}).
 				AddRow(1, time.Now(), time.Now(), testDate, "email", "The application is great!", 1))
 		mock.ExpectQuery(`INSERT INTO "analyses"`).
-			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 		mock.ExpectCommit()
 
 		mock.ExpectCommit()
 
 		// Execute the function being tested
-		successCount, errs, err := UploadFeedbacksFromFile(testFeedbacks, "dummyemail@example.com")
+		successCount, errs, err := UploadFeedbacksFromFile(context.Background(), testFeedbacks, "dummyemail@example.com")
 
 		// Assert results
 		assert.Nil(t, err)
@@ -166,7 +164,7 @@ func TestUploadFeedbacksFromFile(t *testing.T) {
 		mock.ExpectRollback()
 
 		// Execute the function being tested
-		successCount, dbErrors, err := UploadFeedbacksFromFile(testFeedbacks, "dummyemail@example.com")
+		successCount, dbErrors, err := UploadFeedbacksFromFile(context.Background(), testFeedbacks, "dummyemail@example.com")
 
 		// Assert results
 		assert.Nil(t, err)
@@ -185,7 +183,7 @@ func TestUploadFeedbacksFromFile(t *testing.T) {
 		var testFeedbacks []Feedback.Feedback
 
 		// Execute the function being tested
-		successCount, dbErrors, err := UploadFeedbacksFromFile(testFeedbacks, "dummyemail@example.com")
+		successCount, dbErrors, err := UploadFeedbacksFromFile(context.Background(), testFeedbacks, "dummyemail@example.com")
 
 		// Assert results
 		assert.Nil(t, err)
@@ -230,7 +228,7 @@ func TestUploadFeedbacksFromFile(t *testing.T) {
 		mock.ExpectBegin().WillReturnError(gorm.ErrInvalidTransaction)
 
 		// Execute the function being tested
-		successCount, dbErrors, err := UploadFeedbacksFromFile(testFeedbacks, "test@example.com")
+		successCount, dbErrors, err := UploadFeedbacksFromFile(context.Background(), testFeedbacks, "test@example.com")
 
 		// Assert results
 		assert.NotNil(t, err)
@@ -279,7 +277,7 @@ func TestUploadFeedbacksFromFile(t *testing.T) {
 		mock.ExpectBegin()
 
 		// Mock board check with database error (not record not found)
-		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnError(gorm.ErrInvalidDB) // A database error, not record not found
 
@@ -287,7 +285,7 @@ func TestUploadFeedbacksFromFile(t *testing.T) {
 		mock.ExpectRollback()
 
 		// Execute the function being tested
-		successCount, dbErrors, err := UploadFeedbacksFromFile(testFeedbacks, "test@example.com")
+		successCount, dbErrors, err := UploadFeedbacksFromFile(context.Background(), testFeedbacks, "test@example.com")
 
 		// Assert results
 		assert.NotNil(t, err)
@@ -346,18 +344,19 @@ func TestUploadFeedbacksFromFile(t *testing.T) {
 		// Mock board check success (board exists)
 		boardRows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name"}).
 			AddRow(1, time.Now(), time.Now(), "Test Board")
-		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(boardRows)
 
 		// Mock first feedback creation - success
 		boardRowsCheck1 := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name"}).
 			AddRow(1, time.Now(), time.Now(), "Test Board")
-		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(boardRowsCheck1)
 
 		mock.ExpectBegin()
+		expectNoStoredDuplicate(mock)
 		mock.ExpectQuery(`INSERT INTO "feedbacks"`).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 		mock.ExpectQuery(`SELECT (.+) FROM "feedbacks" WHERE (.+)`).
@@ -365,19 +364,20 @@ func TestUploadFeedbacksFromFile(t *testing.T) {
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "date", "channel", "text", "board_id"}).
 				AddRow(1, time.Now(), time.Now(), testDate, "email", "Valid feedback", 1))
 		mock.ExpectQuery(`INSERT INTO "analyses"`).
-			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 		mock.ExpectCommit()
 
 		// Mock second feedback creation - failure at board check within CreateFeedback
 		boardRowsCheck2 := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name"}).
 			AddRow(1, time.Now(), time.Now(), "Test Board")
-		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 ORDER BY "boards"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(boardRowsCheck2)
 
 		// Make the feedback insertion fail
 		mock.ExpectBegin()
+		expectNoStoredDuplicate(mock)
 		mock.ExpectQuery(`INSERT INTO "feedbacks"`).
 			WillReturnError(gorm.ErrInvalidData) // Simulate feedback creation failure
 		mock.ExpectRollback()
@@ -386,7 +386,7 @@ func TestUploadFeedbacksFromFile(t *testing.T) {
 		mock.ExpectCommit()
 
 		// Execute the function being tested
-		successCount, dbErrors, err := UploadFeedbacksFromFile(testFeedbacks, "test@example.com")
+		successCount, dbErrors, err := UploadFeedbacksFromFile(context.Background(), testFeedbacks, "test@example.com")
 
 		// Assert results
 		assert.Nil(t, err)                                  // No critical error, just individual feedback errors
@@ -0,0 +1,238 @@
+package Feedback
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	customerDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Customer"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Analysis"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"gorm.io/gorm"
+)
+
+var ErrDuplicateFeedback = errors.New("duplicate feedback: already stored on this board")
+
+// Outcome tells what UpsertFeedbacks did with a feedback
+type Outcome int
+
+const (
+	// OutcomeCreated is a new feedback
+	OutcomeCreated Outcome = iota
+	// OutcomeUpdated is a known external ID whose text changed, its analyses were dropped
+	OutcomeUpdated
+	// OutcomeSkipped is a duplicate that was not saved
+	OutcomeSkipped
+)
+
+// ContentHash returns the hash identifying the content of a feedback within a board
+func ContentHash(feedback Feedback.Feedback) string {
+	h := sha256.New()
+	h.Write([]byte(feedback.Date.UTC().Format(time.RFC3339)))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.ToLower(strings.TrimSpace(feedback.Channel))))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.Join(strings.Fields(feedback.Text), " ")))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ClassifyFeedbacks tells, without writing anything, what UpsertFeedbacks would do with feedbacks of a single board
+func ClassifyFeedbacks(db *gorm.DB, feedbacks []Feedback.Feedback) ([]Outcome, error) {
+	outcomes, _, err := planUpsert(db, feedbacks)
+	return outcomes, err
+}
+
+// UpsertFeedbacks saves feedbacks of a single board without analyzing them:
+//   - a feedback whose content hash is already stored is skipped,
+//   - a feedback whose external ID is already stored is skipped when its text is unchanged,
+//     otherwise the stored feedback is updated and its analyses are dropped so it gets analyzed again,
+//   - any other feedback is inserted.
+//
+// The customers given on the saved feedbacks are upserted and linked to them.
+// IDs are set on the given slice and the outcome of each feedback is returned in the same order.
+// The cached copies of the replaced feedbacks are left to the caller, see ReplacedIDs.
+func UpsertFeedbacks(tx *gorm.DB, feedbacks []Feedback.Feedback) ([]Outcome, error) {
+	outcomes, storedIDs, err := planUpsert(tx, feedbacks)
+	if err != nil {
+		return nil, err
+	}
+	if err := linkCustomers(tx, feedbacks, outcomes); err != nil {
+		return nil, err
+	}
+
+	toCreate := make([]Feedback.Feedback, 0, len(feedbacks))
+	for i, outcome := range outcomes {
+		switch outcome {
+		case OutcomeUpdated:
+			if err := replaceFeedback(tx, storedIDs[i], feedbacks[i]); err != nil {
+				return nil, err
+			}
+			feedbacks[i].Id = storedIDs[i]
+		case OutcomeCreated:
+			toCreate = append(toCreate, feedbacks[i])
+		}
+	}
+
+	if err := CreateFeedbacks(tx, toCreate); err != nil {
+		return nil, err
+	}
+	j := 0
+	for i, outcome := range outcomes {
+		if outcome == OutcomeCreated {
+			feedbacks[i] = toCreate[j]
+			j++
+		}
+	}
+
+	return outcomes, nil
+}
+
+// planUpsert computes the content hash of each feedback and decides its outcome
+// against the stored feedbacks and the ones before it in the slice.
+// For updated feedbacks, the ID of the stored feedback is returned at the same index.
+func planUpsert(db *gorm.DB, feedbacks []Feedback.Feedback) ([]Outcome, []int, error) {
+	outcomes := make([]Outcome, len(feedbacks))
+	storedIDs := make([]int, len(feedbacks))
+	if len(feedbacks) == 0 {
+		return outcomes, storedIDs, nil
+	}
+
+	hashes := make([]string, len(feedbacks))
+	externalIDs := make([]string, 0)
+	for i := range feedbacks {
+		hashes[i] = ContentHash(feedbacks[i])
+		feedbacks[i].ContentHash = &hashes[i]
+		if feedbacks[i].ExternalID != nil {
+			externalIDs = append(externalIDs, *feedbacks[i].ExternalID)
+		}
+	}
+
+	// Load the stored feedbacks sharing a hash or an external ID with the batch, the ones in the trash are never replaced
+	var existing []Feedback.Feedback
+	query := db.Unscoped().Select("id", "text", "external_id", "content_hash", "deleted_at").Where("board_id = ?", feedbacks[0].BoardID)
+	if len(externalIDs) > 0 {
+		query = query.Where("content_hash IN ? OR external_id IN ?", hashes, externalIDs)
+	} else {
+		query = query.Where("content_hash IN ?", hashes)
+	}
+	if err := query.Find(&existing).Error; err != nil {
+		return nil, nil, err
+	}
+
+	storedHashes := make(map[string]int)
+	storedExternalIDs := make(map[string]Feedback.Feedback)
+	for _, feedback := range existing {
+		if feedback.ContentHash != nil {
+			storedHashes[*feedback.ContentHash] = feedback.Id
+		}
+		if feedback.ExternalID != nil {
+			key := *feedback.ExternalID
+			if feedback.DeletedAt.Valid {
+				// Handled as an unchanged feedback
+				feedback = Feedback.Feedback{}
+			}
+			storedExternalIDs[key] = feedback
+		}
+	}
+
+	for i, feedback := range feedbacks {
+		if feedback.ExternalID != nil {
+			if stored, ok := storedExternalIDs[*feedback.ExternalID]; ok {
+				if stored.Id == 0 || stored.Text == feedback.Text {
+					// Unchanged, or already handled earlier in this batch
+					outcomes[i] = OutcomeSkipped
+					continue
+				}
+				if id, ok := storedHashes[hashes[i]]; ok && id != stored.Id {
+					outcomes[i] = OutcomeSkipped
+					continue
+				}
+				outcomes[i] = OutcomeUpdated
+				storedIDs[i] = stored.Id
+				storedHashes[hashes[i]] = stored.Id
+				storedExternalIDs[*feedback.ExternalID] = Feedback.Feedback{}
+				continue
+			}
+		}
+
+		if _, ok := storedHashes[hashes[i]]; ok {
+			outcomes[i] = OutcomeSkipped
+			continue
+		}
+
+		// Reserve the hash and external ID so duplicates within the batch are skipped
+		storedHashes[hashes[i]] = 0
+		if feedback.ExternalID != nil {
+			storedExternalIDs[*feedback.ExternalID] = Feedback.Feedback{}
+		}
+		outcomes[i] = OutcomeCreated
+	}
+
+	return outcomes, storedIDs, nil
+}
+
+// linkCustomers resolves the customers of the feedbacks about to be saved and sets their CustomerID
+func linkCustomers(tx *gorm.DB, feedbacks []Feedback.Feedback, outcomes []Outcome) error {
+	sightings := make([]customerDB.Sighting, 0)
+	linked := make([]int, 0)
+	for i, feedback := range feedbacks {
+		if feedback.Customer == nil || outcomes[i] == OutcomeSkipped {
+			continue
+		}
+		sightings = append(sightings, customerDB.Sighting{Customer: *feedback.Customer, SeenAt: feedback.Date})
+		linked = append(linked, i)
+	}
+	if len(sightings) == 0 {
+		return nil
+	}
+
+	ids, err := customerDB.ResolveCustomers(tx, feedbacks[0].BoardID, sightings)
+	if err != nil {
+		return err
+	}
+	for j, i := range linked {
+		if ids[j] != nil {
+			feedbacks[i].CustomerID = ids[j]
+		}
+	}
+	return nil
+}
+
+// replaceFeedback overwrites the content of a stored feedback and drops its outdated analyses
+func replaceFeedback(tx *gorm.DB, id int, feedback Feedback.Feedback) error {
+	updates := map[string]interface{}{
+		"date":         feedback.Date,
+		"channel":      feedback.Channel,
+		"text":         feedback.Text,
+		"content_hash": feedback.ContentHash,
+	}
+	if feedback.CustomerID != nil {
+		updates["customer_id"] = *feedback.CustomerID
+	}
+	if len(feedback.Metadata) > 0 {
+		updates["metadata"] = feedback.Metadata
+	}
+	if feedback.Rating != nil {
+		updates["rating"] = *feedback.Rating
+		updates["rating_scale"] = feedback.RatingScale
+	}
+	err := tx.Model(&Feedback.Feedback{}).Where("id = ?", id).Updates(updates).Error
+	if err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("feedback_id = ?", id).Delete(&Analysis.Analysis{}).Error
+}
+
+// ReplacedIDs returns the IDs of the feedbacks replaced by UpsertFeedbacks,
+// the callers drop their cached copies with InvalidateFeedbackCache once the transaction is committed
+func ReplacedIDs(feedbacks []Feedback.Feedback, outcomes []Outcome) []int {
+	ids := make([]int, 0)
+	for i, outcome := range outcomes {
+		if outcome == OutcomeUpdated {
+			ids = append(ids, feedbacks[i].Id)
+		}
+	}
+	return ids
+}
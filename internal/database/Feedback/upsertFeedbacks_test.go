@@ -0,0 +1,194 @@
+package Feedback
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Customer"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+)
+
+// expectNoStoredDuplicate mocks the duplicate lookup made before inserting feedbacks
+func expectNoStoredDuplicate(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(`SELECT "id","text","external_id","content_hash","deleted_at" FROM "feedbacks" WHERE board_id = \$1`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "text", "external_id", "content_hash"}))
+}
+
+func TestContentHash(t *testing.T) {
+	date := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	base := Feedback.Feedback{Date: date, Channel: "Email", Text: "Great  app"}
+
+	// Case of the channel, surrounding and repeated spaces and time zone do not matter
+	same := Feedback.Feedback{Date: date.In(time.FixedZone("CEST", 2*3600)), Channel: " email", Text: "Great app "}
+	assert.Equal(t, ContentHash(base), ContentHash(same))
+
+	assert.NotEqual(t, ContentHash(base), ContentHash(Feedback.Feedback{Date: date, Channel: "email", Text: "Great app!"}))
+	assert.NotEqual(t, ContentHash(base), ContentHash(Feedback.Feedback{Date: date.Add(time.Hour), Channel: "email", Text: "Great app"}))
+	assert.Len(t, ContentHash(base), 64)
+}
+
+func TestUpsertFeedbacks(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	date := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	known, changed := "c-1", "c-2"
+	feedbacks := []Feedback.Feedback{
+		{Date: date, Channel: "email", Text: "Already stored", BoardID: 1},
+		{Date: date, Channel: "web", Text: "Same text", BoardID: 1, ExternalID: &known},
+		{Date: date, Channel: "web", Text: "Edited text", BoardID: 1, ExternalID: &changed},
+		{Date: date, Channel: "app", Text: "Brand new", BoardID: 1},
+		{Date: date, Channel: "app", Text: "Brand new", BoardID: 1},
+	}
+	storedHash := ContentHash(feedbacks[0])
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT "id","text","external_id","content_hash","deleted_at" FROM "feedbacks" WHERE board_id = \$1 AND \(content_hash IN (.+) OR external_id IN (.+)\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "text", "external_id", "content_hash"}).
+			AddRow(10, "Already stored", nil, storedHash).
+			AddRow(11, "Same text", known, "hash-11").
+			AddRow(12, "Old text", changed, "hash-12"))
+	mock.ExpectExec(`UPDATE "feedbacks" SET (.+) WHERE id = \$(\d+)`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM "analyses" WHERE feedback_id = \$1`).
+		WithArgs(12).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO "feedbacks"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(13))
+	mock.ExpectCommit()
+
+	tx := database.DB.Begin()
+	outcomes, err := UpsertFeedbacks(tx, feedbacks)
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Commit().Error)
+
+	assert.Equal(t, []Outcome{OutcomeSkipped, OutcomeSkipped, OutcomeUpdated, OutcomeCreated, OutcomeSkipped}, outcomes)
+	assert.Equal(t, []int{12}, ReplacedIDs(feedbacks, outcomes))
+	assert.Equal(t, 12, feedbacks[2].Id)
+	assert.Equal(t, 13, feedbacks[3].Id)
+	assert.NotNil(t, feedbacks[3].ContentHash)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClassifyFeedbacks_TrashedExternalID(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	date := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	trashed := "c-1"
+	feedbacks := []Feedback.Feedback{
+		{Date: date, Channel: "web", Text: "Edited text", BoardID: 1, ExternalID: &trashed},
+	}
+
+	mock.ExpectQuery(`SELECT "id","text","external_id","content_hash","deleted_at" FROM "feedbacks" WHERE board_id = \$1 AND \(content_hash IN (.+) OR external_id IN (.+)\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "text", "external_id", "content_hash", "deleted_at"}).
+			AddRow(11, "Old text", trashed, "hash-11", date))
+
+	// A feedback in the trash is never replaced
+	outcomes, err := ClassifyFeedbacks(database.DB, feedbacks)
+	assert.NoError(t, err)
+	assert.Equal(t, []Outcome{OutcomeSkipped}, outcomes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertFeedbacks_LinksCustomers(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	date := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	feedbacks := []Feedback.Feedback{
+		{Date: date, Channel: "email", Text: "From Ann", BoardID: 1, Customer: &Customer.CustomerJson{Name: "Ann", Email: "ann@example.com"}},
+		{Date: date, Channel: "email", Text: "Anonymous", BoardID: 1},
+	}
+
+	mock.ExpectBegin()
+	expectNoStoredDuplicate(mock)
+	mock.ExpectQuery(`SELECT \* FROM "customers" WHERE board_id = \$1 AND email IN \(\$2\)`).
+		WithArgs(1, "ann@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "email", "name"}).AddRow(8, 1, "ann@example.com", "Ann"))
+	mock.ExpectExec(`UPDATE "customers"`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO "feedbacks" (.+)"customer_id"`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), date, "email", "From Ann", 1, nil, sqlmock.AnyArg(), 8, nil, nil, nil, "new", nil, nil, nil,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), date, "email", "Anonymous", 1, nil, sqlmock.AnyArg(), nil, nil, nil, nil, "new", nil, nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(20).AddRow(21))
+	mock.ExpectCommit()
+
+	tx := database.DB.Begin()
+	_, err = UpsertFeedbacks(tx, feedbacks)
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Commit().Error)
+
+	assert.Equal(t, 8, *feedbacks[0].CustomerID)
+	assert.Nil(t, feedbacks[1].CustomerID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateFeedback_Duplicate(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	feedback := Feedback.Feedback{Date: time.Now(), Channel: "email", Text: "Twice", BoardID: 1}
+
+	mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Board"))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT "id","text","external_id","content_hash","deleted_at" FROM "feedbacks" WHERE board_id = \$1`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "text", "external_id", "content_hash"}).
+			AddRow(3, "Twice", nil, ContentHash(feedback)))
+	mock.ExpectRollback()
+
+	// No analysis is requested for a duplicate
+	_, _, err = CreateFeedback(context.Background(), feedback, "owner@example.com")
+	assert.ErrorIs(t, err, ErrDuplicateFeedback)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateFeedback_ReplacesExternalID(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+	stubSentimentAnalysis(t)
+
+	externalID := "c-1"
+	feedback := Feedback.Feedback{Date: time.Now(), Channel: "web", Text: "Edited text", BoardID: 1, ExternalID: &externalID}
+
+	mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1 AND "boards"."deleted_at" IS NULL ORDER BY "boards"."id" LIMIT \$2`).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Board"))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT "id","text","external_id","content_hash","deleted_at" FROM "feedbacks" WHERE board_id = \$1`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "text", "external_id", "content_hash"}).
+			AddRow(12, "Old text", externalID, "hash-12"))
+	mock.ExpectExec(`UPDATE "feedbacks" SET (.+) WHERE id = \$(\d+)`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM "analyses" WHERE feedback_id = \$1`).
+		WithArgs(12).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT (.+) FROM "feedbacks" WHERE (.+)`).
+		WithArgs(12, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "text", "board_id"}).AddRow(12, "Edited text", 1))
+	mock.ExpectQuery(`INSERT INTO "analyses"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	created, outcome, err := CreateFeedback(context.Background(), feedback, "owner@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, OutcomeUpdated, outcome)
+	assert.Equal(t, 12, created.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,30 @@
+package Feedback
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+)
+
+// MarkWebhookDelivery records a signed webhook delivery in Redis for the given ttl.
+// It returns false when the same signature has already been received for the board.
+// Without Redis the check is skipped and only the timestamp window protects against replays.
+func MarkWebhookDelivery(boardID int, signature string, ttl time.Duration) (bool, error) {
+	if database.RedisClient == nil {
+		return true, nil
+	}
+	ctx := database.GetRedisContext()
+	key := fmt.Sprintf("webhookDelivery:%d:%s", boardID, signature)
+	return database.RedisClient.SetNX(ctx, key, 1, ttl).Result()
+}
+
+// UnmarkWebhookDelivery forgets a delivery recorded by MarkWebhookDelivery so the sender can retry it
+func UnmarkWebhookDelivery(boardID int, signature string) error {
+	if database.RedisClient == nil {
+		return nil
+	}
+	ctx := database.GetRedisContext()
+	key := fmt.Sprintf("webhookDelivery:%d:%s", boardID, signature)
+	return database.RedisClient.Del(ctx, key).Err()
+}
@@ -0,0 +1,80 @@
+package ImportJob
+
+import (
+	"errors"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/ImportJob"
+	"gorm.io/gorm"
+)
+
+var ErrImportJobNotFound = errors.New("import job not found")
+
+// CreateImportJob creates a new import job
+func CreateImportJob(job ImportJob.ImportJob) (ImportJob.ImportJob, error) {
+	result := database.DB.Create(&job)
+	if result.Error != nil {
+		return ImportJob.ImportJob{}, result.Error
+	}
+	return job, nil
+}
+
+// SaveImportJob persists the progress of an import job
+func SaveImportJob(job ImportJob.ImportJob) error {
+	return database.DB.Save(&job).Error
+}
+
+// GetImportJobsByStatus returns the import jobs of every board in a status, oldest first
+func GetImportJobsByStatus(status string) ([]ImportJob.ImportJob, error) {
+	var jobs []ImportJob.ImportJob
+	result := database.DB.Where("status = ?", status).Order("id").Find(&jobs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return jobs, nil
+}
+
+// ClaimImportJob moves an import job from a status to another, it returns false when the job was no longer in the first one
+func ClaimImportJob(id int, from, to string) (bool, error) {
+	result := database.DB.Model(&ImportJob.ImportJob{}).Where("id = ? AND status = ?", id, from).Update("status", to)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// GetImportJob returns an import job of a board
+func GetImportJob(boardID, id int) (ImportJob.ImportJob, error) {
+	var job ImportJob.ImportJob
+	result := database.DB.Where("id = ? AND board_id = ?", id, boardID).First(&job)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return ImportJob.ImportJob{}, ErrImportJobNotFound
+		}
+		return ImportJob.ImportJob{}, result.Error
+	}
+	return job, nil
+}
+
+// GetImportJobByIdempotencyKey returns the import job of a board started with an idempotency key
+func GetImportJobByIdempotencyKey(boardID int, key string) (ImportJob.ImportJob, error) {
+	var job ImportJob.ImportJob
+	result := database.DB.Where("board_id = ? AND idempotency_key = ?", boardID, key).First(&job)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return ImportJob.ImportJob{}, ErrImportJobNotFound
+		}
+		return ImportJob.ImportJob{}, result.Error
+	}
+	return job, nil
+}
+
+// GetImportJobsByBoardID returns the latest import jobs of a board, newest first
+func GetImportJobsByBoardID(boardID, limit int) ([]ImportJob.ImportJob, error) {
+	var jobs []ImportJob.ImportJob
+	result := database.DB.Omit("errors").Where("board_id = ?", boardID).Order("id DESC").Limit(limit).Find(&jobs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return jobs, nil
+}
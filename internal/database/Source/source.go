@@ -0,0 +1,108 @@
+package Source
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Source"
+	"gorm.io/gorm"
+)
+
+var ErrSourceNotFound = errors.New("source not found")
+
+// GetSourcesByBoardID returns all sources of a board
+func GetSourcesByBoardID(boardID int) ([]Source.Source, error) {
+	var sources []Source.Source
+	result := database.DB.Where("board_id = ?", boardID).Order("id").Find(&sources)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return sources, nil
+}
+
+// GetSourceByID returns a source by its ID
+func GetSourceByID(id int) (Source.Source, error) {
+	var source Source.Source
+	result := database.DB.Where("id = ?", id).First(&source)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return Source.Source{}, ErrSourceNotFound
+		}
+		return Source.Source{}, result.Error
+	}
+	return source, nil
+}
+
+// GetScheduledSources returns every enabled source that has a schedule, except the ones of boards in the trash
+func GetScheduledSources() ([]Source.Source, error) {
+	var sources []Source.Source
+	result := database.DB.Where("enabled = ? AND schedule <> '' AND board_id IN (SELECT id FROM boards WHERE deleted_at IS NULL)", true).Find(&sources)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return sources, nil
+}
+
+// CreateSource creates a new source
+func CreateSource(source Source.Source) (Source.Source, error) {
+	result := database.DB.Create(&source)
+	if result.Error != nil {
+		return Source.Source{}, result.Error
+	}
+	return source, nil
+}
+
+// DeleteSource deletes a source and its sync history
+func DeleteSource(id int) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("source_id = ?", id).Delete(&Source.SyncRun{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id = ?", id).Delete(&Source.Source{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrSourceNotFound
+		}
+		return nil
+	})
+}
+
+// UpdateSourceCursor moves the high-water mark of a source after a sync
+func UpdateSourceCursor(id int, cursorDate *time.Time, cursorExternalID string, syncedAt time.Time) error {
+	return database.DB.Model(&Source.Source{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"cursor_date":        cursorDate,
+		"cursor_external_id": cursorExternalID,
+		"last_synced_at":     syncedAt,
+	}).Error
+}
+
+// CreateSyncRun records the start of a sync run
+func CreateSyncRun(run Source.SyncRun) (Source.SyncRun, error) {
+	result := database.DB.Create(&run)
+	if result.Error != nil {
+		return Source.SyncRun{}, result.Error
+	}
+	return run, nil
+}
+
+// SaveSyncRun persists the counters and status of a sync run
+func SaveSyncRun(run Source.SyncRun) error {
+	return database.DB.Save(&run).Error
+}
+
+// GetSyncRunsBySourceID returns the latest sync runs of a source, newest first
+func GetSyncRunsBySourceID(sourceID, limit int) ([]Source.SyncRun, error) {
+	var runs []Source.SyncRun
+	query := database.DB.Where("source_id = ?", sourceID).Order("started_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	result := query.Find(&runs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return runs, nil
+}
@@ -0,0 +1,125 @@
+package Source
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Source"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// setupTest creates a mock database connection for testing
+func setupTest(t *testing.T) sqlmock.Sqlmock {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:                 db,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	database.DB = gormDB
+	return mock
+}
+
+func TestGetSourcesByBoardID(t *testing.T) {
+	mock := setupTest(t)
+
+	rows := sqlmock.NewRows([]string{"id", "name", "type", "url", "schedule", "enabled", "board_id"}).
+		AddRow(1, "Comments", Source.TypeJSONPlaceholder, "http://example.com", "@hourly", true, 4)
+	mock.ExpectQuery(`SELECT (.+) FROM "sources" WHERE board_id = \$1 ORDER BY id`).
+		WithArgs(4).
+		WillReturnRows(rows)
+
+	sources, err := GetSourcesByBoardID(4)
+	assert.NoError(t, err)
+	assert.Len(t, sources, 1)
+	assert.Equal(t, "Comments", sources[0].Name)
+	assert.Equal(t, "@hourly", sources[0].Schedule)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSourceByID_NotFound(t *testing.T) {
+	mock := setupTest(t)
+
+	mock.ExpectQuery(`SELECT (.+) FROM "sources" WHERE id = \$1`).
+		WithArgs(7, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := GetSourceByID(7)
+	assert.True(t, errors.Is(err, ErrSourceNotFound))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetScheduledSources(t *testing.T) {
+	mock := setupTest(t)
+
+	mock.ExpectQuery(`SELECT (.+) FROM "sources" WHERE enabled = \$1 AND schedule <> ''`).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "schedule"}).AddRow(1, "*/5 * * * *").AddRow(2, "@daily"))
+
+	sources, err := GetScheduledSources()
+	assert.NoError(t, err)
+	assert.Len(t, sources, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateSourceCursor(t *testing.T) {
+	mock := setupTest(t)
+
+	cursor := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	syncedAt := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "sources" SET (.+) WHERE id = \$\d+`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := UpdateSourceCursor(1, &cursor, "42", syncedAt)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteSource_NotFound(t *testing.T) {
+	mock := setupTest(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "sync_runs" WHERE source_id = \$1`).
+		WithArgs(9).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM "sources" WHERE id = \$1`).
+		WithArgs(9).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := DeleteSource(9)
+	assert.True(t, errors.Is(err, ErrSourceNotFound))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSyncRunsBySourceID(t *testing.T) {
+	mock := setupTest(t)
+
+	rows := sqlmock.NewRows([]string{"id", "source_id", "trigger", "status", "started_at", "imported_count", "errors"}).
+		AddRow(2, 1, "schedule", Source.SyncStatusPartial, time.Now(), 3, `["Item #4: boom"]`).
+		AddRow(1, 1, "manual", Source.SyncStatusSuccess, time.Now(), 5, `[]`)
+	mock.ExpectQuery(`SELECT (.+) FROM "sync_runs" WHERE source_id = \$1 ORDER BY started_at DESC LIMIT \$2`).
+		WithArgs(1, 20).
+		WillReturnRows(rows)
+
+	runs, err := GetSyncRunsBySourceID(1, 20)
+	assert.NoError(t, err)
+	assert.Len(t, runs, 2)
+	assert.Equal(t, []string{"Item #4: boom"}, runs[0].Errors)
+	assert.Equal(t, 5, runs[1].ImportedCount)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
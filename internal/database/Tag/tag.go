@@ -0,0 +1,186 @@
+package Tag
+
+import (
+	"errors"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	tagModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Tag"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrTagNotFound = errors.New("tag not found")
+var ErrTagExists = errors.New("a tag with this name already exists on the board")
+
+// GetTagsByBoardID returns the tags of a board sorted by name
+func GetTagsByBoardID(boardID int) ([]tagModel.Tag, error) {
+	tags := make([]tagModel.Tag, 0)
+	result := database.DB.Where("board_id = ?", boardID).Order("name").Find(&tags)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return tags, nil
+}
+
+// CreateTag creates a tag, ErrTagExists is returned when its name is already used on the board
+func CreateTag(tag tagModel.Tag) (tagModel.Tag, error) {
+	if err := checkNameAvailable(tag.BoardID, tag.Name, 0); err != nil {
+		return tagModel.Tag{}, err
+	}
+	result := database.DB.Create(&tag)
+	if result.Error != nil {
+		return tagModel.Tag{}, result.Error
+	}
+	return tag, nil
+}
+
+// UpdateTag renames or recolors a tag of a board
+func UpdateTag(boardID, id int, body tagModel.TagJson) (tagModel.Tag, error) {
+	var tag tagModel.Tag
+	result := database.DB.Where("id = ? AND board_id = ?", id, boardID).First(&tag)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return tagModel.Tag{}, ErrTagNotFound
+		}
+		return tagModel.Tag{}, result.Error
+	}
+	if body.Name != "" && body.Name != tag.Name {
+		if err := checkNameAvailable(boardID, body.Name, id); err != nil {
+			return tagModel.Tag{}, err
+		}
+		tag.Name = body.Name
+	}
+	if body.Color != "" {
+		tag.Color = body.Color
+	}
+	if err := database.DB.Save(&tag).Error; err != nil {
+		return tagModel.Tag{}, err
+	}
+	invalidateTaggedFeedbacks([]int{id})
+	return tag, nil
+}
+
+// DeleteTag deletes a tag of a board and removes it from its feedbacks
+func DeleteTag(boardID, id int) error {
+	feedbackIDs := make([]int, 0)
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&tagModel.FeedbackTag{}).Where("tag_id = ?", id).Pluck("feedback_id", &feedbackIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("tag_id = ?", id).Delete(&tagModel.FeedbackTag{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id = ? AND board_id = ?", id, boardID).Delete(&tagModel.Tag{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrTagNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	invalidateFeedbacks(feedbackIDs)
+	return nil
+}
+
+// ApplyTags puts tags on feedbacks of a board, tags already on a feedback are left as is.
+// feedbackDB.ErrFeedbackNotFound or ErrTagNotFound is returned when an ID does not belong to the board.
+func ApplyTags(boardID int, feedbackIDs, tagIDs []int) error {
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := checkBoardIDs(tx, boardID, feedbackIDs, tagIDs); err != nil {
+			return err
+		}
+		links := make([]tagModel.FeedbackTag, 0, len(feedbackIDs)*len(tagIDs))
+		for _, feedbackID := range distinct(feedbackIDs) {
+			for _, tagID := range distinct(tagIDs) {
+				links = append(links, tagModel.FeedbackTag{FeedbackID: feedbackID, TagID: tagID})
+			}
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+	})
+	if err != nil {
+		return err
+	}
+	invalidateFeedbacks(feedbackIDs)
+	return nil
+}
+
+// RemoveTags takes tags off feedbacks of a board
+func RemoveTags(boardID int, feedbackIDs, tagIDs []int) error {
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := checkBoardIDs(tx, boardID, feedbackIDs, tagIDs); err != nil {
+			return err
+		}
+		return tx.Where("feedback_id IN ? AND tag_id IN ?", feedbackIDs, tagIDs).Delete(&tagModel.FeedbackTag{}).Error
+	})
+	if err != nil {
+		return err
+	}
+	invalidateFeedbacks(feedbackIDs)
+	return nil
+}
+
+// checkNameAvailable returns ErrTagExists when another tag of the board has the name
+func checkNameAvailable(boardID int, name string, exceptID int) error {
+	var count int64
+	err := database.DB.Model(&tagModel.Tag{}).Where("board_id = ? AND name = ? AND id <> ?", boardID, name, exceptID).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrTagExists
+	}
+	return nil
+}
+
+// checkBoardIDs ensures every feedback and tag belongs to the board
+func checkBoardIDs(tx *gorm.DB, boardID int, feedbackIDs, tagIDs []int) error {
+	var count int64
+	if err := tx.Model(&feedbackModel.Feedback{}).Where("board_id = ? AND id IN ?", boardID, feedbackIDs).Count(&count).Error; err != nil {
+		return err
+	}
+	if int(count) != len(distinct(feedbackIDs)) {
+		return feedbackDB.ErrFeedbackNotFound
+	}
+	if err := tx.Model(&tagModel.Tag{}).Where("board_id = ? AND id IN ?", boardID, tagIDs).Count(&count).Error; err != nil {
+		return err
+	}
+	if int(count) != len(distinct(tagIDs)) {
+		return ErrTagNotFound
+	}
+	return nil
+}
+
+// invalidateTaggedFeedbacks drops the cached feedbacks carrying one of the tags
+func invalidateTaggedFeedbacks(tagIDs []int) {
+	feedbackIDs := make([]int, 0)
+	if err := database.DB.Model(&tagModel.FeedbackTag{}).Where("tag_id IN ?", tagIDs).Pluck("feedback_id", &feedbackIDs).Error; err != nil {
+		return
+	}
+	invalidateFeedbacks(feedbackIDs)
+}
+
+// invalidateFeedbacks drops the cached copies of feedbacks whose tags changed
+func invalidateFeedbacks(feedbackIDs []int) {
+	for _, id := range feedbackIDs {
+		_ = feedbackDB.DeleteFeedbackFromCache(id)
+		_ = feedbackDB.DeleteFeedbackWithAnalysisFromCache(id)
+	}
+}
+
+func distinct(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
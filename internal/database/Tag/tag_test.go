@@ -0,0 +1,139 @@
+package Tag
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	tagModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Tag"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupTest() (sqlmock.Sqlmock, error) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if err != nil {
+		return nil, err
+	}
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:                 db,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	database.DB = gormDB
+	return mock, nil
+}
+
+func TestCreateTag(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "tags" WHERE board_id = \$1 AND name = \$2 AND id <> \$3`).
+		WithArgs(1, "bug", 0).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "tags" \("created_at","updated_at","board_id","name","color"\)`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1, "bug", "#ff0000").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+	mock.ExpectCommit()
+
+	tag, err := CreateTag(tagModel.Tag{BoardID: 1, Name: "bug", Color: "#ff0000"})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, tag.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateTag_Exists(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "tags" WHERE board_id = \$1 AND name = \$2 AND id <> \$3`).
+		WithArgs(1, "bug", 0).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	_, err = CreateTag(tagModel.Tag{BoardID: 1, Name: "bug", Color: "#ff0000"})
+	assert.ErrorIs(t, err, ErrTagExists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApplyTags(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "feedbacks" WHERE \(board_id = \$1 AND id IN \(\$2,\$3\)\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(1, 10, 11).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "tags" WHERE board_id = \$1 AND id IN \(\$2\)`).
+		WithArgs(1, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec(`INSERT INTO "feedback_tags" \("feedback_id","tag_id"\) VALUES \(\$1,\$2\),\(\$3,\$4\) ON CONFLICT DO NOTHING`).
+		WithArgs(10, 4, 11, 4).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	assert.NoError(t, ApplyTags(1, []int{10, 11}, []int{4}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApplyTags_NotOnBoard(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	// Feedback 12 belongs to another board
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "feedbacks" WHERE \(board_id = \$1 AND id IN \(\$2,\$3\)\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(1, 10, 12).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	assert.ErrorIs(t, ApplyTags(1, []int{10, 12}, []int{4}), feedbackDB.ErrFeedbackNotFound)
+
+	// Tag 5 belongs to another board
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "feedbacks" WHERE \(board_id = \$1 AND id IN \(\$2\)\) AND "feedbacks"."deleted_at" IS NULL`).
+		WithArgs(1, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "tags" WHERE board_id = \$1 AND id IN \(\$2,\$3\)`).
+		WithArgs(1, 4, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	assert.ErrorIs(t, RemoveTags(1, []int{10}, []int{4, 5}), ErrTagNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteTag(t *testing.T) {
+	mock, err := setupTest()
+	if err != nil {
+		t.Fatalf("Error setting up test: %v", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT "feedback_id" FROM "feedback_tags" WHERE tag_id = \$1`).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"feedback_id"}).AddRow(10))
+	mock.ExpectExec(`DELETE FROM "feedback_tags" WHERE tag_id = \$1`).
+		WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM "tags" WHERE id = \$1 AND board_id = \$2`).
+		WithArgs(4, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	assert.ErrorIs(t, DeleteTag(1, 4), ErrTagNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -32,14 +32,38 @@ func InitDatabase(user, password, dbname, host, port, sslMode string) error {
 	return nil
 }
 
-func InitRedis(url string) {
+// InitRedis connects the cache, see config for the validation of the URL
+func InitRedis(url string) error {
 	opts, err := redis.ParseURL(url)
 	if err != nil {
-		panic(err)
+		return errors.New("invalid redis URL, " + err.Error())
 	}
 	opts.ReadTimeout = 5 * 60 * 1000000000 // 5 minutes
 
 	RedisClient = redis.NewClient(opts)
+	return nil
+}
+
+// Close closes the cache and the database connections, when they are open
+func Close() error {
+	var errs []error
+	if RedisClient != nil {
+		if err := RedisClient.Close(); err != nil {
+			errs = append(errs, errors.New("failed to close redis, "+err.Error()))
+		}
+		RedisClient = nil
+	}
+	if DB != nil {
+		sqlDB, err := DB.DB()
+		if err == nil {
+			err = sqlDB.Close()
+		}
+		if err != nil {
+			errs = append(errs, errors.New("failed to close database, "+err.Error()))
+		}
+		DB = nil
+	}
+	return errors.Join(errs...)
 }
 
 func GetRedisContext() context.Context {
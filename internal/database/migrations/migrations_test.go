@@ -0,0 +1,273 @@
+package migrations
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:                 db,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open gorm DB: %v", err)
+	}
+	return gormDB, mock
+}
+
+func expectLock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(`SELECT pg_advisory_lock\(\$1\)`).
+		WithArgs(lockKey).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).
+		WithArgs(lockKey).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+	assert.Equal(t, 1, migrations[0].Version)
+	assert.Equal(t, "baseline", migrations[0].Name)
+	assert.Contains(t, migrations[0].Up, `CREATE TABLE IF NOT EXISTS "feedbacks"`)
+	assert.Contains(t, migrations[0].Down, `DROP TABLE IF EXISTS "feedbacks"`)
+	for i := 1; i < len(migrations); i++ {
+		assert.Less(t, migrations[i-1].Version, migrations[i].Version)
+	}
+}
+
+// autoMigrateSchema is the schema created by AutoMigrate before versioned migrations, the baseline must upgrade it
+var autoMigrateSchema = map[string][]string{
+	"users":       {"id", "created_at", "updated_at", "uuid", "username", "email", "password"},
+	"boards":      {"id", "created_at", "updated_at", "name"},
+	"user_boards": {"board_id", "user_id"},
+	"feedbacks":   {"id", "created_at", "updated_at", "date", "channel", "text", "board_id"},
+	"analyses":    {"id", "created_at", "updated_at", "sentiment_score", "topic", "feedback_id"},
+}
+
+var (
+	createTableRegexp = regexp.MustCompile(`(?s)^CREATE TABLE IF NOT EXISTS "(\w+)" \((.*)\)$`)
+	columnRegexp      = regexp.MustCompile(`(?m)^\s+"(\w+)" `)
+	addColumnRegexp   = regexp.MustCompile(`^ALTER TABLE "(\w+)" ADD COLUMN IF NOT EXISTS "(\w+)" `)
+	createIndexRegexp = regexp.MustCompile(`^CREATE (?:UNIQUE )?INDEX IF NOT EXISTS "\w+" ON "(\w+)" \((.+)\)$`)
+)
+
+// applyBaseline replays the guarded statements of the baseline on the tables and columns of a schema
+func applyBaseline(t *testing.T, up string, schema map[string][]string) map[string]map[string]bool {
+	tables := make(map[string]map[string]bool)
+	for table, columns := range schema {
+		tables[table] = make(map[string]bool)
+		for _, column := range columns {
+			tables[table][column] = true
+		}
+	}
+
+	lines := make([]string, 0)
+	for _, line := range strings.Split(up, "\n") {
+		if !strings.HasPrefix(line, "--") {
+			lines = append(lines, line)
+		}
+	}
+	for _, statement := range strings.Split(strings.Join(lines, "\n"), ";") {
+		statement = strings.TrimSpace(statement)
+		if match := createTableRegexp.FindStringSubmatch(statement); match != nil {
+			if _, ok := tables[match[1]]; ok {
+				continue
+			}
+			tables[match[1]] = make(map[string]bool)
+			for _, column := range columnRegexp.FindAllStringSubmatch(match[2], -1) {
+				tables[match[1]][column[1]] = true
+			}
+		} else if match := addColumnRegexp.FindStringSubmatch(statement); match != nil {
+			tables[match[1]][match[2]] = true
+		} else if match := createIndexRegexp.FindStringSubmatch(statement); match != nil {
+			for _, column := range strings.Split(match[2], ",") {
+				column = strings.Trim(strings.TrimSpace(column), `"`)
+				assert.True(t, tables[match[1]][column], "index on missing column %s.%s", match[1], column)
+			}
+		}
+	}
+	return tables
+}
+
+func TestBaseline_UpgradesAutoMigrateSchema(t *testing.T) {
+	migrations, err := Load()
+	assert.NoError(t, err)
+	up := migrations[0].Up
+
+	// A database created by the current baseline and one upgraded from AutoMigrate end up with the same columns
+	fresh := applyBaseline(t, up, nil)
+	upgraded := applyBaseline(t, up, autoMigrateSchema)
+	assert.Equal(t, fresh, upgraded)
+	assert.True(t, upgraded["feedbacks"]["deleted_at"])
+	assert.True(t, upgraded["boards"]["webhook_token"])
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	_, err := load(fstest.MapFS{
+		"sql/0002_second.up.sql": {Data: []byte("SELECT 1;")},
+	}, "sql")
+	assert.ErrorContains(t, err, "needs both an up and a down file")
+
+	_, err = load(fstest.MapFS{
+		"sql/0002_second.up.sql":  {Data: []byte("SELECT 1;")},
+		"sql/0002_other.down.sql": {Data: []byte("SELECT 1;")},
+	}, "sql")
+	assert.ErrorContains(t, err, "is named both")
+
+	_, err = load(fstest.MapFS{
+		"sql/second.sql": {Data: []byte("SELECT 1;")},
+	}, "sql")
+	assert.ErrorContains(t, err, "invalid migration file name")
+}
+
+func TestUp(t *testing.T) {
+	db, mock := setupMockDB(t)
+
+	expectLock(mock)
+	mock.ExpectQuery(`SELECT "version" FROM "schema_migrations"`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	mock.ExpectBegin()
+	mock.ExpectExec(`CREATE EXTENSION IF NOT EXISTS "uuid-ossp"`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO "schema_migrations" \("version","name","applied_at"\) VALUES \(\$1,\$2,\$3\)`).
+		WithArgs(1, "baseline", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(`ALTER TABLE "feedback_comments" ALTER COLUMN "author_id" DROP NOT NULL`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO "schema_migrations" \("version","name","applied_at"\) VALUES \(\$1,\$2,\$3\)`).
+		WithArgs(2, "nullable_comment_author", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	applied, err := Up(db)
+	assert.NoError(t, err)
+	assert.Len(t, applied, 2)
+	assert.Equal(t, "baseline", applied[0].Name)
+	assert.Equal(t, "nullable_comment_author", applied[1].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUp_AlreadyApplied(t *testing.T) {
+	db, mock := setupMockDB(t)
+
+	expectLock(mock)
+	mock.ExpectQuery(`SELECT "version" FROM "schema_migrations"`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1).AddRow(2))
+	expectUnlock(mock)
+
+	applied, err := Up(db)
+	assert.NoError(t, err)
+	assert.Empty(t, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUp_Failure(t *testing.T) {
+	db, mock := setupMockDB(t)
+
+	expectLock(mock)
+	mock.ExpectQuery(`SELECT "version" FROM "schema_migrations"`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	mock.ExpectBegin()
+	mock.ExpectExec(`CREATE EXTENSION`).
+		WillReturnError(errors.New("permission denied"))
+	mock.ExpectRollback()
+	expectUnlock(mock)
+
+	applied, err := Up(db)
+	assert.ErrorContains(t, err, "migration 1_baseline failed: permission denied")
+	assert.Empty(t, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDown(t *testing.T) {
+	db, mock := setupMockDB(t)
+
+	expectLock(mock)
+	mock.ExpectQuery(`SELECT \* FROM "schema_migrations" ORDER BY version DESC LIMIT \$1`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "name", "applied_at"}).AddRow(1, "baseline", time.Now()))
+	mock.ExpectBegin()
+	mock.ExpectExec(`DROP TABLE IF EXISTS "import_jobs"`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM "schema_migrations" WHERE version = \$1`).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUnlock(mock)
+
+	reverted, err := Down(db, 1)
+	assert.NoError(t, err)
+	assert.Len(t, reverted, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Nothing left to revert
+	expectLock(mock)
+	mock.ExpectQuery(`SELECT \* FROM "schema_migrations" ORDER BY version DESC LIMIT \$1`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "name", "applied_at"}))
+	expectUnlock(mock)
+
+	_, err = Down(db, 1)
+	assert.ErrorIs(t, err, ErrNoMigrationToRevert)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetStatus(t *testing.T) {
+	db, mock := setupMockDB(t)
+
+	appliedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT \* FROM "schema_migrations"`).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "name", "applied_at"}).AddRow(1, "baseline", appliedAt))
+
+	statuses, err := GetStatus(db)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, statuses)
+	assert.Equal(t, "baseline", statuses[0].Name)
+	assert.Equal(t, appliedAt, *statuses[0].AppliedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPending(t *testing.T) {
+	db, mock := setupMockDB(t)
+
+	mock.ExpectQuery(`SELECT "version" FROM "schema_migrations"`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	pending, err := Pending(db)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, pending[0].Version)
+
+	mock.ExpectQuery(`SELECT "version" FROM "schema_migrations"`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1).AddRow(2))
+	pending, err = Pending(db)
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
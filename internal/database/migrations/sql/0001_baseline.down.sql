@@ -0,0 +1,17 @@
+-- Drops the whole schema, the uuid-ossp extension is left in place
+
+DROP TABLE IF EXISTS "import_jobs";
+DROP TABLE IF EXISTS "api_keys";
+DROP TABLE IF EXISTS "sync_runs";
+DROP TABLE IF EXISTS "sources";
+DROP TABLE IF EXISTS "analyses";
+DROP TABLE IF EXISTS "feedback_comments";
+DROP TABLE IF EXISTS "feedback_transitions";
+DROP TABLE IF EXISTS "feedback_tags";
+DROP TABLE IF EXISTS "feedbacks";
+DROP TABLE IF EXISTS "tags";
+DROP TABLE IF EXISTS "customers";
+DROP TABLE IF EXISTS "metadata_fields";
+DROP TABLE IF EXISTS "user_boards";
+DROP TABLE IF EXISTS "boards";
+DROP TABLE IF EXISTS "users";
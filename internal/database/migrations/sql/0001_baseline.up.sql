@@ -0,0 +1,244 @@
+-- Baseline: the schema as created by GORM AutoMigrate before versioned migrations.
+-- Every statement is guarded so that a database created by AutoMigrate is adopted as is.
+-- The columns added to users, boards, feedbacks and analyses since their first AutoMigrate schema
+-- are added to the tables that lack them, before the indexes using them.
+
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "uuid" uuid NOT NULL DEFAULT uuid_generate_v4(),
+    "username" text NOT NULL,
+    "email" text NOT NULL,
+    "password" text NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_users_uuid" UNIQUE ("uuid"),
+    CONSTRAINT "uni_users_username" UNIQUE ("username"),
+    CONSTRAINT "uni_users_email" UNIQUE ("email")
+);
+
+CREATE TABLE IF NOT EXISTS "boards" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "name" text NOT NULL,
+    "deleted_at" timestamptz,
+    "webhook_token" text,
+    "webhook_secret" text,
+    PRIMARY KEY ("id")
+);
+ALTER TABLE "boards" ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz;
+ALTER TABLE "boards" ADD COLUMN IF NOT EXISTS "webhook_token" text;
+ALTER TABLE "boards" ADD COLUMN IF NOT EXISTS "webhook_secret" text;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_boards_webhook_token" ON "boards" ("webhook_token");
+CREATE INDEX IF NOT EXISTS "idx_boards_deleted_at" ON "boards" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "user_boards" (
+    "board_id" bigint,
+    "user_id" bigint,
+    PRIMARY KEY ("board_id", "user_id"),
+    CONSTRAINT "fk_user_boards_board" FOREIGN KEY ("board_id") REFERENCES "boards" ("id"),
+    CONSTRAINT "fk_user_boards_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "metadata_fields" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "board_id" bigint NOT NULL,
+    "key" text NOT NULL,
+    "type" text NOT NULL,
+    "allowed_values" text,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_metadata_fields_board_key" ON "metadata_fields" ("board_id", "key");
+
+CREATE TABLE IF NOT EXISTS "customers" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "board_id" bigint NOT NULL,
+    "external_id" text,
+    "email" text,
+    "name" text,
+    "attributes" text,
+    "first_seen_at" timestamptz,
+    "last_seen_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_customers_board_email" ON "customers" ("board_id", "email");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_customers_board_external_id" ON "customers" ("board_id", "external_id");
+
+CREATE TABLE IF NOT EXISTS "tags" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "board_id" bigint NOT NULL,
+    "name" text NOT NULL,
+    "color" text NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_tags_board_name" ON "tags" ("board_id", "name");
+
+CREATE TABLE IF NOT EXISTS "feedbacks" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "date" timestamptz NOT NULL,
+    "channel" text NOT NULL,
+    "text" text NOT NULL,
+    "board_id" bigint NOT NULL,
+    "external_id" text,
+    "content_hash" text,
+    "customer_id" bigint,
+    "metadata" jsonb,
+    "rating" decimal,
+    "rating_scale" bigint,
+    "status" text NOT NULL DEFAULT 'new',
+    "assignee_id" bigint,
+    "priority" text,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_boards_feedbacks" FOREIGN KEY ("board_id") REFERENCES "boards" ("id")
+);
+ALTER TABLE "feedbacks" ADD COLUMN IF NOT EXISTS "external_id" text;
+ALTER TABLE "feedbacks" ADD COLUMN IF NOT EXISTS "content_hash" text;
+ALTER TABLE "feedbacks" ADD COLUMN IF NOT EXISTS "customer_id" bigint;
+ALTER TABLE "feedbacks" ADD COLUMN IF NOT EXISTS "metadata" jsonb;
+ALTER TABLE "feedbacks" ADD COLUMN IF NOT EXISTS "rating" decimal;
+ALTER TABLE "feedbacks" ADD COLUMN IF NOT EXISTS "rating_scale" bigint;
+ALTER TABLE "feedbacks" ADD COLUMN IF NOT EXISTS "status" text NOT NULL DEFAULT 'new';
+ALTER TABLE "feedbacks" ADD COLUMN IF NOT EXISTS "assignee_id" bigint;
+ALTER TABLE "feedbacks" ADD COLUMN IF NOT EXISTS "priority" text;
+ALTER TABLE "feedbacks" ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_feedbacks_deleted_at" ON "feedbacks" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_feedbacks_assignee_id" ON "feedbacks" ("assignee_id");
+CREATE INDEX IF NOT EXISTS "idx_feedbacks_status" ON "feedbacks" ("status");
+CREATE INDEX IF NOT EXISTS "idx_feedbacks_customer_id" ON "feedbacks" ("customer_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_feedbacks_board_content_hash" ON "feedbacks" ("board_id", "content_hash");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_feedbacks_board_external_id" ON "feedbacks" ("board_id", "external_id");
+
+CREATE TABLE IF NOT EXISTS "feedback_tags" (
+    "feedback_id" bigint,
+    "tag_id" bigint,
+    PRIMARY KEY ("feedback_id", "tag_id"),
+    CONSTRAINT "fk_feedback_tags_feedback" FOREIGN KEY ("feedback_id") REFERENCES "feedbacks" ("id"),
+    CONSTRAINT "fk_feedback_tags_tag" FOREIGN KEY ("tag_id") REFERENCES "tags" ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "feedback_transitions" (
+    "id" bigserial,
+    "feedback_id" bigint NOT NULL,
+    "field" text NOT NULL,
+    "from" text,
+    "to" text,
+    "user_id" bigint,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_feedback_transitions_feedback_id" ON "feedback_transitions" ("feedback_id");
+
+CREATE TABLE IF NOT EXISTS "feedback_comments" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "feedback_id" bigint NOT NULL,
+    "parent_id" bigint,
+    "author_id" bigint NOT NULL,
+    "body" text NOT NULL,
+    "mentions" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_feedback_comments_feedback_id" ON "feedback_comments" ("feedback_id");
+CREATE INDEX IF NOT EXISTS "idx_feedback_comments_parent_id" ON "feedback_comments" ("parent_id");
+
+CREATE TABLE IF NOT EXISTS "analyses" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "sentiment_score" decimal NOT NULL,
+    "topic" text NOT NULL,
+    "feedback_id" bigint NOT NULL,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_analyses_feedback" FOREIGN KEY ("feedback_id") REFERENCES "feedbacks" ("id"),
+    CONSTRAINT "uni_analyses_feedback_id" UNIQUE ("feedback_id")
+);
+ALTER TABLE "analyses" ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_analyses_deleted_at" ON "analyses" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "sources" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "name" text NOT NULL,
+    "type" text NOT NULL,
+    "url" text NOT NULL,
+    "schedule" text,
+    "enabled" boolean NOT NULL DEFAULT true,
+    "cursor_date" timestamptz,
+    "cursor_external_id" text,
+    "last_synced_at" timestamptz,
+    "board_id" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_sources_board_id" ON "sources" ("board_id");
+
+CREATE TABLE IF NOT EXISTS "sync_runs" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "source_id" bigint NOT NULL,
+    "trigger" text NOT NULL,
+    "status" text NOT NULL,
+    "started_at" timestamptz NOT NULL,
+    "finished_at" timestamptz,
+    "fetched_count" bigint,
+    "imported_count" bigint,
+    "skipped_count" bigint,
+    "error_count" bigint,
+    "errors" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_sync_runs_source_id" ON "sync_runs" ("source_id");
+
+CREATE TABLE IF NOT EXISTS "api_keys" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "name" text NOT NULL,
+    "prefix" text NOT NULL,
+    "hash" text NOT NULL,
+    "scopes" text NOT NULL,
+    "expires_at" timestamptz,
+    "last_used_at" timestamptz,
+    "revoked_at" timestamptz,
+    "board_id" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_api_keys_prefix" ON "api_keys" ("prefix");
+CREATE INDEX IF NOT EXISTS "idx_api_keys_board_id" ON "api_keys" ("board_id");
+
+CREATE TABLE IF NOT EXISTS "import_jobs" (
+    "id" bigserial,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "board_id" bigint NOT NULL,
+    "filename" text,
+    "format" text NOT NULL,
+    "status" text NOT NULL,
+    "total_rows" bigint,
+    "imported_count" bigint,
+    "skipped_count" bigint,
+    "analyzed_count" bigint,
+    "error_count" bigint,
+    "errors" text,
+    "started_at" timestamptz NOT NULL,
+    "finished_at" timestamptz,
+    "idempotency_key" text,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_import_jobs_board_idempotency_key" ON "import_jobs" ("board_id", "idempotency_key");
+CREATE INDEX IF NOT EXISTS "idx_import_jobs_board_id" ON "import_jobs" ("board_id");
@@ -0,0 +1,4 @@
+-- The comments left by deleted users have no author to restore and are dropped
+
+DELETE FROM "feedback_comments" WHERE "author_id" IS NULL;
+ALTER TABLE "feedback_comments" ALTER COLUMN "author_id" SET NOT NULL;
@@ -0,0 +1,3 @@
+-- The comments of a deleted user are kept with no author
+
+ALTER TABLE "feedback_comments" ALTER COLUMN "author_id" DROP NOT NULL;
@@ -4,7 +4,9 @@ import (
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
 	BoardModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Board"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/User"
+	"gorm.io/gorm"
 )
 
 // CreateUser inserts a new user into the database
@@ -89,3 +91,23 @@ func DeleteUser(id uint) error {
 	}
 	return nil
 }
+
+// DeleteUserWithMemberships deletes a user along with its board memberships and unassigns its feedbacks, trash included.
+// The boards, comments and triage history of the user are kept without their author.
+func DeleteUserWithMemberships(id int) error {
+	return database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&Feedback.Feedback{}).Where("assignee_id = ?", id).Update("assignee_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&Feedback.Comment{}).Where("author_id = ?", id).Update("author_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&Feedback.Transition{}).Where("user_id = ?", id).Update("user_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM user_boards WHERE user_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&User.User{}, id).Error
+	})
+}
@@ -344,3 +344,78 @@ func TestDeleteUser_NotFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "record not found")
 }
+
+func TestDeleteUserWithMemberships(t *testing.T) {
+	mock, cleanup := setupTestDB()
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "feedbacks" SET "assignee_id"=\$1,"updated_at"=\$2 WHERE assignee_id = \$3`).
+		WithArgs(nil, sqlmock.AnyArg(), 4).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`UPDATE "feedback_comments" SET "author_id"=\$1,"updated_at"=\$2 WHERE author_id = \$3`).
+		WithArgs(nil, sqlmock.AnyArg(), 4).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE "feedback_transitions" SET "user_id"=\$1 WHERE user_id = \$2`).
+		WithArgs(nil, 4).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM user_boards WHERE user_id = \$1`).
+		WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM "users" WHERE "users"."id" = \$1`).
+		WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := DeleteUserWithMemberships(4)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteUserWithMemberships_CommentsAndTransitions(t *testing.T) {
+	expectAuthorCleared := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "feedbacks" SET "assignee_id"=\$1,"updated_at"=\$2 WHERE assignee_id = \$3`).
+			WithArgs(nil, sqlmock.AnyArg(), 4).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`UPDATE "feedback_comments" SET "author_id"=\$1,"updated_at"=\$2 WHERE author_id = \$3`).
+			WithArgs(nil, sqlmock.AnyArg(), 4).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+	}
+
+	t.Run("comments and transitions are kept without the user", func(t *testing.T) {
+		mock, cleanup := setupTestDB()
+		defer cleanup()
+
+		expectAuthorCleared(mock)
+		mock.ExpectExec(`UPDATE "feedback_transitions" SET "user_id"=\$1 WHERE user_id = \$2`).
+			WithArgs(nil, 4).
+			WillReturnResult(sqlmock.NewResult(0, 5))
+		mock.ExpectExec(`DELETE FROM user_boards WHERE user_id = \$1`).
+			WithArgs(4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM "users" WHERE "users"."id" = \$1`).
+			WithArgs(4).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := DeleteUserWithMemberships(4)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("the user is kept when the history cannot be cleared", func(t *testing.T) {
+		mock, cleanup := setupTestDB()
+		defer cleanup()
+
+		expectAuthorCleared(mock)
+		mock.ExpectExec(`UPDATE "feedback_transitions" SET "user_id"=\$1 WHERE user_id = \$2`).
+			WithArgs(nil, 4).
+			WillReturnError(errors.New("database error"))
+		mock.ExpectRollback()
+
+		err := DeleteUserWithMemberships(4)
+		assert.EqualError(t, err, "database error")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,179 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/migrations"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/sentimentAnalysis"
+)
+
+const (
+	// StatusOK is a dependency, or an instance, working as expected
+	StatusOK = "ok"
+	// StatusDegraded is an instance whose optional dependencies fail, it keeps serving requests
+	StatusDegraded = "degraded"
+	// StatusUnavailable is a failing dependency, or an instance with a failing required dependency
+	StatusUnavailable = "unavailable"
+	// StatusDisabled is an optional dependency which is not configured
+	StatusDisabled = "disabled"
+)
+
+// checkTimeout bounds every check, a probe answers within it even when a dependency hangs
+const checkTimeout = 2 * time.Second
+
+// analyzerCheckInterval is how long the result of the analyzer check is reused, not to call the Mistral API on every probe
+const analyzerCheckInterval = time.Minute
+
+// ErrDisabled is returned by the checks of optional dependencies which are not configured
+var ErrDisabled = errors.New("not configured")
+
+// Check verifies a dependency, a failing required dependency makes the instance unavailable
+type Check struct {
+	Name     string
+	Required bool
+	Run      func(ctx context.Context) error
+}
+
+// Result is the outcome of a check
+type Result struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the readiness of the instance along with the result of every check
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Run runs the checks concurrently and aggregates their results
+func Run(ctx context.Context, checks []Check) Report {
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(checks))}
+	for i, check := range checks {
+		result := results[i]
+		report.Checks[check.Name] = result
+		if result.Status != StatusUnavailable {
+			continue
+		}
+		if check.Required {
+			report.Status = StatusUnavailable
+		} else if report.Status == StatusOK {
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+// run runs a check within checkTimeout, a check ignoring the context is abandoned when it expires
+func run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check.Run(ctx)
+	}()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out: %w", ctx.Err())
+	}
+	result := Result{LatencyMs: float64(time.Since(start).Microseconds()) / 1000}
+
+	switch {
+	case errors.Is(err, ErrDisabled):
+		result.Status = StatusDisabled
+	case err != nil:
+		result.Status = StatusUnavailable
+		result.Error = err.Error()
+	default:
+		result.Status = StatusOK
+	}
+	return result
+}
+
+// DefaultChecks are the dependencies of the API: Postgres and its migrations are required, the cache and the analyzer are optional
+func DefaultChecks() []Check {
+	return []Check{
+		{Name: "database", Required: true, Run: checkDatabase},
+		{Name: "migrations", Required: true, Run: checkMigrations},
+		{Name: "redis", Run: checkRedis},
+		{Name: "analyzer", Run: analyzer.check},
+	}
+}
+
+func checkDatabase(ctx context.Context) error {
+	if database.DB == nil {
+		return errors.New("database is not initialized")
+	}
+	sqlDB, err := database.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func checkMigrations(ctx context.Context) error {
+	if database.DB == nil {
+		return errors.New("database is not initialized")
+	}
+	pending, err := migrations.Pending(database.DB.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%d pending migrations, next is %d_%s", len(pending), pending[0].Version, pending[0].Name)
+	}
+	return nil
+}
+
+func checkRedis(ctx context.Context) error {
+	if database.RedisClient == nil {
+		return ErrDisabled
+	}
+	return database.RedisClient.Ping(ctx).Err()
+}
+
+// analyzerCheck caches the outcome of the analyzer check for analyzerCheckInterval
+type analyzerCheck struct {
+	mu        sync.Mutex
+	checkedAt time.Time
+	err       error
+}
+
+var analyzer = &analyzerCheck{}
+
+func (a *analyzerCheck) check(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.checkedAt.IsZero() && time.Since(a.checkedAt) < analyzerCheckInterval {
+		return a.err
+	}
+
+	err := sentimentAnalysis.Ping()
+	if errors.Is(err, sentimentAnalysis.ErrNotInitialized) {
+		err = ErrDisabled
+	}
+	a.checkedAt = time.Now()
+	a.err = err
+	return err
+}
@@ -0,0 +1,88 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func check(name string, required bool, err error) Check {
+	return Check{Name: name, Required: required, Run: func(ctx context.Context) error { return err }}
+}
+
+func TestRun(t *testing.T) {
+	report := Run(context.Background(), []Check{
+		check("database", true, nil),
+		check("redis", false, ErrDisabled),
+	})
+	assert.Equal(t, StatusOK, report.Status)
+	assert.Equal(t, StatusOK, report.Checks["database"].Status)
+	assert.Equal(t, StatusDisabled, report.Checks["redis"].Status)
+
+	// Only optional dependencies fail
+	report = Run(context.Background(), []Check{
+		check("database", true, nil),
+		check("redis", false, errors.New("connection refused")),
+	})
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.Equal(t, StatusUnavailable, report.Checks["redis"].Status)
+	assert.Equal(t, "connection refused", report.Checks["redis"].Error)
+
+	report = Run(context.Background(), []Check{
+		check("database", true, errors.New("connection refused")),
+		check("redis", false, errors.New("connection refused")),
+	})
+	assert.Equal(t, StatusUnavailable, report.Status)
+}
+
+func TestRun_Timeout(t *testing.T) {
+	hanging := Check{Name: "analyzer", Run: func(ctx context.Context) error {
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	}}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	report := Run(ctx, []Check{hanging})
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.Contains(t, report.Checks["analyzer"].Error, "timed out")
+}
+
+func TestDefaultChecks(t *testing.T) {
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp), sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	defer mockDB.Close()
+	// gorm pings the database when opening it
+	mock.ExpectPing()
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: mockDB, PreferSimpleProtocol: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open gorm DB: %v", err)
+	}
+	originalDB, originalRedis := database.DB, database.RedisClient
+	database.DB, database.RedisClient = db, nil
+	defer func() { database.DB, database.RedisClient = originalDB, originalRedis }()
+
+	mock.ExpectPing()
+	mock.ExpectQuery(`SELECT "version" FROM "schema_migrations"`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+
+	// Checks run concurrently, the order of the expectations does not matter
+	mock.MatchExpectationsInOrder(false)
+	report := Run(context.Background(), DefaultChecks())
+	assert.Equal(t, StatusUnavailable, report.Status)
+	assert.Equal(t, StatusOK, report.Checks["database"].Status)
+	assert.Equal(t, StatusUnavailable, report.Checks["migrations"].Status)
+	assert.Contains(t, report.Checks["migrations"].Error, "pending migrations, next is 1_baseline")
+	assert.Equal(t, StatusDisabled, report.Checks["redis"].Status)
+	assert.Equal(t, StatusDisabled, report.Checks["analyzer"].Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,125 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// HeaderRequestID carries the ID of a request, accepted from the client and sent on the outbound calls
+const HeaderRequestID = "X-Request-ID"
+
+// Formats of the log lines
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+var (
+	// Levels are the names accepted by Setup
+	Levels = []string{"debug", "info", "warn", "error"}
+	// Formats are the formats accepted by Setup
+	Formats = []string{FormatJSON, FormatText}
+)
+
+// requestIDRegexp bounds the IDs accepted from the clients, which end up in the logs and the headers
+var requestIDRegexp = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+type requestIDKey struct{}
+
+// Setup makes slog, and the log package through it, write lines of the given level and format to stdout
+func Setup(level, format string) error {
+	handler, err := NewHandler(os.Stdout, level, format)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// NewHandler returns a slog handler adding the request ID of the context to each line
+func NewHandler(w io.Writer, level, format string) (slog.Handler, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q", level)
+	}
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		return contextHandler{slog.NewJSONHandler(w, opts)}, nil
+	case FormatText:
+		return contextHandler{slog.NewTextHandler(w, opts)}, nil
+	}
+	return nil, fmt.Errorf("invalid log format %q", format)
+}
+
+// NewRequestID returns a new random request ID
+func NewRequestID() string {
+	return uuid.NewString()
+}
+
+// ValidRequestID reports whether an ID received from a client can be reused
+func ValidRequestID(id string) bool {
+	return requestIDRegexp.MatchString(id)
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID carried by ctx, empty outside of a request
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// NewTransport returns a transport sending the request ID of the context of each outbound request.
+// A nil base uses http.DefaultTransport.
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return transport{base}
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+func (t transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	id := RequestID(req.Context())
+	if id == "" || req.Header.Get(HeaderRequestID) != "" {
+		return t.base.RoundTrip(req)
+	}
+	// A RoundTripper must not modify the request
+	req = req.Clone(req.Context())
+	req.Header.Set(HeaderRequestID, id)
+	return t.base.RoundTrip(req)
+}
+
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
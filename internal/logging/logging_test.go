@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHandler(t *testing.T) {
+	var buf bytes.Buffer
+	handler, err := NewHandler(&buf, "info", FormatJSON)
+	assert.NoError(t, err)
+	logger := slog.New(handler)
+
+	logger.DebugContext(context.Background(), "hidden")
+	logger.With("board_id", 3).InfoContext(WithRequestID(context.Background(), "req-1"), "imported")
+
+	var line map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "imported", line["msg"])
+	assert.Equal(t, "req-1", line["request_id"])
+	assert.Equal(t, 3.0, line["board_id"])
+
+	_, err = NewHandler(&buf, "verbose", FormatJSON)
+	assert.EqualError(t, err, `invalid log level "verbose"`)
+	_, err = NewHandler(&buf, "info", "xml")
+	assert.EqualError(t, err, `invalid log format "xml"`)
+}
+
+func TestValidRequestID(t *testing.T) {
+	assert.True(t, ValidRequestID(NewRequestID()))
+	assert.True(t, ValidRequestID("trace-01:abc.def_2"))
+	assert.False(t, ValidRequestID(""))
+	assert.False(t, ValidRequestID("with space"))
+	assert.False(t, ValidRequestID("line\nbreak"))
+	assert.False(t, ValidRequestID(string(make([]byte, 129))))
+}
+
+func TestTransport(t *testing.T) {
+	received := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get(HeaderRequestID)
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: NewTransport(nil)}
+
+	req, _ := http.NewRequestWithContext(WithRequestID(context.Background(), "req-2"), "GET", server.URL, nil)
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "req-2", <-received)
+	assert.Empty(t, req.Header.Get(HeaderRequestID))
+
+	resp, err = client.Get(server.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, <-received)
+}
@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/background"
+)
+
+// namespace prefixes the name of every metric of the API
+const namespace = "feedpulse"
+
+// Results of the analyzer calls, the cache lookups and the emails
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+	ResultHit     = "hit"
+	ResultMiss    = "miss"
+	ResultSent    = "sent"
+	ResultFailed  = "failed"
+)
+
+// Registry holds the metrics exposed on /metrics, along with the Go runtime and process metrics
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of the HTTP requests by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	analyzerCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "analyzer_calls_total",
+		Help:      "Calls to the sentiment analyzer by result, each retry being a call.",
+	}, []string{"result"})
+
+	analyzerDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "analyzer_call_duration_seconds",
+		Help:      "Latency of the calls to the sentiment analyzer.",
+		Buckets:   []float64{0.25, 0.5, 1, 2, 4, 8, 16, 32},
+	})
+
+	analyzerRetries = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "analyzer_retries_total",
+		Help:      "Calls to the sentiment analyzer retried after a failure.",
+	})
+
+	analysisQueue = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "analysis_queue_depth",
+		Help:      "Imported feedbacks waiting for their analysis.",
+	})
+
+	backgroundTasks = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "background_tasks",
+		Help:      "Background tasks running, such as import analyses and mention emails.",
+	}, func() float64 { return float64(background.Running()) })
+
+	cacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_lookups_total",
+		Help:      "Lookups of the feedback cache by key prefix and result.",
+	}, []string{"prefix", "result"})
+
+	emails = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "emails_total",
+		Help:      "Emails by result.",
+	}, []string{"result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests, httpDuration,
+		analyzerCalls, analyzerDuration, analyzerRetries,
+		analysisQueue, backgroundTasks,
+		cacheLookups, emails,
+	)
+}
+
+// Handler serves the metrics in the Prometheus text format
+func Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+}
+
+// RegisterDB exposes the connection pool stats of the database
+func RegisterDB(db *sql.DB) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, "postgres"))
+}
+
+// ObserveRequest records a handled HTTP request, route being the pattern of the matched route
+func ObserveRequest(method, route string, status int, duration time.Duration) {
+	labels := prometheus.Labels{"method": method, "route": route, "status": strconv.Itoa(status)}
+	httpRequests.With(labels).Inc()
+	httpDuration.With(labels).Observe(duration.Seconds())
+}
+
+// ObserveAnalyzerCall records a call to the sentiment analyzer
+func ObserveAnalyzerCall(duration time.Duration, err error) {
+	analyzerDuration.Observe(duration.Seconds())
+	analyzerCalls.WithLabelValues(result(err, ResultSuccess, ResultError)).Inc()
+}
+
+// AnalyzerRetry records a call to the sentiment analyzer retried after a failure
+func AnalyzerRetry() {
+	analyzerRetries.Inc()
+}
+
+// QueueAnalyses adds delta feedbacks to the analysis queue, a negative delta removes them
+func QueueAnalyses(delta int) {
+	analysisQueue.Add(float64(delta))
+}
+
+// ObserveCacheLookup records a lookup of a key of the cache, redis.Nil being a miss
+func ObserveCacheLookup(prefix string, err error) {
+	outcome := ResultHit
+	switch {
+	case errors.Is(err, redis.Nil):
+		outcome = ResultMiss
+	case err != nil:
+		outcome = ResultError
+	}
+	cacheLookups.WithLabelValues(prefix, outcome).Inc()
+}
+
+// ObserveEmail records an email sent or which failed to be sent
+func ObserveEmail(err error) {
+	emails.WithLabelValues(result(err, ResultSent, ResultFailed)).Inc()
+}
+
+func result(err error, success, failure string) string {
+	if err != nil {
+		return failure
+	}
+	return success
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveCacheLookup(t *testing.T) {
+	ObserveCacheLookup("feedback", nil)
+	ObserveCacheLookup("feedback", redis.Nil)
+	ObserveCacheLookup("feedback", errors.New("connection refused"))
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(cacheLookups.WithLabelValues("feedback", ResultHit)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(cacheLookups.WithLabelValues("feedback", ResultMiss)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(cacheLookups.WithLabelValues("feedback", ResultError)))
+}
+
+func TestObserveAnalyzerCall(t *testing.T) {
+	ObserveAnalyzerCall(time.Second, errors.New("rate limited"))
+	AnalyzerRetry()
+	ObserveAnalyzerCall(time.Second, nil)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(analyzerCalls.WithLabelValues(ResultError)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(analyzerCalls.WithLabelValues(ResultSuccess)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(analyzerRetries))
+}
+
+func TestQueueAnalyses(t *testing.T) {
+	QueueAnalyses(3)
+	QueueAnalyses(-1)
+	assert.Equal(t, 2.0, testutil.ToFloat64(analysisQueue))
+	QueueAnalyses(-2)
+}
+
+func TestHandler(t *testing.T) {
+	ObserveEmail(nil)
+
+	app := fiber.New()
+	app.Get("/metrics", Handler())
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `feedpulse_emails_total{result="sent"} 1`)
+	assert.Contains(t, string(body), "feedpulse_background_tasks 0")
+	assert.Contains(t, string(body), "go_goroutines")
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+
+	apiKeyDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/APIKey"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/apiKey"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
+)
+
+const (
+	// BoardContextKey is the key used to store the board ID granted by an API key
+	BoardContextKey = "boardID"
+	// APIKeyContextKey is the key used to store the ID of the API key used by the request
+	APIKeyContextKey = "apiKeyID"
+	// APIKeyHeader is the header carrying the API key, "Authorization: Bearer fp_..." is accepted too
+	APIKeyHeader = "X-API-Key"
+)
+
+// lastUsedResolution limits the writes made to record the last use of a key
+const lastUsedResolution = time.Minute
+
+// APIKeyRequired middleware ensures that the request carries a valid, unexpired API key granting scope.
+// It stores the board of the key in the context, handlers read it with GetBoardID.
+func APIKeyRequired(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := getAPIKey(c)
+		if key == "" {
+			return httpUtils.NewError(c, fiber.StatusUnauthorized, errors.New("unauthorized: Missing API key"))
+		}
+
+		prefix, err := apiKey.ParsePrefix(key)
+		if err != nil {
+			return httpUtils.NewError(c, fiber.StatusUnauthorized, errors.New("unauthorized: Invalid API key"))
+		}
+
+		storedKey, err := apiKeyDB.GetAPIKeyByPrefix(prefix)
+		if err != nil {
+			if !errors.Is(err, apiKeyDB.ErrAPIKeyNotFound) {
+				CaptureEvent(c, &sentry.Event{
+					Message: fmt.Sprintf("Failed to retrieve API key %s: %v", prefix, err),
+					Level:   sentry.LevelError,
+					Tags: map[string]string{
+						"handler": "APIKeyRequired",
+						"action":  "get_api_key",
+					},
+				})
+				return httpUtils.NewError(c, fiber.StatusInternalServerError, errors.New("failed to validate API key"))
+			}
+			return httpUtils.NewError(c, fiber.StatusUnauthorized, errors.New("unauthorized: Invalid API key"))
+		}
+
+		now := time.Now()
+		if !apiKey.Matches(key, storedKey.Hash) || storedKey.RevokedAt != nil {
+			return httpUtils.NewError(c, fiber.StatusUnauthorized, errors.New("unauthorized: Invalid API key"))
+		}
+		if storedKey.ExpiresAt != nil && now.After(*storedKey.ExpiresAt) {
+			return httpUtils.NewError(c, fiber.StatusUnauthorized, errors.New("unauthorized: Expired API key"))
+		}
+		if !apiKey.HasScope(storedKey.Scopes, scope) {
+			return httpUtils.NewError(c, fiber.StatusForbidden, fmt.Errorf("forbidden: API key lacks the %s scope", scope))
+		}
+
+		if storedKey.LastUsedAt == nil || now.Sub(*storedKey.LastUsedAt) > lastUsedResolution {
+			_ = apiKeyDB.TouchAPIKey(storedKey.Id)
+		}
+
+		c.Locals(BoardContextKey, storedKey.BoardID)
+		c.Locals(APIKeyContextKey, storedKey.Id)
+		return c.Next()
+	}
+}
+
+// AuthOrAPIKey accepts either a user session or an API key granting scope
+func AuthOrAPIKey(scope string) fiber.Handler {
+	apiKeyHandler := APIKeyRequired(scope)
+	authHandler := AuthRequired()
+	return func(c *fiber.Ctx) error {
+		if getAPIKey(c) != "" {
+			return apiKeyHandler(c)
+		}
+		return authHandler(c)
+	}
+}
+
+// GetBoardID retrieves the board ID granted by an API key from the Fiber context
+func GetBoardID(c *fiber.Ctx) (int, bool) {
+	boardID, ok := c.Locals(BoardContextKey).(int)
+	return boardID, ok && boardID > 0
+}
+
+// GetAPIKeyID retrieves the ID of the API key used by the request
+func GetAPIKeyID(c *fiber.Ctx) (int, bool) {
+	id, ok := c.Locals(APIKeyContextKey).(int)
+	return id, ok && id > 0
+}
+
+// getAPIKey returns the API key of the request from the X-API-Key or Authorization header
+func getAPIKey(c *fiber.Ctx) string {
+	if key := c.Get(APIKeyHeader); key != "" {
+		return key
+	}
+	authHeader := c.Get("Authorization")
+	if strings.HasPrefix(authHeader, "Bearer "+apiKey.KeyPrefix) {
+		return strings.TrimPrefix(authHeader, "Bearer ")
+	}
+	return ""
+}
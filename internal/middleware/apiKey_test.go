@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/sessionManager"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/apiKey"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupAPIKeyMock(t *testing.T) (sqlmock.Sqlmock, func()) {
+	mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:                 mockDB,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Error opening gorm DB: %v", err)
+	}
+
+	originalDB := database.DB
+	database.DB = db
+	return mock, func() {
+		database.DB = originalDB
+		mockDB.Close()
+	}
+}
+
+func setupAPIKeyApp(handler fiber.Handler) *fiber.App {
+	app := fiber.New()
+	app.Get("/board", handler, func(c *fiber.Ctx) error {
+		boardID, ok := GetBoardID(c)
+		keyID, _ := GetAPIKeyID(c)
+		return c.JSON(fiber.Map{"boardID": boardID, "ok": ok, "keyID": keyID})
+	})
+	return app
+}
+
+// expectAPIKey mocks the lookup of a key, lastUsedAt is recent so no update is made
+func expectAPIKey(mock sqlmock.Sqlmock, prefix, hash, scopes string, expiresAt, revokedAt interface{}) {
+	mock.ExpectQuery(`SELECT (.+) FROM "api_keys" WHERE prefix = \$1`).
+		WithArgs(prefix, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "prefix", "hash", "scopes", "expires_at", "last_used_at", "revoked_at", "board_id"}).
+			AddRow(7, "ci", prefix, hash, scopes, expiresAt, time.Now(), revokedAt, 3))
+}
+
+func TestAPIKeyRequired(t *testing.T) {
+	key, prefix, hash, err := apiKey.Generate()
+	assert.NoError(t, err)
+
+	t.Run("Missing key", func(t *testing.T) {
+		app := setupAPIKeyApp(APIKeyRequired(apiKey.ScopeFeedbackWrite))
+		req, _ := http.NewRequest("GET", "/board", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("Malformed key", func(t *testing.T) {
+		app := setupAPIKeyApp(APIKeyRequired(apiKey.ScopeFeedbackWrite))
+		req, _ := http.NewRequest("GET", "/board", nil)
+		req.Header.Set(APIKeyHeader, "not-a-key")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+
+	tests := []struct {
+		name      string
+		hash      string
+		scopes    string
+		expiresAt interface{}
+		revokedAt interface{}
+		status    int
+	}{
+		{"Valid key", hash, `["feedback:write"]`, nil, nil, fiber.StatusOK},
+		{"Wrong secret", apiKey.Hash("fp_00000000_other"), `["feedback:write"]`, nil, nil, fiber.StatusUnauthorized},
+		{"Revoked key", hash, `["feedback:write"]`, nil, time.Now().Add(-time.Hour), fiber.StatusUnauthorized},
+		{"Expired key", hash, `["feedback:write"]`, time.Now().Add(-time.Hour), nil, fiber.StatusUnauthorized},
+		{"Missing scope", hash, `["metrics:read"]`, nil, nil, fiber.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, cleanup := setupAPIKeyMock(t)
+			defer cleanup()
+			expectAPIKey(mock, prefix, tt.hash, tt.scopes, tt.expiresAt, tt.revokedAt)
+
+			app := setupAPIKeyApp(APIKeyRequired(apiKey.ScopeFeedbackWrite))
+			req, _ := http.NewRequest("GET", "/board", nil)
+			req.Header.Set(APIKeyHeader, key)
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAuthOrAPIKey(t *testing.T) {
+	sessionManager.InitSessionManager("test-secret-key", time.Hour)
+	key, prefix, hash, err := apiKey.Generate()
+	assert.NoError(t, err)
+
+	t.Run("Bearer API key", func(t *testing.T) {
+		mock, cleanup := setupAPIKeyMock(t)
+		defer cleanup()
+		expectAPIKey(mock, prefix, hash, `["metrics:read"]`, nil, nil)
+
+		app := setupAPIKeyApp(AuthOrAPIKey(apiKey.ScopeMetricsRead))
+		req, _ := http.NewRequest("GET", "/board", nil)
+		req.Header.Set("Authorization", "Bearer "+key)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("Falls back to session", func(t *testing.T) {
+		app := setupAPIKeyApp(AuthOrAPIKey(apiKey.ScopeMetricsRead))
+		req, _ := http.NewRequest("GET", "/board", nil)
+		req.Header.Set("Authorization", "Bearer invalid.jwt.token")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+}
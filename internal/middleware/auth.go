@@ -32,7 +32,7 @@ func AuthRequired() fiber.Handler {
 
 		// Check if the authorization header exists and has the bearer format
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-			sentry.CaptureEvent(&sentry.Event{
+			CaptureEvent(c, &sentry.Event{
 				Message: "Unauthorized access attempt: Missing or invalid authorization header",
 				Level:   sentry.LevelError,
 				Tags: map[string]string{
@@ -50,7 +50,7 @@ func AuthRequired() fiber.Handler {
 		// Validate the session using the session manager
 		valid, err := sessionManager.Instance.ValidateSession(tokenString)
 		if err != nil || !valid {
-			sentry.CaptureEvent(&sentry.Event{
+			CaptureEvent(c, &sentry.Event{
 				Message: fmt.Sprintf("Unauthorized access attempt: %v", err),
 				Level:   sentry.LevelError,
 				Tags: map[string]string{
@@ -70,7 +70,7 @@ func AuthRequired() fiber.Handler {
 		})
 
 		if err != nil || !token.Valid {
-			sentry.CaptureEvent(&sentry.Event{
+			CaptureEvent(c, &sentry.Event{
 				Message: fmt.Sprintf("Unauthorized access attempt: %v", err),
 				Level:   sentry.LevelError,
 				Tags: map[string]string{
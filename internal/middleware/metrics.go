@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/metrics"
+)
+
+// unmatchedRoute labels the requests which matched no route, such as scanned paths and CORS preflights,
+// so that they do not create a series per path
+const unmatchedRoute = "unmatched"
+
+// Metrics records the count and the latency of the requests by route pattern and status
+func Metrics() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		// The status is only final once the error handler has written the response
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			if e, ok := err.(*fiber.Error); ok {
+				status = e.Code
+			}
+		}
+		// A request matching no route keeps the route of the middleware
+		route := c.Route().Path
+		if route == "/" && c.Path() != "/" {
+			route = unmatchedRoute
+		}
+		metrics.ObserveRequest(c.Method(), route, status, time.Since(start))
+		return err
+	}
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/metrics"
+)
+
+func TestMetrics(t *testing.T) {
+	app := fiber.New()
+	app.Use(Metrics())
+	app.Get("/boards/:id", func(c *fiber.Ctx) error {
+		return c.SendString(c.Params("id"))
+	})
+	app.Get("/fail", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusConflict, "conflict")
+	})
+	app.Get("/metrics", metrics.Handler())
+
+	for _, path := range []string{"/boards/1", "/boards/2", "/fail", "/wp-login.php"} {
+		_, err := app.Test(httptest.NewRequest("GET", path, nil))
+		assert.NoError(t, err)
+	}
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	// Requests are labelled with the pattern of their route, not their path
+	assert.Contains(t, string(body), `feedpulse_http_requests_total{method="GET",route="/boards/:id",status="200"} 2`)
+	assert.Contains(t, string(body), `feedpulse_http_requests_total{method="GET",route="/fail",status="409"} 1`)
+	assert.Contains(t, string(body), `feedpulse_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.NotContains(t, string(body), "wp-login")
+}
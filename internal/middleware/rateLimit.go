@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/httpUtils"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/rateLimit"
+)
+
+// RateLimitKey identifies the client whose requests are counted together
+type RateLimitKey func(c *fiber.Ctx) string
+
+// KeyByIP counts the requests by client IP, for the routes without authentication
+func KeyByIP(c *fiber.Ctx) string {
+	return rateLimit.KindIP + ":" + c.IP()
+}
+
+// KeyByClient counts the requests by API key or user UUID, by IP when the request is not authenticated.
+// It must run after AuthRequired or AuthOrAPIKey.
+func KeyByClient(c *fiber.Ctx) string {
+	if id, ok := GetAPIKeyID(c); ok {
+		return rateLimit.KindAPIKey + ":" + strconv.Itoa(id)
+	}
+	if userUUID, ok := GetUserUUID(c); ok {
+		return rateLimit.KindUser + ":" + userUUID
+	}
+	return KeyByIP(c)
+}
+
+// KeyByBoardToken counts the requests by the board_token parameter, for the public ingestion routes.
+// The token is hashed, the secret is not kept in the limiter keys.
+func KeyByBoardToken(c *fiber.Ctx) string {
+	sum := sha256.Sum256([]byte(c.Params("board_token")))
+	return rateLimit.KindBoard + ":" + hex.EncodeToString(sum[:16])
+}
+
+// RateLimit lets the rule of the policy for the kind of key through per sliding window for each key of the group,
+// answering 429 with Retry-After beyond. The RateLimit headers describe the window of the client.
+func RateLimit(group string, policy rateLimit.Policy, key RateLimitKey) fiber.Handler {
+	return RateLimitWhen(group, policy, key, nil)
+}
+
+// RateLimitWhen is RateLimit counting only the requests for which counted returns true, every request when it is nil
+func RateLimitWhen(group string, policy rateLimit.Policy, key RateLimitKey, counted func(c *fiber.Ctx) bool) fiber.Handler {
+	if !policy.Enabled() {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+	return func(c *fiber.Ctx) error {
+		if counted != nil && !counted(c) {
+			return c.Next()
+		}
+		k := key(c)
+		rule := policy.Rule(k)
+		if !rule.Enabled() {
+			return c.Next()
+		}
+		result := rateLimit.Allow(c.UserContext(), group+":"+k, rule)
+		reset := strconv.Itoa(seconds(result.Reset))
+		c.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", rule.Limit, int(rule.Window.Seconds())))
+		c.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Set("RateLimit-Reset", reset)
+		if !result.Allowed {
+			c.Set(fiber.HeaderRetryAfter, reset)
+			return httpUtils.NewError(c, fiber.StatusTooManyRequests, fmt.Errorf("too many requests, retry in %s seconds", reset))
+		}
+		return c.Next()
+	}
+}
+
+// seconds rounds up, so that a client waiting for Retry-After finds a free slot
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
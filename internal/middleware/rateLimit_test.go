@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/rateLimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	app := fiber.New()
+	app.Post("/login", RateLimit("test-login", rateLimit.Policy{Default: rateLimit.Rule{Limit: 2, Window: time.Minute}}, KeyByIP), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	for i, remaining := range []string{"1", "0"} {
+		resp, err := app.Test(httptest.NewRequest("POST", "/login", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode, "request %d", i+1)
+		assert.Equal(t, "2", resp.Header.Get("RateLimit-Limit"))
+		assert.Equal(t, remaining, resp.Header.Get("RateLimit-Remaining"))
+		assert.Equal(t, "2;w=60", resp.Header.Get("RateLimit-Policy"))
+	}
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/login", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "0", resp.Header.Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", resp.Header.Get("Retry-After"))
+	assert.Equal(t, "60", resp.Header.Get("RateLimit-Reset"))
+}
+
+func TestRateLimit_Disabled(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", RateLimit("test-disabled", rateLimit.Policy{}, KeyByIP), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("RateLimit-Limit"))
+}
+
+func TestRateLimit_KindRule(t *testing.T) {
+	policy := rateLimit.Policy{
+		Default: rateLimit.Rule{Limit: 1, Window: time.Minute},
+		Kinds:   map[string]rateLimit.Rule{rateLimit.KindAPIKey: {Limit: 5, Window: time.Minute}, rateLimit.KindIP: {}},
+	}
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		if c.Query("api_key") != "" {
+			c.Locals(APIKeyContextKey, 7)
+		}
+		return c.Next()
+	}, RateLimit("test-kind", policy, KeyByClient), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/?api_key=1", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "5", resp.Header.Get("RateLimit-Limit"))
+
+	// The IPs are not limited by the policy
+	for range 2 {
+		resp, err = app.Test(httptest.NewRequest("GET", "/", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get("RateLimit-Limit"))
+	}
+}
+
+func TestRateLimitWhen(t *testing.T) {
+	rule := rateLimit.Policy{Default: rateLimit.Rule{Limit: 1, Window: time.Minute}}
+	app := fiber.New()
+	app.Post("/", RateLimitWhen("test-when", rule, KeyByIP, func(c *fiber.Ctx) bool {
+		return c.Query("analyze") == "true"
+	}), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	for _, target := range []string{"/?analyze=true", "/", "/"} {
+		resp, err := app.Test(httptest.NewRequest("POST", target, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode, target)
+	}
+	resp, err := app.Test(httptest.NewRequest("POST", "/?analyze=true", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+}
+
+func TestKeyByBoardToken(t *testing.T) {
+	app := fiber.New()
+	app.Post("/webhook/:board_token", func(c *fiber.Ctx) error {
+		return c.SendString(KeyByBoardToken(c))
+	})
+	resp, err := app.Test(httptest.NewRequest("POST", "/webhook/secret-token", nil))
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Regexp(t, "^board:[0-9a-f]{32}$", string(body))
+	assert.NotContains(t, string(body), "secret-token")
+}
+
+func TestKeyByClient(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		keys := []string{KeyByClient(c)}
+		c.Locals(UserContextKey, "user-uuid")
+		keys = append(keys, KeyByClient(c))
+		c.Locals(APIKeyContextKey, 4)
+		keys = append(keys, KeyByClient(c))
+		return c.JSON(keys)
+	})
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	assert.NoError(t, err)
+	var keys []string
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&keys))
+	assert.Equal(t, []string{"ip:0.0.0.0", "user:user-uuid", "apikey:4"}, keys)
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/logging"
+)
+
+// requestIDKey is the key of the request ID in the locals of the request
+const requestIDKey = "requestID"
+
+// RequestID reuses the X-Request-ID of the client when it is valid, or generates one, then sends it back
+// and carries it in the user context, for the logs and the outbound calls, and on the Sentry scope
+func RequestID() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(logging.HeaderRequestID)
+		if !logging.ValidRequestID(id) {
+			id = logging.NewRequestID()
+		}
+		c.Locals(requestIDKey, id)
+		c.Set(logging.HeaderRequestID, id)
+		c.SetUserContext(logging.WithRequestID(c.UserContext(), id))
+		if hub := GetHubFromContext(c); hub != nil {
+			hub.Scope().SetTag("request_id", id)
+		}
+		if span := GetSpanFromContext(c); span != nil {
+			span.SetTag("request_id", id)
+		}
+		return c.Next()
+	}
+}
+
+// GetRequestID returns the ID of the request, empty when the RequestID middleware did not run
+func GetRequestID(c *fiber.Ctx) string {
+	id, _ := c.Locals(requestIDKey).(string)
+	return id
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/logging"
+)
+
+func TestRequestID(t *testing.T) {
+	app := fiber.New()
+	app.Use(RequestID())
+	app.Get("/id", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"locals": GetRequestID(c), "context": logging.RequestID(c.UserContext())})
+	})
+
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{"Reuses the ID of the client", "client-id-1", "client-id-1"},
+		{"Generates an ID when missing", "", ""},
+		{"Replaces an invalid ID", "bad id\twith spaces", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/id", nil)
+			if tt.header != "" {
+				req.Header.Set(logging.HeaderRequestID, tt.header)
+			}
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+
+			var body map[string]string
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			id := resp.Header.Get(logging.HeaderRequestID)
+			if tt.expected != "" {
+				assert.Equal(t, tt.expected, id)
+			} else {
+				assert.True(t, logging.ValidRequestID(id))
+				assert.NotEqual(t, tt.header, id)
+			}
+			assert.Equal(t, id, body["locals"])
+			assert.Equal(t, id, body["context"])
+		})
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	var buf bytes.Buffer
+	handler, err := logging.NewHandler(&buf, "info", logging.FormatJSON)
+	assert.NoError(t, err)
+	previous := slog.Default()
+	slog.SetDefault(slog.New(handler))
+	defer slog.SetDefault(previous)
+
+	app := fiber.New()
+	app.Use(RequestID(), AccessLog())
+	app.Get("/boards/:id", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusNotFound, "board not found")
+	})
+
+	req := httptest.NewRequest("GET", "/boards/7", nil)
+	req.Header.Set(logging.HeaderRequestID, "req-7")
+	_, err = app.Test(req)
+	assert.NoError(t, err)
+
+	var line map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "WARN", line["level"])
+	assert.Equal(t, "request", line["msg"])
+	assert.Equal(t, "req-7", line["request_id"])
+	assert.Equal(t, "/boards/:id", line["route"])
+	assert.Equal(t, "/boards/7", line["path"])
+	assert.Equal(t, 404.0, line["status"])
+}
@@ -123,6 +123,20 @@ func (h *handler) recoverWithSentry(hub *sentry.Hub, ctx *fiber.Ctx) {
 	}
 }
 
+// CaptureEvent reports the event on the hub of the request, tagged with the request ID
+func CaptureEvent(ctx *fiber.Ctx, event *sentry.Event) *sentry.EventID {
+	if id := GetRequestID(ctx); id != "" {
+		if event.Tags == nil {
+			event.Tags = make(map[string]string)
+		}
+		event.Tags["request_id"] = id
+	}
+	if hub := GetHubFromContext(ctx); hub != nil {
+		return hub.CaptureEvent(event)
+	}
+	return sentry.CaptureEvent(event)
+}
+
 // GetHubFromContext retrieves the Hub instance from the *fiber.Ctx.
 func GetHubFromContext(ctx *fiber.Ctx) *sentry.Hub {
 	if hub, ok := ctx.Locals(valuesKey).(*sentry.Hub); ok {
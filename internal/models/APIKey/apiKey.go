@@ -0,0 +1,32 @@
+package APIKey
+
+import (
+	"time"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/BaseModel"
+)
+
+// APIKey grants machine access to a board, only the SHA-256 of the key is stored
+type APIKey struct {
+	BaseModel.BaseModel
+	Name       string     `json:"name" gorm:"not null"`
+	Prefix     string     `json:"prefix" gorm:"not null;uniqueIndex"`
+	Hash       string     `json:"-" gorm:"not null"`
+	Scopes     []string   `json:"scopes" gorm:"serializer:json;not null"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	BoardID    int        `json:"board_id" gorm:"not null;index"`
+}
+
+type APIKeyJson struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// CreatedAPIKey is returned once on creation, Key cannot be retrieved afterwards
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
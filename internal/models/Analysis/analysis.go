@@ -3,6 +3,7 @@ package Analysis
 import (
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/BaseModel"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"gorm.io/gorm"
 )
 
 type Analysis struct {
@@ -10,6 +11,8 @@ type Analysis struct {
 	SentimentScore float64 `json:"sentiment_score" gorm:"not null"`
 	Topic          string  `json:"topic" gorm:"not null"`
 	FeedbackID     int     `json:"feedback_id" gorm:"not null;unique"`
+	// DeletedAt follows the one of the feedback
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Associate Feedback
 	Feedback Feedback.Feedback
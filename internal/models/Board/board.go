@@ -4,11 +4,18 @@ import (
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/BaseModel"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/User"
+	"gorm.io/gorm"
 )
 
 type Board struct {
 	BaseModel.BaseModel
 	Name string `json:"name" gorm:"not null"`
+	// DeletedAt puts the board in the trash along with its feedbacks until it is restored or purged
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+
+	// Credentials of the inbound webhook, generated on first use and only written by updates
+	WebhookToken  *string `json:"-" gorm:"uniqueIndex;<-:update"`
+	WebhookSecret string  `json:"-" gorm:"<-:update"`
 
 	Users     []User.User         `gorm:"many2many:user_boards;"`
 	Feedbacks []Feedback.Feedback `gorm:"foreignKey:BoardID"`
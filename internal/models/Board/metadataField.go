@@ -0,0 +1,137 @@
+package Board
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/BaseModel"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+)
+
+// Types of a metadata field
+const (
+	MetadataTypeString  = "string"
+	MetadataTypeNumber  = "number"
+	MetadataTypeBoolean = "boolean"
+	MetadataTypeDate    = "date"
+)
+
+var MetadataTypes = []string{MetadataTypeString, MetadataTypeNumber, MetadataTypeBoolean, MetadataTypeDate}
+
+// MetadataField declares a custom field of the board's feedbacks, keys without a declaration are accepted as is
+type MetadataField struct {
+	BaseModel.BaseModel
+	BoardID       int      `json:"board_id" gorm:"not null;uniqueIndex:idx_metadata_fields_board_key,priority:1"`
+	Key           string   `json:"key" gorm:"not null;uniqueIndex:idx_metadata_fields_board_key,priority:2"`
+	Type          string   `json:"type" gorm:"not null"`
+	AllowedValues []string `json:"allowed_values,omitempty" gorm:"serializer:json"`
+}
+
+type MetadataFieldJson struct {
+	Key           string   `json:"key"`
+	Type          string   `json:"type"`
+	AllowedValues []string `json:"allowed_values,omitempty"`
+}
+
+// Validate checks the declaration of a field
+func (f MetadataFieldJson) Validate() error {
+	if f.Key == "" {
+		return fmt.Errorf("key is required")
+	}
+	if strings.ContainsAny(f.Key, " .") {
+		return fmt.Errorf("key %q must not contain spaces or dots", f.Key)
+	}
+	if !slices.Contains(MetadataTypes, f.Type) {
+		return fmt.Errorf("type of %q must be one of %s", f.Key, strings.Join(MetadataTypes, ", "))
+	}
+	if len(f.AllowedValues) > 0 && f.Type == MetadataTypeBoolean {
+		return fmt.Errorf("allowed values are not supported on boolean field %q", f.Key)
+	}
+	field := MetadataField{Key: f.Key, Type: f.Type}
+	for _, value := range f.AllowedValues {
+		if _, err := field.coerce(value); err != nil {
+			return fmt.Errorf("allowed value %q: %w", value, err)
+		}
+	}
+	return nil
+}
+
+// MetadataSchema is the set of fields declared on a board
+type MetadataSchema []MetadataField
+
+// Validate checks metadata against the schema and returns it with its values converted to the declared types.
+// Text values such as the cells of a CSV file are parsed as numbers, booleans or dates when the field asks for it.
+func (s MetadataSchema) Validate(metadata Feedback.Metadata) (Feedback.Metadata, error) {
+	if len(metadata) == 0 {
+		return metadata, nil
+	}
+	validated := make(Feedback.Metadata, len(metadata))
+	for key, value := range metadata {
+		validated[key] = value
+		if value == nil {
+			continue
+		}
+		field := s.field(key)
+		if field == nil {
+			continue
+		}
+		coerced, err := field.coerce(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(field.AllowedValues) > 0 && !slices.Contains(field.AllowedValues, Feedback.MetadataText(coerced)) {
+			return nil, fmt.Errorf("metadata %q must be one of %s", key, strings.Join(field.AllowedValues, ", "))
+		}
+		validated[key] = coerced
+	}
+	return validated, nil
+}
+
+func (s MetadataSchema) field(key string) *MetadataField {
+	for i := range s {
+		if s[i].Key == key {
+			return &s[i]
+		}
+	}
+	return nil
+}
+
+// coerce converts a value to the type of the field
+func (f MetadataField) coerce(value interface{}) (interface{}, error) {
+	text, isText := value.(string)
+	if isText {
+		text = strings.TrimSpace(text)
+	}
+	switch f.Type {
+	case MetadataTypeNumber:
+		if number, ok := value.(float64); ok {
+			return number, nil
+		}
+		if number, err := strconv.ParseFloat(text, 64); isText && err == nil {
+			return number, nil
+		}
+	case MetadataTypeBoolean:
+		if boolean, ok := value.(bool); ok {
+			return boolean, nil
+		}
+		if boolean, err := strconv.ParseBool(text); isText && err == nil {
+			return boolean, nil
+		}
+	case MetadataTypeDate:
+		if isText {
+			for _, layout := range []string{time.RFC3339, time.DateOnly} {
+				if date, err := time.Parse(layout, text); err == nil {
+					return date.Format(layout), nil
+				}
+			}
+		}
+	default:
+		if isText {
+			return text, nil
+		}
+	}
+	return nil, fmt.Errorf("metadata %q must be a %s", f.Key, f.Type)
+}
@@ -0,0 +1,42 @@
+package Customer
+
+import (
+	"time"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/BaseModel"
+)
+
+// Customer is the author of feedbacks on a board, identified by its external ID or, failing that, its email.
+// Attributes holds free segment data such as the plan or the country of the customer.
+type Customer struct {
+	BaseModel.BaseModel
+	BoardID     int               `json:"board_id" gorm:"not null;uniqueIndex:idx_customers_board_external_id,priority:1;uniqueIndex:idx_customers_board_email,priority:1"`
+	ExternalID  *string           `json:"external_id,omitempty" gorm:"uniqueIndex:idx_customers_board_external_id,priority:2"`
+	Email       *string           `json:"email,omitempty" gorm:"uniqueIndex:idx_customers_board_email,priority:2"`
+	Name        string            `json:"name"`
+	Attributes  map[string]string `json:"attributes" gorm:"serializer:json"`
+	FirstSeenAt time.Time         `json:"first_seen_at"`
+	LastSeenAt  time.Time         `json:"last_seen_at"`
+}
+
+// CustomerJson is the author of a feedback as given on ingestion
+type CustomerJson struct {
+	ExternalID string            `json:"external_id,omitempty"`
+	Name       string            `json:"name,omitempty"`
+	Email      string            `json:"email,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// CustomerWithStats is a customer along with a summary of its feedbacks
+type CustomerWithStats struct {
+	Customer
+	FeedbackCount    int      `json:"feedback_count"`
+	AverageSentiment *float64 `json:"average_sentiment"`
+}
+
+// SentimentPoint is the average sentiment of a customer's feedbacks over a period
+type SentimentPoint struct {
+	Period           time.Time `json:"period"`
+	FeedbackCount    int       `json:"feedback_count"`
+	AverageSentiment *float64  `json:"average_sentiment"`
+}
@@ -0,0 +1,70 @@
+package Feedback
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/BaseModel"
+)
+
+// maxCommentLength is the maximum number of characters of a comment body
+const maxCommentLength = 5000
+
+var mentionRegexp = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9_.\-]+)`)
+
+// Comment is an internal note of a board member on a feedback.
+// A reply points to the first comment of its thread, threads are one level deep.
+type Comment struct {
+	BaseModel.BaseModel
+	FeedbackID int `json:"feedback_id" gorm:"not null;index"`
+	// ParentID is the comment starting the thread, nil for the first comment of a thread
+	ParentID *int `json:"parent_id,omitempty" gorm:"index"`
+	// AuthorID is nil once the author is deleted
+	AuthorID *int `json:"author_id"`
+	// Author is the username of the author, filled when comments are listed
+	Author string `json:"author,omitempty" gorm:"->;-:migration"`
+	Body   string `json:"body" gorm:"type:text;not null"`
+	// Mentions are the IDs of the board members mentioned in the body
+	Mentions []int     `json:"mentions" gorm:"serializer:json"`
+	Replies  []Comment `json:"replies,omitempty" gorm:"-"`
+}
+
+// TableName keeps the comments apart from other tables
+func (Comment) TableName() string {
+	return "feedback_comments"
+}
+
+type CommentJson struct {
+	Body     string `json:"body"`
+	ParentID *int   `json:"parent_id,omitempty"`
+}
+
+// Validate checks the body of a comment
+func (c CommentJson) Validate() error {
+	if strings.TrimSpace(c.Body) == "" {
+		return errors.New("body is required")
+	}
+	if len([]rune(c.Body)) > maxCommentLength {
+		return errors.New("body must be at most 5000 characters")
+	}
+	if c.ParentID != nil && *c.ParentID <= 0 {
+		return errors.New("invalid parent_id")
+	}
+	return nil
+}
+
+// MentionedUsernames returns the distinct usernames mentioned with @ in a comment body
+func MentionedUsernames(body string) []string {
+	seen := make(map[string]bool)
+	usernames := make([]string, 0)
+	for _, match := range mentionRegexp.FindAllStringSubmatch(body, -1) {
+		// A trailing dot ends the sentence rather than the username
+		username := strings.TrimRight(match[1], ".")
+		if username != "" && !seen[username] {
+			seen[username] = true
+			usernames = append(usernames, username)
+		}
+	}
+	return usernames
+}
@@ -4,6 +4,9 @@ import (
 	"time"
 
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/BaseModel"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Customer"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Tag"
+	"gorm.io/gorm"
 )
 
 type Feedback struct {
@@ -11,11 +14,56 @@ type Feedback struct {
 	Date    time.Time `json:"date" gorm:"not null"`
 	Channel string    `json:"channel" gorm:"not null"`
 	Text    string    `json:"text" gorm:"not null"`
-	BoardID int       `json:"board_id" gorm:"not null"`
+	BoardID int       `json:"board_id" gorm:"not null;uniqueIndex:idx_feedbacks_board_external_id,priority:1;uniqueIndex:idx_feedbacks_board_content_hash,priority:1"`
+	// ExternalID is the identifier of the feedback in the system it comes from
+	ExternalID *string `json:"external_id,omitempty" gorm:"uniqueIndex:idx_feedbacks_board_external_id,priority:2"`
+	// ContentHash identifies the content of the feedback within its board, see ContentHash in database/Feedback
+	ContentHash *string `json:"-" gorm:"uniqueIndex:idx_feedbacks_board_content_hash,priority:2"`
+	// CustomerID is the author of the feedback, when known
+	CustomerID *int `json:"customer_id,omitempty" gorm:"index"`
+	// Metadata holds the custom fields of the feedback, see MetadataField in models/Board
+	Metadata Metadata `json:"metadata,omitempty"`
+	// Rating is the score given along with the feedback, on a 1-5 or 0-10 RatingScale
+	Rating      *float64 `json:"rating,omitempty"`
+	RatingScale *int     `json:"rating_scale,omitempty"`
+	// Status, AssigneeID and Priority track the follow-up of the feedback, see Transition for their history
+	Status     string  `json:"status" gorm:"not null;default:new;index"`
+	AssigneeID *int    `json:"assignee_id,omitempty" gorm:"index"`
+	Priority   *string `json:"priority,omitempty"`
+	// Tags are the labels put on the feedback by the board members
+	Tags []Tag.Tag `json:"tags,omitempty" gorm:"many2many:feedback_tags;"`
+	// DeletedAt puts the feedback in the trash along with its analysis, its tags, comments and history are kept until it is purged
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+	// Customer is the author given on ingestion, resolved to CustomerID when the feedback is saved
+	Customer *Customer.CustomerJson `json:"-" gorm:"-"`
 }
 
 type FeedbackJson struct {
-	Date    time.Time `json:"date"`
-	Channel string    `json:"channel"`
-	Text    string    `json:"text"`
+	Date       time.Time `json:"date"`
+	Channel    string    `json:"channel"`
+	Text       string    `json:"text"`
+	ExternalID string    `json:"external_id,omitempty"`
+	// Customer is the author of the feedback, optional
+	Customer *Customer.CustomerJson `json:"customer,omitempty"`
+	// Metadata holds custom fields, optional
+	Metadata Metadata `json:"metadata,omitempty"`
+	// Rating is optional, RatingScale defaults to DefaultRatingScale
+	Rating      *float64 `json:"rating,omitempty"`
+	RatingScale *int     `json:"rating_scale,omitempty"`
+}
+
+// FeedbackPatch is a change of the content of a feedback, nil fields are left unchanged
+type FeedbackPatch struct {
+	Date     *time.Time `json:"date,omitempty"`
+	Channel  *string    `json:"channel,omitempty"`
+	Text     *string    `json:"text,omitempty"`
+	Metadata *Metadata  `json:"metadata,omitempty"`
+	// Rating and RatingScale are changed together, a rating without scale uses DefaultRatingScale
+	Rating      *float64 `json:"rating,omitempty"`
+	RatingScale *int     `json:"rating_scale,omitempty"`
+}
+
+// Empty tells whether the patch changes nothing
+func (p FeedbackPatch) Empty() bool {
+	return p.Date == nil && p.Channel == nil && p.Text == nil && p.Metadata == nil && p.Rating == nil && p.RatingScale == nil
 }
@@ -8,6 +8,14 @@ type FeedbackWithAnalysis struct {
 	Channel        string    `json:"channel"`
 	Text           string    `json:"text"`
 	BoardID        int       `json:"board_id"`
+	Metadata       Metadata  `json:"metadata,omitempty"`
+	Rating         *float64  `json:"rating,omitempty"`
+	RatingScale    *int      `json:"rating_scale,omitempty"`
+	Status         string    `json:"status"`
+	AssigneeID     *int      `json:"assignee_id,omitempty"`
+	Priority       *string   `json:"priority,omitempty"`
+	Tags           []string  `json:"tags,omitempty" gorm:"-"`
+	CommentCount   int       `json:"comment_count"`
 	SentimentScore float64   `json:"sentiment_score"`
 	Topic          string    `json:"topic"`
 }
@@ -0,0 +1,105 @@
+package Feedback
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Metadata holds custom fields of a feedback such as the app version or the plan tier, stored as JSONB
+type Metadata map[string]interface{}
+
+// GormDataType tells GORM which column type to use
+func (Metadata) GormDataType() string {
+	return "jsonb"
+}
+
+// Value stores empty metadata as NULL
+func (m Metadata) Value() (driver.Value, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan reads a JSONB column
+func (m *Metadata) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Metadata", value)
+	}
+	return json.Unmarshal(data, m)
+}
+
+// Text returns the value of a key as the text Postgres' ->> operator gives, false when the key is absent or null
+func (m Metadata) Text(key string) (string, bool) {
+	value, ok := m[key]
+	if !ok || value == nil {
+		return "", false
+	}
+	return MetadataText(value), true
+}
+
+// Matches reports whether every filter key has the given value
+func (m Metadata) Matches(filters map[string]string) bool {
+	for key, expected := range filters {
+		if value, ok := m.Text(key); !ok || value != expected {
+			return false
+		}
+	}
+	return true
+}
+
+// MetadataText formats a metadata value as text
+func MetadataText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+// MetadataFilterPrefix marks the query parameters filtering on metadata, metadata.plan=pro keeps the pro plan
+const MetadataFilterPrefix = "metadata."
+
+// MetadataGroupNone is the group of the feedbacks without a value for the grouping key
+const MetadataGroupNone = "(none)"
+
+// ParseMetadataFilters extracts the metadata filters from query parameters
+func ParseMetadataFilters(queries map[string]string) map[string]string {
+	filters := make(map[string]string)
+	for name, value := range queries {
+		if key, ok := strings.CutPrefix(name, MetadataFilterPrefix); ok && key != "" {
+			filters[key] = value
+		}
+	}
+	return filters
+}
+
+// Group returns the value of a key to group feedbacks on, MetadataGroupNone when it is absent
+func (m Metadata) Group(key string) string {
+	if value, ok := m.Text(key); ok {
+		return value
+	}
+	return MetadataGroupNone
+}
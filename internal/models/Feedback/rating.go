@@ -0,0 +1,40 @@
+package Feedback
+
+import (
+	"fmt"
+	"math"
+)
+
+// Rating scales accepted on feedbacks, named by their maximum
+const (
+	// RatingScaleFive is a 1 to 5 star rating as found on app stores and CSAT surveys
+	RatingScaleFive = 5
+	// RatingScaleTen is a 0 to 10 score as asked by NPS surveys
+	RatingScaleTen = 10
+)
+
+// DefaultRatingScale is used when a rating comes without its scale
+const DefaultRatingScale = RatingScaleFive
+
+// ValidateRating checks a rating against its scale and returns the scale to store, nil when there is no rating
+func ValidateRating(rating *float64, scale *int) (*int, error) {
+	if rating == nil {
+		return nil, nil
+	}
+	max := DefaultRatingScale
+	if scale != nil {
+		max = *scale
+	}
+	min := 1.0
+	switch max {
+	case RatingScaleFive:
+	case RatingScaleTen:
+		min = 0
+	default:
+		return nil, fmt.Errorf("rating scale must be %d or %d", RatingScaleFive, RatingScaleTen)
+	}
+	if math.IsNaN(*rating) || *rating < min || *rating > float64(max) {
+		return nil, fmt.Errorf("rating %v is out of the %v to %d scale", *rating, min, max)
+	}
+	return &max, nil
+}
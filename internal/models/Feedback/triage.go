@@ -0,0 +1,78 @@
+package Feedback
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Triage statuses of a feedback
+const (
+	StatusNew        = "new"
+	StatusTriaged    = "triaged"
+	StatusInProgress = "in_progress"
+	StatusResolved   = "resolved"
+	StatusIgnored    = "ignored"
+)
+
+var Statuses = []string{StatusNew, StatusTriaged, StatusInProgress, StatusResolved, StatusIgnored}
+
+// Triage priorities of a feedback, a feedback without priority has not been prioritized yet
+const (
+	PriorityLow    = "low"
+	PriorityMedium = "medium"
+	PriorityHigh   = "high"
+	PriorityUrgent = "urgent"
+)
+
+var Priorities = []string{PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent}
+
+// Triage fields recorded in the transitions
+const (
+	TriageFieldStatus   = "status"
+	TriageFieldAssignee = "assignee_id"
+	TriageFieldPriority = "priority"
+)
+
+// Transition records a change of the status, assignee or priority of a feedback
+type Transition struct {
+	Id         int     `json:"id" gorm:"primaryKey;autoIncrement"`
+	FeedbackID int     `json:"feedback_id" gorm:"not null;index"`
+	Field      string  `json:"field" gorm:"not null"`
+	From       *string `json:"from"`
+	To         *string `json:"to"`
+	// UserID is the board member who made the change, nil for an API key
+	UserID    *int      `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName keeps the transitions apart from other tables
+func (Transition) TableName() string {
+	return "feedback_transitions"
+}
+
+// TriageUpdate is a change of the triage fields of feedbacks, nil fields are left unchanged.
+// An AssigneeID of 0 unassigns the feedbacks and an empty Priority clears it.
+type TriageUpdate struct {
+	Status     *string `json:"status,omitempty"`
+	AssigneeID *int    `json:"assignee_id,omitempty"`
+	Priority   *string `json:"priority,omitempty"`
+}
+
+// Validate checks the values of the update
+func (u TriageUpdate) Validate() error {
+	if u.Status == nil && u.AssigneeID == nil && u.Priority == nil {
+		return fmt.Errorf("at least one of status, assignee_id or priority is required")
+	}
+	if u.Status != nil && !slices.Contains(Statuses, *u.Status) {
+		return fmt.Errorf("status must be one of %s", strings.Join(Statuses, ", "))
+	}
+	if u.AssigneeID != nil && *u.AssigneeID < 0 {
+		return fmt.Errorf("invalid assignee_id")
+	}
+	if u.Priority != nil && *u.Priority != "" && !slices.Contains(Priorities, *u.Priority) {
+		return fmt.Errorf("priority must be one of %s", strings.Join(Priorities, ", "))
+	}
+	return nil
+}
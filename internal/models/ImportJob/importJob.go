@@ -0,0 +1,52 @@
+package ImportJob
+
+import (
+	"time"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/BaseModel"
+)
+
+const (
+	StatusImporting = "importing"
+	StatusAnalyzing = "analyzing"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+	// StatusInterrupted is a job whose analysis was cut short by a shutdown, it is resumed at startup
+	StatusInterrupted = "interrupted"
+)
+
+// ImportJob tracks the import of an uploaded feedback file and the analysis of its rows
+type ImportJob struct {
+	BaseModel.BaseModel
+	BoardID       int        `json:"board_id" gorm:"not null;index;uniqueIndex:idx_import_jobs_board_idempotency_key,priority:1"`
+	Filename      string     `json:"filename"`
+	Format        string     `json:"format" gorm:"not null"`
+	Status        string     `json:"status" gorm:"not null"`
+	TotalRows     int        `json:"total_rows"`
+	ImportedCount int        `json:"imported_count"`
+	SkippedCount  int        `json:"skipped_count"`
+	AnalyzedCount int        `json:"analyzed_count"`
+	ErrorCount    int        `json:"error_count"`
+	Errors        []string   `json:"errors" gorm:"serializer:json"`
+	StartedAt     time.Time  `json:"started_at" gorm:"not null"`
+	FinishedAt    *time.Time `json:"finished_at"`
+	// IdempotencyKey is the Idempotency-Key header of the upload, a retry with the same key returns this job
+	IdempotencyKey *string `json:"-" gorm:"uniqueIndex:idx_import_jobs_board_idempotency_key,priority:2"`
+}
+
+// Progress returns the share of imported rows that went through analysis, from 0 to 100
+func (j ImportJob) Progress() float64 {
+	if j.Status == StatusCompleted {
+		return 100
+	}
+	if j.ImportedCount == 0 {
+		return 0
+	}
+	return float64(j.AnalyzedCount) * 100 / float64(j.ImportedCount)
+}
+
+// ImportJobProgress is an import job along with its progress percentage
+type ImportJobProgress struct {
+	ImportJob
+	Progress float64 `json:"progress"`
+}
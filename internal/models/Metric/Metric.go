@@ -1,12 +1,14 @@
 package metric
 
 // Package metric provides the structure for storing and processing metrics related to feedback analysis.
-// It includes the distribution of feedback by channel and theme, volumetry by day, average sentiment, and sentiment breakdown.
+// It includes the distribution of feedback by channel, theme and tag, volumetry by day, average sentiment, sentiment breakdown and ratings.
 type Metric struct {
 	DistributionByChannel            map[string]float64 `json:"distributionByChannel"`
 	DistributionByTopic              map[string]float64 `json:"distributionByTopic"`
+	DistributionByTag                map[string]float64 `json:"distributionByTag"`
 	VolumetryByDay                   map[string]float64 `json:"volumetryByDay"`
 	AverageSentiment                 float64            `json:"averageSentiment"`
 	Sentiment                        Sentiment          `json:"Sentiment"`
 	PercentageSentimentUnderTreshold float64            `json:"percentageSentimentUnderTreshold"`
+	Rating                           Rating             `json:"rating"`
 }
@@ -0,0 +1,24 @@
+package metric
+
+// Rating summarizes the ratings given along with the feedbacks, each score is nil when no rating allows computing it
+type Rating struct {
+	// Count is the number of rated feedbacks
+	Count int `json:"count"`
+	// Average is the mean rating brought back to a 5-point scale
+	Average *float64 `json:"average"`
+	// NPS is computed from the 0-10 ratings
+	NPS *NPS `json:"nps"`
+	// CSAT is the percentage of 1-5 ratings of 4 or more
+	CSAT *float64 `json:"csat"`
+	// SentimentCorrelation is the Pearson correlation between the ratings and the sentiment scores, from -1 to 1
+	SentimentCorrelation *float64 `json:"sentimentCorrelation"`
+}
+
+// NPS is the Net Promoter Score: promoters rate 9 or 10, passives 7 or 8 and detractors 6 or less
+type NPS struct {
+	Promoters  float64 `json:"promoters"`
+	Passives   float64 `json:"passives"`
+	Detractors float64 `json:"detractors"`
+	// Score is the percentage of promoters minus the percentage of detractors, from -100 to 100
+	Score float64 `json:"score"`
+}
@@ -0,0 +1,38 @@
+package Source
+
+import (
+	"time"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/BaseModel"
+)
+
+const (
+	// TypeJSONPlaceholder fetches comments from a JSONPlaceholder compatible API
+	TypeJSONPlaceholder = "jsonplaceholder"
+	// TypeJSON fetches an array of FeedbackJson from any URL
+	TypeJSON = "json"
+)
+
+// Source is an external feedback source attached to a board.
+// CursorDate and CursorExternalID are the high-water marks of the last sync,
+// only items past them are imported on the next run.
+type Source struct {
+	BaseModel.BaseModel
+	Name             string     `json:"name" gorm:"not null"`
+	Type             string     `json:"type" gorm:"not null"`
+	URL              string     `json:"url" gorm:"not null"`
+	Schedule         string     `json:"schedule"`
+	Enabled          bool       `json:"enabled" gorm:"not null;default:true"`
+	CursorDate       *time.Time `json:"cursor_date"`
+	CursorExternalID string     `json:"cursor_external_id"`
+	LastSyncedAt     *time.Time `json:"last_synced_at"`
+	BoardID          int        `json:"board_id" gorm:"not null;index"`
+}
+
+type SourceJson struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	URL      string `json:"url"`
+	Schedule string `json:"schedule"`
+	Enabled  *bool  `json:"enabled"`
+}
@@ -0,0 +1,29 @@
+package Source
+
+import (
+	"time"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/BaseModel"
+)
+
+const (
+	SyncStatusRunning = "running"
+	SyncStatusSuccess = "success"
+	SyncStatusPartial = "partial"
+	SyncStatusFailed  = "failed"
+)
+
+// SyncRun records one execution of a source sync
+type SyncRun struct {
+	BaseModel.BaseModel
+	SourceID      int        `json:"source_id" gorm:"not null;index"`
+	Trigger       string     `json:"trigger" gorm:"not null"`
+	Status        string     `json:"status" gorm:"not null"`
+	StartedAt     time.Time  `json:"started_at" gorm:"not null"`
+	FinishedAt    *time.Time `json:"finished_at"`
+	FetchedCount  int        `json:"fetched_count"`
+	ImportedCount int        `json:"imported_count"`
+	SkippedCount  int        `json:"skipped_count"`
+	ErrorCount    int        `json:"error_count"`
+	Errors        []string   `json:"errors" gorm:"serializer:json"`
+}
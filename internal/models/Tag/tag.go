@@ -0,0 +1,51 @@
+package Tag
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/BaseModel"
+)
+
+var colorRegexp = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Tag is a label of a board that its members put on feedbacks
+type Tag struct {
+	BaseModel.BaseModel
+	BoardID int    `json:"board_id" gorm:"not null;uniqueIndex:idx_tags_board_name,priority:1"`
+	Name    string `json:"name" gorm:"not null;uniqueIndex:idx_tags_board_name,priority:2"`
+	// Color is a #rrggbb hex color
+	Color string `json:"color" gorm:"not null"`
+}
+
+type TagJson struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// FeedbackTag is a tag put on a feedback
+type FeedbackTag struct {
+	FeedbackID int `gorm:"primaryKey"`
+	TagID      int `gorm:"primaryKey"`
+}
+
+func (FeedbackTag) TableName() string {
+	return "feedback_tags"
+}
+
+// Validate checks the name and color of a tag, partial is true for an update where empty fields are kept
+func (t TagJson) Validate(partial bool) error {
+	if t.Name == "" && !partial {
+		return errors.New("name is required")
+	}
+	if len(t.Name) > 50 {
+		return errors.New("name must be at most 50 characters")
+	}
+	if t.Color == "" && partial {
+		return nil
+	}
+	if !colorRegexp.MatchString(t.Color) {
+		return errors.New("color must be a hex color like #1a2b3c")
+	}
+	return nil
+}
@@ -14,6 +14,8 @@ type SessionManager struct {
 	mu         sync.Mutex
 	secretKey  []byte
 	expiration time.Duration
+	done       chan struct{}
+	closeOnce  sync.Once
 }
 
 func InitSessionManager(secretKey string, expiration time.Duration) {
@@ -25,6 +27,7 @@ func NewSessionManager(secretKey string, expiration time.Duration) *SessionManag
 		sessions:   make(map[string]time.Time),
 		secretKey:  []byte(secretKey),
 		expiration: expiration,
+		done:       make(chan struct{}),
 	}
 
 	// Start a goroutine to clean up expired sessions
@@ -86,9 +89,20 @@ func (sm *SessionManager) DeleteSession(tokenString string) {
 	delete(sm.sessions, tokenString)
 }
 
+// Close stops the cleanup of the expired sessions, the sessions stay valid
+func (sm *SessionManager) Close() {
+	sm.closeOnce.Do(func() { close(sm.done) })
+}
+
 func (sm *SessionManager) cleanupExpiredSessions() {
+	ticker := time.NewTicker(time.Minute) // Run cleanup every minute
+	defer ticker.Stop()
 	for {
-		time.Sleep(time.Minute) // Run cleanup every minute
+		select {
+		case <-sm.done:
+			return
+		case <-ticker.C:
+		}
 
 		sm.mu.Lock()
 		for token, expiration := range sm.sessions {
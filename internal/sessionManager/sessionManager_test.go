@@ -110,3 +110,16 @@ func TestInitSessionManager(t *testing.T) {
 		t.Error("InitSessionManager() did not initialize the global Instance")
 	}
 }
+
+func TestSessionManager_Close(t *testing.T) {
+	sm := NewSessionManager("test-secret-key", time.Minute)
+	sm.Close()
+	// Closing twice must not panic
+	sm.Close()
+
+	select {
+	case <-sm.done:
+	default:
+		t.Error("Close() did not stop the cleanup of the expired sessions")
+	}
+}
@@ -0,0 +1,90 @@
+package apiKey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// KeyPrefix starts every API key so they are recognisable in headers and secret scanners
+	KeyPrefix = "fp_"
+
+	ScopeFeedbackRead  = "feedback:read"
+	ScopeFeedbackWrite = "feedback:write"
+	ScopeMetricsRead   = "metrics:read"
+)
+
+// Scopes lists every scope an API key can be granted
+var Scopes = []string{ScopeFeedbackRead, ScopeFeedbackWrite, ScopeMetricsRead}
+
+var ErrMalformedKey = errors.New("malformed API key")
+
+// Generate returns a new key in the "fp_<prefix>_<secret>" format, its lookup prefix and its hash
+func Generate() (key, prefix, hash string, err error) {
+	prefixBytes := make([]byte, 4)
+	if _, err = rand.Read(prefixBytes); err != nil {
+		return "", "", "", err
+	}
+	secretBytes := make([]byte, 24)
+	if _, err = rand.Read(secretBytes); err != nil {
+		return "", "", "", err
+	}
+	prefix = KeyPrefix + hex.EncodeToString(prefixBytes)
+	key = prefix + "_" + hex.EncodeToString(secretBytes)
+	return key, prefix, Hash(key), nil
+}
+
+// Hash returns the hex SHA-256 of a key, keys are random enough not to need a slow hash
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// ParsePrefix extracts the lookup prefix of a key
+func ParsePrefix(key string) (string, error) {
+	if !strings.HasPrefix(key, KeyPrefix) {
+		return "", ErrMalformedKey
+	}
+	i := strings.LastIndex(key, "_")
+	if i <= len(KeyPrefix) || i == len(key)-1 {
+		return "", ErrMalformedKey
+	}
+	return key[:i], nil
+}
+
+// Matches compares a key with a stored hash in constant time
+func Matches(key, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(Hash(key)), []byte(hash)) == 1
+}
+
+// ValidateScopes checks that every scope is known and that there is at least one
+func ValidateScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return errors.New("at least one scope is required")
+	}
+	for _, scope := range scopes {
+		if !isKnownScope(scope) {
+			return fmt.Errorf("unknown scope %q", scope)
+		}
+	}
+	return nil
+}
+
+// HasScope reports whether scope is part of granted
+func HasScope(granted []string, scope string) bool {
+	for _, s := range granted {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func isKnownScope(scope string) bool {
+	return HasScope(Scopes, scope)
+}
@@ -0,0 +1,44 @@
+package apiKey
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	key, prefix, hash, err := Generate()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(key, prefix+"_"))
+	assert.True(t, strings.HasPrefix(prefix, KeyPrefix))
+	assert.Equal(t, Hash(key), hash)
+	assert.True(t, Matches(key, hash))
+
+	other, _, _, err := Generate()
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other)
+	assert.False(t, Matches(other, hash))
+}
+
+func TestParsePrefix(t *testing.T) {
+	prefix, err := ParsePrefix("fp_0a1b2c3d_secretpart")
+	assert.NoError(t, err)
+	assert.Equal(t, "fp_0a1b2c3d", prefix)
+
+	for _, key := range []string{"", "0a1b2c3d_secret", "fp_", "fp_secret", "fp_0a1b2c3d_"} {
+		_, err := ParsePrefix(key)
+		assert.ErrorIs(t, err, ErrMalformedKey, key)
+	}
+}
+
+func TestValidateScopes(t *testing.T) {
+	assert.NoError(t, ValidateScopes([]string{ScopeFeedbackWrite, ScopeMetricsRead}))
+	assert.Error(t, ValidateScopes(nil))
+	assert.Error(t, ValidateScopes([]string{ScopeFeedbackRead, "admin"}))
+}
+
+func TestHasScope(t *testing.T) {
+	assert.True(t, HasScope([]string{ScopeFeedbackWrite}, ScopeFeedbackWrite))
+	assert.False(t, HasScope([]string{ScopeFeedbackWrite}, ScopeMetricsRead))
+}
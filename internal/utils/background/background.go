@@ -0,0 +1,66 @@
+package background
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// running waits for the work started by Go which has not returned yet
+	running sync.WaitGroup
+	// count is the number of such work, for the metrics
+	count atomic.Int64
+	// stopped is done once Stop is called
+	stopped, stop = context.WithCancel(context.Background())
+)
+
+// Go runs fn in a goroutine that Drain waits for, for work outliving the request which started it
+func Go(fn func()) {
+	running.Add(1)
+	count.Add(1)
+	go func() {
+		defer running.Done()
+		defer count.Add(-1)
+		fn()
+	}()
+}
+
+// Running returns the number of work started by Go which has not returned yet
+func Running() int {
+	return int(count.Load())
+}
+
+// Drain waits for the work started by Go to return, or for ctx to be done
+func Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WithStop returns a copy of ctx which is also done once Stop is called.
+// The work started by Go uses it to stop early at shutdown, saving its state to resume it later.
+func WithStop(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	if stopped.Err() != nil {
+		cancel()
+	}
+	unregister := context.AfterFunc(stopped, cancel)
+	return ctx, func() {
+		unregister()
+		cancel()
+	}
+}
+
+// Stop interrupts the work started by Go which did not return in time, it still has to be drained
+func Stop() {
+	stop()
+}
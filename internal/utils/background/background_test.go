@@ -0,0 +1,43 @@
+package background
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrain(t *testing.T) {
+	finished := false
+	Go(func() {
+		time.Sleep(20 * time.Millisecond)
+		finished = true
+	})
+	assert.NoError(t, Drain(context.Background()))
+	assert.True(t, finished)
+
+	release := make(chan struct{})
+	Go(func() { <-release })
+	assert.Equal(t, 1, Running())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, Drain(ctx), context.DeadlineExceeded)
+	close(release)
+	assert.NoError(t, Drain(context.Background()))
+}
+
+func TestWithStop(t *testing.T) {
+	ctx, cancel := WithStop(context.Background())
+	defer cancel()
+	assert.NoError(t, ctx.Err())
+
+	Stop()
+	<-ctx.Done()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	// Once stopped, new work is interrupted right away
+	ctx, cancel = WithStop(context.Background())
+	defer cancel()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
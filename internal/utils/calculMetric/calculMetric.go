@@ -18,6 +18,7 @@ func CalculMetric(feedbacks []Feedback.Feedback) (metric.Metric, error) {
 	var m = metric.Metric{
 		DistributionByChannel: make(map[string]float64),
 		DistributionByTopic:   make(map[string]float64),
+		DistributionByTag:     make(map[string]float64),
 		VolumetryByDay:        make(map[string]float64),
 		Sentiment: metric.Sentiment{
 			Positive: 0,
@@ -41,6 +42,9 @@ func CalculMetric(feedbacks []Feedback.Feedback) (metric.Metric, error) {
 	}
 	m.DistributionByTopic = distributionByTopic
 
+	// Calculate the distribution by tag
+	m.DistributionByTag = CalculDistributionByTag(feedbacks)
+
 	// Calculate the volumetry by day
 	m.VolumetryByDay = CalculVolumetryByDay(feedbacks)
 
@@ -53,6 +57,9 @@ func CalculMetric(feedbacks []Feedback.Feedback) (metric.Metric, error) {
 	// Calculate the percentage of sentiment under threshold
 	m.PercentageSentimentUnderTreshold = CalculatePercentageSentimentUnderThreshold(feedbacks, -0.5)
 
+	// Calculate the rating scores
+	m.Rating = CalculRating(feedbacks)
+
 	return m, nil
 }
 
@@ -100,6 +107,28 @@ func CalculDistributionByTopic(feedbacks []Feedback.Feedback) (map[string]float6
 	return distributionByTheme, nil
 }
 
+// CalculDistributionByTag returns the percentage of feedbacks carrying each tag, a feedback with several tags counts for each of them
+func CalculDistributionByTag(feedbacks []Feedback.Feedback) map[string]float64 {
+	distributionByTag := make(map[string]float64)
+
+	// Iterate over feedbacks and count the feedbacks of each tag
+	for _, feedback := range feedbacks {
+		for _, tag := range feedback.Tags {
+			distributionByTag[tag.Name]++
+		}
+	}
+
+	// Calculate the total number of feedbacks
+	totalFeedbacks := float64(len(feedbacks))
+
+	// Calculate the percentage for each tag
+	for tag, count := range distributionByTag {
+		distributionByTag[tag] = roundToTwo((count / totalFeedbacks) * 100)
+	}
+
+	return distributionByTag
+}
+
 func CalculVolumetryByDay(feedbacks []Feedback.Feedback) map[string]float64 {
 	volumetryByDay := make(map[string]float64)
 
@@ -186,3 +215,105 @@ func CalculatePercentageSentimentUnderThreshold(feedbacks []Feedback.Feedback, t
 
 	return roundToTwo((float64(countUnderThreshold) / totalFeedbacks) * 100)
 }
+
+func CalculRating(feedbacks []Feedback.Feedback) metric.Rating {
+	var rating metric.Rating
+	var total float64
+	var promoters, passives, detractors, npsCount int
+	var satisfied, csatCount int
+	ratings := make([]float64, 0)
+	sentiments := make([]float64, 0)
+
+	// Iterate over the rated feedbacks
+	for _, feedback := range feedbacks {
+		if feedback.Rating == nil {
+			continue
+		}
+		scale := Feedback.DefaultRatingScale
+		if feedback.RatingScale != nil {
+			scale = *feedback.RatingScale
+		}
+		value := *feedback.Rating
+		rating.Count++
+		total += value / float64(scale) * 5
+
+		switch scale {
+		case Feedback.RatingScaleTen:
+			npsCount++
+			if value >= 9 {
+				promoters++
+			} else if value >= 7 {
+				passives++
+			} else {
+				detractors++
+			}
+		case Feedback.RatingScaleFive:
+			csatCount++
+			if value >= 4 {
+				satisfied++
+			}
+		}
+
+		// Only the analyzed feedbacks count in the correlation
+		analysis, err := Analysis.GetAnalysisByFeedbackID(feedback.Id)
+		if err != nil {
+			continue
+		}
+		ratings = append(ratings, value/float64(scale))
+		sentiments = append(sentiments, analysis.SentimentScore)
+	}
+
+	if rating.Count == 0 {
+		return rating
+	}
+	average := roundToTwo(total / float64(rating.Count))
+	rating.Average = &average
+
+	if npsCount > 0 {
+		nps := metric.NPS{
+			Promoters:  roundToTwo(float64(promoters) / float64(npsCount) * 100),
+			Passives:   roundToTwo(float64(passives) / float64(npsCount) * 100),
+			Detractors: roundToTwo(float64(detractors) / float64(npsCount) * 100),
+		}
+		nps.Score = roundToTwo(float64(promoters-detractors) / float64(npsCount) * 100)
+		rating.NPS = &nps
+	}
+
+	if csatCount > 0 {
+		csat := roundToTwo(float64(satisfied) / float64(csatCount) * 100)
+		rating.CSAT = &csat
+	}
+
+	if correlation, ok := pearson(ratings, sentiments); ok {
+		correlation = roundToTwo(correlation)
+		rating.SentimentCorrelation = &correlation
+	}
+
+	return rating
+}
+
+// pearson returns the Pearson correlation coefficient of two series, false when it is undefined
+func pearson(xs, ys []float64) (float64, bool) {
+	n := float64(len(xs))
+	if len(xs) < 2 {
+		return 0, false
+	}
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var cov, varX, varY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0, false
+	}
+	return cov / math.Sqrt(varX*varY), true
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/BaseModel"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
 	metric "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Metric"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Tag"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -126,46 +127,46 @@ func TestCalculMetric(t *testing.T) {
 		}
 
 		// Mock analysis queries for first feedback
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(1, testDate, testDate, 1, "Support Client", 0.7))
 
 		// Mock analysis queries for second feedback
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(2, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(2, testDate, testDate, 2, "Performance", -0.6))
 
 		// Mock analysis queries for average sentiment calculation
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(1, testDate, testDate, 1, "Support Client", 0.7))
 
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(2, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(2, testDate, testDate, 2, "Performance", -0.6))
 
 		// Mock analysis queries for sentiment percentage calculation
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(1, testDate, testDate, 1, "Support Client", 0.7))
 
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(2, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(2, testDate, testDate, 2, "Performance", -0.6))
 
 		// Mock analysis queries for threshold calculation
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(1, testDate, testDate, 1, "Support Client", 0.7))
 
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(2, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(2, testDate, testDate, 2, "Performance", -0.6))
@@ -218,7 +219,7 @@ func TestCalculMetric(t *testing.T) {
 		}
 
 		// Mock analysis query to return error
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnError(errors.New("database error"))
 
@@ -301,17 +302,17 @@ func TestCalculAverageSentiment(t *testing.T) {
 		}
 
 		// Mock analysis queries
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(1, testDate, testDate, 1, "Topic1", 0.5))
 
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(2, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(2, testDate, testDate, 2, "Topic2", -0.3))
 
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(3, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(3, testDate, testDate, 3, "Topic3", 0.8))
@@ -333,7 +334,7 @@ func TestCalculAverageSentiment(t *testing.T) {
 		}
 
 		// Mock analysis query to return error
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnError(errors.New("database error"))
 
@@ -359,22 +360,22 @@ func TestCalculateSentimentPercentage(t *testing.T) {
 		}
 
 		// Mock analysis queries
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(1, testDate, testDate, 1, "Topic1", 0.8))
 
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(2, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(2, testDate, testDate, 2, "Topic2", -0.7))
 
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(3, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(3, testDate, testDate, 3, "Topic3", 0.1))
 
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(4, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(4, testDate, testDate, 4, "Topic4", 0.3))
@@ -402,12 +403,12 @@ func TestCalculateSentimentPercentage(t *testing.T) {
 		}
 
 		// Mock first query to return error
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnError(errors.New("database error"))
 
 		// Mock second query to succeed
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(2, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(2, testDate, testDate, 2, "Topic2", 0.8))
@@ -440,22 +441,22 @@ func TestCalculatePercentageSentimentUnderThreshold(t *testing.T) {
 		}
 
 		// Mock analysis queries
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(1, testDate, testDate, 1, "Topic1", -0.7))
 
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(2, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(2, testDate, testDate, 2, "Topic2", 0.3))
 
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(3, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(3, testDate, testDate, 3, "Topic3", -0.6))
 
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(4, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(4, testDate, testDate, 4, "Topic4", -0.4))
@@ -479,12 +480,12 @@ func TestCalculatePercentageSentimentUnderThreshold(t *testing.T) {
 		}
 
 		// Mock analysis queries
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(1, testDate, testDate, 1, "Topic1", 0.5))
 
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(2, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(2, testDate, testDate, 2, "Topic2", -0.2))
@@ -508,12 +509,12 @@ func TestCalculatePercentageSentimentUnderThreshold(t *testing.T) {
 		}
 
 		// Mock first query to return error
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnError(errors.New("database error"))
 
 		// Mock second query to succeed
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(2, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(2, testDate, testDate, 2, "Topic2", -0.7))
@@ -541,17 +542,17 @@ func TestCalculDistributionByTopic(t *testing.T) {
 		}
 
 		// Mock analysis queries
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(1, testDate, testDate, 1, "Support Client", 0.5))
 
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(2, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(2, testDate, testDate, 2, "Support Client", -0.3))
 
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(3, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
 				AddRow(3, testDate, testDate, 3, "Performance", 0.8))
@@ -578,7 +579,7 @@ func TestCalculDistributionByTopic(t *testing.T) {
 		}
 
 		// Mock analysis query to return error
-		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 ORDER BY "analyses"."id" LIMIT \$2`).
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
 			WithArgs(1, 1).
 			WillReturnError(errors.New("database error"))
 
@@ -600,3 +601,60 @@ func TestCalculDistributionByTopic(t *testing.T) {
 		assert.Empty(t, got)
 	})
 }
+
+func TestCalculRating(t *testing.T) {
+	t.Run("No rating", func(t *testing.T) {
+		got := CalculRating([]Feedback.Feedback{{BaseModel: BaseModel.BaseModel{Id: 1}}})
+		assert.Equal(t, metric.Rating{}, got)
+	})
+
+	t.Run("Success case", func(t *testing.T) {
+		mock := setupTestCalculMetric(t)
+		testDate := time.Now()
+		rating := func(value float64) *float64 { return &value }
+		ten := Feedback.RatingScaleTen
+
+		feedbacks := []Feedback.Feedback{
+			{BaseModel: BaseModel.BaseModel{Id: 1}, Rating: rating(10), RatingScale: &ten},
+			{BaseModel: BaseModel.BaseModel{Id: 2}, Rating: rating(7), RatingScale: &ten},
+			{BaseModel: BaseModel.BaseModel{Id: 3}, Rating: rating(2), RatingScale: &ten},
+			{BaseModel: BaseModel.BaseModel{Id: 4}, Rating: rating(5)},
+			{BaseModel: BaseModel.BaseModel{Id: 5}},
+		}
+
+		// Analyses of the rated feedbacks, the last one is not analyzed yet
+		for id, score := range []float64{0.9, 0.2, -0.8} {
+			mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
+				WithArgs(id+1, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "feedback_id", "topic", "sentiment_score"}).
+					AddRow(id+1, testDate, testDate, id+1, "Topic", score))
+		}
+		mock.ExpectQuery(`SELECT \* FROM "analyses" WHERE feedback_id = \$1 AND "analyses"."deleted_at" IS NULL ORDER BY "analyses"."id" LIMIT \$2`).
+			WithArgs(4, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		got := CalculRating(feedbacks)
+		assert.Equal(t, 4, got.Count)
+		assert.Equal(t, 3.63, *got.Average) // (5 + 3.5 + 1 + 5) / 4 = 3.625
+		assert.Equal(t, metric.NPS{Promoters: 33.33, Passives: 33.33, Detractors: 33.33, Score: 0}, *got.NPS)
+		assert.Equal(t, 100.0, *got.CSAT)
+		assert.Equal(t, 1.0, *got.SentimentCorrelation)
+
+		// Verify that all mock expectations were met
+		err := mock.ExpectationsWereMet()
+		assert.NoError(t, err)
+	})
+}
+
+func TestCalculDistributionByTag(t *testing.T) {
+	bug := Tag.Tag{Name: "bug"}
+	urgent := Tag.Tag{Name: "urgent"}
+
+	feedbacks := []Feedback.Feedback{
+		{Tags: []Tag.Tag{bug, urgent}},
+		{Tags: []Tag.Tag{bug}},
+		{},
+	}
+	assert.Equal(t, map[string]float64{"bug": 66.67, "urgent": 33.33}, CalculDistributionByTag(feedbacks))
+	assert.Equal(t, map[string]float64{}, CalculDistributionByTag([]Feedback.Feedback{{}}))
+}
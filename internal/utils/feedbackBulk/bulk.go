@@ -0,0 +1,230 @@
+package feedbackBulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	boardDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
+	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	tagDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Tag"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+)
+
+const (
+	ActionDelete    = "delete"
+	ActionReanalyze = "reanalyze"
+	ActionSetStatus = "set_status"
+	ActionAddTag    = "add_tag"
+	ActionRemoveTag = "remove_tag"
+	ActionMove      = "move"
+
+	// ChunkSize is the number of feedbacks processed per transaction
+	ChunkSize = 200
+	// MaxFeedbacks caps the feedbacks a single request may target
+	MaxFeedbacks = 10000
+	// MaxReanalyzed caps the feedbacks a single request may analyze again, each one calls the sentiment API
+	MaxReanalyzed = 500
+)
+
+// Actions lists the supported bulk actions
+var Actions = []string{ActionDelete, ActionReanalyze, ActionSetStatus, ActionAddTag, ActionRemoveTag, ActionMove}
+
+var ErrTooManyFeedbacks = fmt.Errorf("a bulk action may target at most %d feedbacks", MaxFeedbacks)
+var ErrTooManyReanalyzed = fmt.Errorf("a bulk reanalyze may target at most %d feedbacks", MaxReanalyzed)
+var ErrSameBoard = errors.New("the feedbacks are already on the target board")
+var ErrTargetBoardForbidden = errors.New("feedbacks can only be moved to a board you are a member of")
+
+// Request is a bulk action on feedbacks of a board.
+// The feedbacks are either listed by IDs or selected with the filters of the listing.
+type Request struct {
+	IDs    []int  `json:"ids,omitempty"`
+	Action string `json:"action"`
+	// Status is the triage status set by set_status
+	Status string `json:"status,omitempty"`
+	// TagID is the tag of the board added or removed by add_tag and remove_tag
+	TagID int `json:"tag_id,omitempty"`
+	// TargetBoardID is the board the feedbacks go to with move
+	TargetBoardID int `json:"target_board_id,omitempty"`
+}
+
+// Validate checks the action and its parameters
+func (r Request) Validate() error {
+	if len(r.IDs) > MaxFeedbacks {
+		return ErrTooManyFeedbacks
+	}
+	for _, id := range r.IDs {
+		if id <= 0 {
+			return errors.New("ids must be positive")
+		}
+	}
+	switch r.Action {
+	case ActionDelete, ActionReanalyze:
+	case ActionSetStatus:
+		if !slices.Contains(feedbackModel.Statuses, r.Status) {
+			return fmt.Errorf("status must be one of %s", strings.Join(feedbackModel.Statuses, ", "))
+		}
+	case ActionAddTag, ActionRemoveTag:
+		if r.TagID <= 0 {
+			return errors.New("tag_id is required")
+		}
+	case ActionMove:
+		if r.TargetBoardID <= 0 {
+			return errors.New("target_board_id is required")
+		}
+	default:
+		return fmt.Errorf("action must be one of %s", strings.Join(Actions, ", "))
+	}
+	return nil
+}
+
+// Summary reports the outcome of a bulk action
+type Summary struct {
+	Action string `json:"action"`
+	// Matched is the number of feedbacks of the board targeted by the request
+	Matched int `json:"matched"`
+	// Processed is the number of feedbacks the action was applied to
+	Processed int `json:"processed"`
+	// Skipped is the number of moved feedbacks already stored on the target board
+	Skipped int `json:"skipped"`
+	// Failed is the number of feedbacks of the chunks that were rolled back
+	Failed int `json:"failed"`
+	Chunks int `json:"chunks"`
+	// NotFound lists the requested IDs that are not feedbacks of the board
+	NotFound []int    `json:"not_found,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// Actor is who runs the bulk action
+type Actor struct {
+	// UserID is the board member, nil for an API key
+	UserID *int
+	Email  string
+}
+
+// Run applies the action to the feedbacks of the board in chunks of ChunkSize, every chunk in its own transaction.
+// A failed chunk is rolled back and reported in the summary while the next ones go on.
+// An error is returned when the targeted feedbacks cannot be resolved.
+func Run(ctx context.Context, boardID int, request Request, filter feedbackDB.Filter, actor Actor) (Summary, error) {
+	summary := Summary{Action: request.Action}
+	if err := checkTarget(boardID, request, actor); err != nil {
+		return summary, err
+	}
+
+	var ids []int
+	var err error
+	if len(request.IDs) > 0 {
+		ids, err = feedbackDB.FindBoardFeedbackIDs(boardID, request.IDs)
+		if err != nil {
+			return summary, err
+		}
+		summary.NotFound = missing(request.IDs, ids)
+	} else {
+		ids, err = feedbackDB.FindFeedbackIDs(boardID, filter)
+		if err != nil {
+			return summary, err
+		}
+	}
+	summary.Matched = len(ids)
+	if len(ids) > MaxFeedbacks {
+		return summary, ErrTooManyFeedbacks
+	}
+	if request.Action == ActionReanalyze && len(ids) > MaxReanalyzed {
+		return summary, ErrTooManyReanalyzed
+	}
+
+	for start := 0; start < len(ids); start += ChunkSize {
+		chunk := ids[start:min(start+ChunkSize, len(ids))]
+		summary.Chunks++
+		processed, skipped, err := runChunk(ctx, boardID, request, chunk, actor)
+		if err != nil {
+			summary.Failed += len(chunk)
+			summary.Errors = append(summary.Errors, fmt.Sprintf("feedbacks %d to %d: %v", chunk[0], chunk[len(chunk)-1], err))
+			continue
+		}
+		summary.Processed += processed
+		summary.Skipped += skipped
+	}
+	return summary, nil
+}
+
+// checkTarget ensures the tag or the board the action refers to can be used, before any chunk runs
+func checkTarget(boardID int, request Request, actor Actor) error {
+	switch request.Action {
+	case ActionAddTag, ActionRemoveTag:
+		tags, err := tagDB.GetTagsByBoardID(boardID)
+		if err != nil {
+			return err
+		}
+		for _, tag := range tags {
+			if tag.Id == request.TagID {
+				return nil
+			}
+		}
+		return tagDB.ErrTagNotFound
+	case ActionMove:
+		if request.TargetBoardID == boardID {
+			return ErrSameBoard
+		}
+		// API keys are bound to a single board
+		if actor.UserID == nil {
+			return ErrTargetBoardForbidden
+		}
+		boards, err := boardDB.GetBoardsByUserID(*actor.UserID)
+		if err != nil {
+			return err
+		}
+		for _, board := range boards {
+			if board.Id == request.TargetBoardID {
+				return nil
+			}
+		}
+		return ErrTargetBoardForbidden
+	}
+	return nil
+}
+
+// runChunk applies the action to a chunk of feedback IDs of the board
+func runChunk(ctx context.Context, boardID int, request Request, ids []int, actor Actor) (processed int, skipped int, err error) {
+	switch request.Action {
+	case ActionDelete:
+		processed, err = feedbackDB.DeleteFeedbacks(boardID, ids)
+	case ActionReanalyze:
+		processed, err = feedbackDB.ReanalyzeFeedbacks(ctx, boardID, ids, actor.Email)
+	case ActionSetStatus:
+		_, err = feedbackDB.TriageFeedbacks(boardID, ids, feedbackModel.TriageUpdate{Status: &request.Status}, actor.UserID)
+		processed = len(ids)
+	case ActionAddTag:
+		err = tagDB.ApplyTags(boardID, ids, []int{request.TagID})
+		processed = len(ids)
+	case ActionRemoveTag:
+		err = tagDB.RemoveTags(boardID, ids, []int{request.TagID})
+		processed = len(ids)
+	case ActionMove:
+		var moved, notMoved []int
+		moved, notMoved, err = feedbackDB.MoveFeedbacks(boardID, request.TargetBoardID, ids)
+		processed, skipped = len(moved), len(notMoved)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	return processed, skipped, nil
+}
+
+// missing returns the requested IDs absent from found, in request order without duplicates
+func missing(requested, found []int) []int {
+	known := make(map[int]bool, len(found))
+	for _, id := range found {
+		known[id] = true
+	}
+	var absent []int
+	for _, id := range requested {
+		if !known[id] {
+			known[id] = true
+			absent = append(absent, id)
+		}
+	}
+	return absent
+}
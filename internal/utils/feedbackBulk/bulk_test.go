@@ -0,0 +1,22 @@
+package feedbackBulk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestValidate(t *testing.T) {
+	assert.NoError(t, Request{Action: ActionDelete, IDs: []int{1}}.Validate())
+	assert.NoError(t, Request{Action: ActionSetStatus, Status: "resolved"}.Validate())
+	assert.Error(t, Request{Action: ActionSetStatus, Status: "done"}.Validate())
+	assert.Error(t, Request{Action: ActionAddTag}.Validate())
+	assert.Error(t, Request{Action: ActionMove}.Validate())
+	assert.Error(t, Request{Action: "archive"}.Validate())
+	assert.Error(t, Request{Action: ActionDelete, IDs: []int{0}}.Validate())
+}
+
+func TestMissing(t *testing.T) {
+	assert.Equal(t, []int{9, 4}, missing([]int{3, 9, 9, 4}, []int{3}))
+	assert.Nil(t, missing([]int{3}, []int{3}))
+}
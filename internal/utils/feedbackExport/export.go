@@ -0,0 +1,105 @@
+package feedbackExport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/feedbackImport"
+)
+
+// Formats lists the supported export formats, the same ones as the import
+var Formats = []string{feedbackImport.FormatCSV, feedbackImport.FormatJSON, feedbackImport.FormatNDJSON}
+
+var csvHeader = []string{
+	"feedback_id", "date", "channel", "text", "board_id", "status", "priority", "assignee_id",
+	"rating", "rating_scale", "sentiment_score", "topic", "tags", "comment_count", "metadata",
+}
+
+// Write writes the feedbacks along with their analysis in the given format
+func Write(w io.Writer, format string, feedbacks []feedbackModel.FeedbackWithAnalysis) error {
+	switch format {
+	case feedbackImport.FormatCSV:
+		return writeCsv(w, feedbacks)
+	case feedbackImport.FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(feedbacks)
+	case feedbackImport.FormatNDJSON:
+		encoder := json.NewEncoder(w)
+		for _, feedback := range feedbacks {
+			if err := encoder.Encode(feedback); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported export format %q, expected one of %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+func writeCsv(w io.Writer, feedbacks []feedbackModel.FeedbackWithAnalysis) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, feedback := range feedbacks {
+		metadata := ""
+		if len(feedback.Metadata) > 0 {
+			data, err := json.Marshal(feedback.Metadata)
+			if err != nil {
+				return fmt.Errorf("feedback %d: %w", feedback.FeedbackID, err)
+			}
+			metadata = string(data)
+		}
+
+		record := []string{
+			strconv.Itoa(feedback.FeedbackID),
+			feedback.Date.UTC().Format(time.RFC3339),
+			feedback.Channel,
+			feedback.Text,
+			strconv.Itoa(feedback.BoardID),
+			feedback.Status,
+			optionalString(feedback.Priority),
+			optionalInt(feedback.AssigneeID),
+			optionalFloat(feedback.Rating),
+			optionalInt(feedback.RatingScale),
+			strconv.FormatFloat(feedback.SentimentScore, 'f', -1, 64),
+			feedback.Topic,
+			strings.Join(feedback.Tags, ";"),
+			strconv.Itoa(feedback.CommentCount),
+			metadata,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func optionalString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func optionalInt(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
+
+func optionalFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
@@ -0,0 +1,79 @@
+package feedbackExport
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+)
+
+func testFeedbacks() []feedbackModel.FeedbackWithAnalysis {
+	rating := 4.5
+	scale := 5
+	priority := "high"
+	return []feedbackModel.FeedbackWithAnalysis{
+		{
+			FeedbackID:     1,
+			Date:           time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+			Channel:        "email",
+			Text:           "Great, but slow",
+			BoardID:        2,
+			Status:         "new",
+			Priority:       &priority,
+			Rating:         &rating,
+			RatingScale:    &scale,
+			SentimentScore: 0.25,
+			Topic:          "Performance",
+			Tags:           []string{"speed", "ui"},
+			CommentCount:   3,
+			Metadata:       feedbackModel.Metadata{"plan": "pro"},
+		},
+		{
+			FeedbackID: 2,
+			Date:       time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC),
+			Channel:    "twitter",
+			Text:       "Nice",
+			BoardID:    2,
+			Status:     "closed",
+		},
+	}
+}
+
+func TestWrite_Csv(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, "csv", testFeedbacks())
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, strings.Join(csvHeader, ","), lines[0])
+	assert.Equal(t, `1,2024-05-01T10:00:00Z,email,"Great, but slow",2,new,high,,4.5,5,0.25,Performance,speed;ui,3,"{""plan"":""pro""}"`, lines[1])
+	assert.Equal(t, `2,2024-05-02T10:00:00Z,twitter,Nice,2,closed,,,,,0,,,0,`, lines[2])
+}
+
+func TestWrite_Ndjson(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, "ndjson", testFeedbacks())
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"feedback_id":1`)
+	assert.Contains(t, lines[1], `"channel":"twitter"`)
+}
+
+func TestWrite_Json(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, "json", testFeedbacks())
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(buf.String(), "["))
+	assert.Contains(t, buf.String(), `"topic": "Performance"`)
+}
+
+func TestWrite_UnsupportedFormat(t *testing.T) {
+	err := Write(&bytes.Buffer{}, "xml", testFeedbacks())
+	assert.ErrorContains(t, err, `unsupported export format "xml"`)
+}
@@ -0,0 +1,368 @@
+package feedbackGenerator
+
+const (
+	LanguageFrench  = "fr"
+	LanguageEnglish = "en"
+)
+
+const (
+	SentimentPositive = "positive"
+	SentimentNeutral  = "neutral"
+	SentimentNegative = "negative"
+)
+
+// Topics are the topics assigned by the sentiment analysis, see sentimentAnalysis
+var Topics = []string{
+	"Support Client",
+	"Tarifs et Valeur",
+	"Interface Utilisateur",
+	"Bugs et Problèmes Techniques",
+	"Documentation",
+	"Performance",
+	"Personnalisation",
+	"Processus d’Inscription",
+	"Fonctionnalités Avancées",
+	"Expérience Utilisateur Générale",
+}
+
+// Channels are the channels feedbacks are generated on by default
+var Channels = []string{"twitter", "facebook", "instagram", "web"}
+
+// Languages are the languages of the generated texts
+var Languages = []string{LanguageFrench, LanguageEnglish}
+
+// phrases holds the sentences of every topic, by language then sentiment
+var phrases = map[string]map[string]map[string][]string{
+	"Support Client": {
+		LanguageFrench: {
+			SentimentPositive: {
+				"Le support client a été très réactif et a résolu mon problème en moins d'une heure.",
+				"Merci à l'équipe du support, toujours aimable et de bon conseil.",
+			},
+			SentimentNeutral: {
+				"J'ai contacté le support par chat, la réponse est arrivée le lendemain.",
+				"Le support m'a redirigé vers la FAQ pour ma question sur la facturation.",
+			},
+			SentimentNegative: {
+				"Trois jours sans réponse du support, c'est inacceptable pour un abonnement payant.",
+				"Le support m'a donné trois réponses différentes pour le même problème.",
+			},
+		},
+		LanguageEnglish: {
+			SentimentPositive: {
+				"Customer support was quick and fixed my issue within the hour.",
+				"Huge thanks to the support team, friendly and genuinely helpful.",
+			},
+			SentimentNeutral: {
+				"I reached support through the chat and got an answer the next day.",
+				"Support pointed me to the FAQ for my billing question.",
+			},
+			SentimentNegative: {
+				"Three days without any answer from support, unacceptable for a paid plan.",
+				"Support gave me three different answers for the same problem.",
+			},
+		},
+	},
+	"Tarifs et Valeur": {
+		LanguageFrench: {
+			SentimentPositive: {
+				"Le rapport qualité-prix est excellent, l'offre gratuite suffit déjà pour une petite équipe.",
+				"Pour ce prix, l'outil nous fait gagner des heures chaque semaine.",
+			},
+			SentimentNeutral: {
+				"Les tarifs sont dans la moyenne des outils du marché.",
+				"J'aimerais un plan intermédiaire entre l'offre Starter et l'offre Pro.",
+			},
+			SentimentNegative: {
+				"Je trouve les tarifs beaucoup trop élevés pour les fonctionnalités proposées.",
+				"Le prix a augmenté de 30 % sans aucune nouveauté, je vais résilier.",
+			},
+		},
+		LanguageEnglish: {
+			SentimentPositive: {
+				"Great value for money, the free tier is enough for a small team.",
+				"For this price the tool saves us hours every week.",
+			},
+			SentimentNeutral: {
+				"Pricing is in line with similar tools on the market.",
+				"I would like a plan between Starter and Pro.",
+			},
+			SentimentNegative: {
+				"Way too expensive for the features you actually get.",
+				"The price went up 30% with nothing new, I am cancelling.",
+			},
+		},
+	},
+	"Interface Utilisateur": {
+		LanguageFrench: {
+			SentimentPositive: {
+				"La nouvelle interface est claire et très agréable à utiliser.",
+				"J'adore le mode sombre et la disposition du tableau de bord.",
+			},
+			SentimentNeutral: {
+				"Le menu a changé de place depuis la dernière mise à jour.",
+				"L'interface est correcte mais les icônes sont un peu petites.",
+			},
+			SentimentNegative: {
+				"Impossible de trouver le bouton d'export, l'interface est confuse.",
+				"Les textes se chevauchent sur mobile, l'écran est illisible.",
+			},
+		},
+		LanguageEnglish: {
+			SentimentPositive: {
+				"The new interface is clean and a pleasure to use.",
+				"Love the dark mode and the dashboard layout.",
+			},
+			SentimentNeutral: {
+				"The menu moved since the last update.",
+				"The interface is fine but the icons are a bit small.",
+			},
+			SentimentNegative: {
+				"I cannot find the export button, the interface is confusing.",
+				"Labels overlap on mobile, the screen is unreadable.",
+			},
+		},
+	},
+	"Bugs et Problèmes Techniques": {
+		LanguageFrench: {
+			SentimentPositive: {
+				"Le bug de synchronisation que j'avais signalé a été corrigé en deux jours, bravo.",
+				"Depuis le correctif, plus aucun plantage au démarrage.",
+			},
+			SentimentNeutral: {
+				"J'ai parfois un message d'erreur à la connexion, mais ça passe au deuxième essai.",
+				"Un petit décalage d'affichage apparaît après la mise à jour.",
+			},
+			SentimentNegative: {
+				"L'application plante à chaque fois que j'ouvre un fichier joint.",
+				"J'ai perdu toutes mes données après la dernière mise à jour.",
+			},
+		},
+		LanguageEnglish: {
+			SentimentPositive: {
+				"The sync bug I reported was fixed in two days, well done.",
+				"No more crashes at startup since the fix.",
+			},
+			SentimentNeutral: {
+				"I sometimes get an error when logging in, the second attempt works.",
+				"There is a small layout glitch after the update.",
+			},
+			SentimentNegative: {
+				"The app crashes every time I open an attachment.",
+				"I lost all my data after the latest update.",
+			},
+		},
+	},
+	"Documentation": {
+		LanguageFrench: {
+			SentimentPositive: {
+				"La documentation de l'API est complète, avec des exemples qui fonctionnent.",
+				"Les tutoriels vidéo m'ont permis de démarrer en dix minutes.",
+			},
+			SentimentNeutral: {
+				"La documentation existe mais elle n'est disponible qu'en anglais.",
+				"Il faudrait une section sur les limites de l'API.",
+			},
+			SentimentNegative: {
+				"La documentation est obsolète, la moitié des exemples ne marchent plus.",
+				"Aucune explication sur les webhooks, j'ai dû tout deviner.",
+			},
+		},
+		LanguageEnglish: {
+			SentimentPositive: {
+				"The API docs are thorough and the examples actually work.",
+				"The video tutorials got me started in ten minutes.",
+			},
+			SentimentNeutral: {
+				"The docs exist but only cover the basic setup.",
+				"A section about the API limits would help.",
+			},
+			SentimentNegative: {
+				"The documentation is outdated, half of the examples are broken.",
+				"Nothing explains the webhooks, I had to guess everything.",
+			},
+		},
+	},
+	"Performance": {
+		LanguageFrench: {
+			SentimentPositive: {
+				"L'application est devenue beaucoup plus rapide, les pages s'affichent instantanément.",
+				"Même avec des milliers de lignes, le tableau reste fluide.",
+			},
+			SentimentNeutral: {
+				"Le chargement prend quelques secondes le matin, puis ça va.",
+				"Les exports volumineux sont un peu longs mais finissent toujours.",
+			},
+			SentimentNegative: {
+				"Le tableau de bord met plus de trente secondes à charger.",
+				"L'application est tellement lente qu'elle en devient inutilisable.",
+			},
+		},
+		LanguageEnglish: {
+			SentimentPositive: {
+				"The app got much faster, pages load instantly.",
+				"Even with thousands of rows the table stays smooth.",
+			},
+			SentimentNeutral: {
+				"Loading takes a few seconds in the morning, then it is fine.",
+				"Large exports are slow but they always complete.",
+			},
+			SentimentNegative: {
+				"The dashboard takes more than thirty seconds to load.",
+				"The app is so slow it is unusable.",
+			},
+		},
+	},
+	"Personnalisation": {
+		LanguageFrench: {
+			SentimentPositive: {
+				"Pouvoir créer mes propres champs et filtres change tout.",
+				"Les thèmes personnalisés permettent de garder nos couleurs, super.",
+			},
+			SentimentNeutral: {
+				"On peut personnaliser les colonnes mais pas leur ordre.",
+				"J'aimerais pouvoir enregistrer plusieurs vues du tableau de bord.",
+			},
+			SentimentNegative: {
+				"Impossible de modifier les notifications, je reçois des mails pour tout.",
+				"Aucune option pour adapter les rapports à notre activité.",
+			},
+		},
+		LanguageEnglish: {
+			SentimentPositive: {
+				"Being able to create my own fields and filters changes everything.",
+				"Custom themes let us keep our brand colors, great.",
+			},
+			SentimentNeutral: {
+				"You can pick the columns but not their order.",
+				"I would like to save several dashboard views.",
+			},
+			SentimentNegative: {
+				"There is no way to change notifications, I get emails for everything.",
+				"No option to tailor the reports to our business.",
+			},
+		},
+	},
+	"Processus d’Inscription": {
+		LanguageFrench: {
+			SentimentPositive: {
+				"Inscription ultra simple, j'étais opérationnel en deux minutes.",
+				"L'assistant de démarrage explique bien chaque étape.",
+			},
+			SentimentNeutral: {
+				"L'inscription demande beaucoup d'informations dès le départ.",
+				"Le mail de confirmation est arrivé dans mes spams.",
+			},
+			SentimentNegative: {
+				"Je n'ai jamais reçu le mail de confirmation, impossible de créer mon compte.",
+				"Le formulaire d'inscription refuse mon adresse email sans explication.",
+			},
+		},
+		LanguageEnglish: {
+			SentimentPositive: {
+				"Sign up was dead simple, I was up and running in two minutes.",
+				"The onboarding wizard explains every step well.",
+			},
+			SentimentNeutral: {
+				"Sign up asks for a lot of information upfront.",
+				"The confirmation email landed in my spam folder.",
+			},
+			SentimentNegative: {
+				"I never received the confirmation email, I cannot create my account.",
+				"The sign up form rejects my email address without any explanation.",
+			},
+		},
+	},
+	"Fonctionnalités Avancées": {
+		LanguageFrench: {
+			SentimentPositive: {
+				"L'analyse automatique des sentiments est bluffante de précision.",
+				"Les règles d'automatisation nous font gagner un temps fou.",
+			},
+			SentimentNeutral: {
+				"Les intégrations existent mais demandent un peu de configuration.",
+				"L'API couvre la plupart des besoins, il manque juste la recherche.",
+			},
+			SentimentNegative: {
+				"Les fonctionnalités avancées sont réservées à l'offre la plus chère.",
+				"L'import automatique échoue dès que le fichier dépasse quelques mégas.",
+			},
+		},
+		LanguageEnglish: {
+			SentimentPositive: {
+				"The automatic sentiment analysis is impressively accurate.",
+				"Automation rules save us a huge amount of time.",
+			},
+			SentimentNeutral: {
+				"Integrations exist but need some configuration.",
+				"The API covers most needs, only search is missing.",
+			},
+			SentimentNegative: {
+				"Advanced features are locked behind the most expensive plan.",
+				"Automatic import fails as soon as the file is a few megabytes.",
+			},
+		},
+	},
+	"Expérience Utilisateur Générale": {
+		LanguageFrench: {
+			SentimentPositive: {
+				"Franchement, c'est l'outil le plus agréable que j'utilise au quotidien.",
+				"Toute l'équipe l'a adopté sans formation, c'est dire.",
+			},
+			SentimentNeutral: {
+				"Dans l'ensemble ça fait le travail, sans plus.",
+				"Je l'utilise depuis un mois, je n'ai pas encore d'avis tranché.",
+			},
+			SentimentNegative: {
+				"Expérience globalement décevante, je retourne à mon ancien outil.",
+				"Trop de clics pour faire la moindre chose, c'est frustrant.",
+			},
+		},
+		LanguageEnglish: {
+			SentimentPositive: {
+				"Honestly the most pleasant tool I use every day.",
+				"The whole team adopted it without any training.",
+			},
+			SentimentNeutral: {
+				"Overall it does the job, nothing more.",
+				"Been using it for a month, no strong opinion yet.",
+			},
+			SentimentNegative: {
+				"Disappointing overall, going back to my previous tool.",
+				"Far too many clicks to do anything, it is frustrating.",
+			},
+		},
+	},
+}
+
+// closings are appended to some texts, by language then sentiment
+var closings = map[string]map[string][]string{
+	LanguageFrench: {
+		SentimentPositive: {"Continuez comme ça !", "Je recommande.", "Merci !"},
+		SentimentNeutral:  {"À voir sur la durée.", "Rien de bloquant.", ""},
+		SentimentNegative: {"Très déçu.", "Merci de corriger rapidement.", "Je ne recommande pas."},
+	},
+	LanguageEnglish: {
+		SentimentPositive: {"Keep it up!", "Highly recommend.", "Thanks!"},
+		SentimentNeutral:  {"We will see over time.", "Nothing blocking.", ""},
+		SentimentNegative: {"Very disappointed.", "Please fix this soon.", "Would not recommend."},
+	},
+}
+
+// names are the customers giving the feedbacks, by language
+var names = map[string][]string{
+	LanguageFrench: {
+		"Camille Martin", "Lucas Bernard", "Léa Dubois", "Hugo Thomas", "Chloé Robert",
+		"Nathan Petit", "Manon Richard", "Louis Durand", "Inès Leroy", "Jules Moreau",
+	},
+	LanguageEnglish: {
+		"Emily Johnson", "James Smith", "Olivia Brown", "Noah Williams", "Ava Jones",
+		"Liam Miller", "Sophia Davis", "Ethan Wilson", "Mia Taylor", "Mason Anderson",
+	},
+}
+
+// emailDomains are the domains of the customer emails, by language
+var emailDomains = map[string]string{
+	LanguageFrench:  "example.fr",
+	LanguageEnglish: "example.com",
+}
@@ -0,0 +1,250 @@
+package feedbackGenerator
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	customerModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Customer"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+)
+
+const (
+	DefaultCount = 50
+	MaxCount     = 10000
+	DefaultDays  = 30
+	// ratingShare is the share of the feedbacks given along with a rating
+	ratingShare = 0.6
+)
+
+// SentimentMix weighs the sentiments of the generated feedbacks, the weights don't need to sum to 100
+type SentimentMix struct {
+	Positive float64 `json:"positive"`
+	Neutral  float64 `json:"neutral"`
+	Negative float64 `json:"negative"`
+}
+
+// DefaultMix is the sentiment mix used when none is given
+var DefaultMix = SentimentMix{Positive: 50, Neutral: 25, Negative: 25}
+
+// Options tune the generated feedbacks. The same options, seed and end included, always generate the same feedbacks.
+type Options struct {
+	Count     int
+	Seed      int64
+	Languages []string
+	Channels  []string
+	Topics    []string
+	Mix       SentimentMix
+	// Days is the number of days before End the feedback dates are spread over
+	Days int
+	End  time.Time
+}
+
+// ParseOptions reads the options from named string values, such as query parameters or command line flags.
+// Missing values get their defaults, the seed is random and End is the start of the current day (UTC).
+func ParseOptions(get func(key string) string) (Options, error) {
+	opts := Options{
+		Count:     DefaultCount,
+		Seed:      time.Now().UnixNano(),
+		Languages: Languages,
+		Channels:  Channels,
+		Topics:    Topics,
+		Mix:       DefaultMix,
+		Days:      DefaultDays,
+		End:       time.Now().UTC().Truncate(24 * time.Hour),
+	}
+
+	var err error
+	if value := get("count"); value != "" {
+		if opts.Count, err = strconv.Atoi(value); err != nil {
+			return opts, fmt.Errorf("count must be a number")
+		}
+	}
+	if value := get("seed"); value != "" {
+		if opts.Seed, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return opts, fmt.Errorf("seed must be a number")
+		}
+	}
+	if value := get("days"); value != "" {
+		if opts.Days, err = strconv.Atoi(value); err != nil {
+			return opts, fmt.Errorf("days must be a number")
+		}
+	}
+	if value := get("end"); value != "" {
+		if opts.End, err = time.Parse("2006-01-02", value); err != nil {
+			return opts, fmt.Errorf("end must be a date such as 2024-05-01")
+		}
+	}
+	if value := get("languages"); value != "" {
+		opts.Languages = splitList(value)
+	}
+	if value := get("channels"); value != "" {
+		opts.Channels = splitList(value)
+	}
+	if value := get("topics"); value != "" {
+		opts.Topics = splitList(value)
+	}
+	if value := get("sentiment"); value != "" {
+		if opts.Mix, err = ParseSentimentMix(value); err != nil {
+			return opts, err
+		}
+	}
+	return opts, opts.Validate()
+}
+
+// ParseSentimentMix reads a mix such as "positive:60,neutral:20,negative:20", missing sentiments weigh 0
+func ParseSentimentMix(value string) (SentimentMix, error) {
+	var mix SentimentMix
+	for _, part := range splitList(value) {
+		sentiment, weight, ok := strings.Cut(part, ":")
+		w, err := strconv.ParseFloat(strings.TrimSpace(weight), 64)
+		if !ok || err != nil {
+			return mix, fmt.Errorf("sentiment must be a list such as positive:60,neutral:20,negative:20")
+		}
+		switch strings.TrimSpace(sentiment) {
+		case SentimentPositive:
+			mix.Positive = w
+		case SentimentNeutral:
+			mix.Neutral = w
+		case SentimentNegative:
+			mix.Negative = w
+		default:
+			return mix, fmt.Errorf("sentiment must be one of %s, %s or %s", SentimentPositive, SentimentNeutral, SentimentNegative)
+		}
+	}
+	return mix, nil
+}
+
+// Validate checks the options, topics are matched regardless of case and normalized
+func (o *Options) Validate() error {
+	if o.Count < 1 || o.Count > MaxCount {
+		return fmt.Errorf("count must be between 1 and %d", MaxCount)
+	}
+	if o.Days < 1 {
+		return fmt.Errorf("days must be at least 1")
+	}
+	if o.Mix.Positive < 0 || o.Mix.Neutral < 0 || o.Mix.Negative < 0 || o.Mix.Positive+o.Mix.Neutral+o.Mix.Negative == 0 {
+		return fmt.Errorf("sentiment weights must be positive and not all 0")
+	}
+	if len(o.Channels) == 0 {
+		return fmt.Errorf("at least one channel is required")
+	}
+	if len(o.Languages) == 0 {
+		return fmt.Errorf("at least one language is required")
+	}
+	for _, language := range o.Languages {
+		if !slices.Contains(Languages, language) {
+			return fmt.Errorf("language must be one of %s", strings.Join(Languages, ", "))
+		}
+	}
+	if len(o.Topics) == 0 {
+		return fmt.Errorf("at least one topic is required")
+	}
+	topics := make([]string, len(o.Topics))
+	for i, topic := range o.Topics {
+		known := slices.IndexFunc(Topics, func(t string) bool { return strings.EqualFold(t, topic) })
+		if known < 0 {
+			return fmt.Errorf("topic must be one of %s", strings.Join(Topics, ", "))
+		}
+		topics[i] = Topics[known]
+	}
+	o.Topics = topics
+	return nil
+}
+
+// Generate returns synthetic product feedbacks ordered by date.
+// Their external IDs derive from the seed, generating the same feedbacks twice on a board skips them the second time.
+func Generate(opts Options) ([]feedbackModel.FeedbackJson, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	spread := int64(opts.Days) * int64(24*time.Hour/time.Second)
+	feedbacks := make([]feedbackModel.FeedbackJson, opts.Count)
+	for i := range feedbacks {
+		language := opts.Languages[rng.Intn(len(opts.Languages))]
+		topic := opts.Topics[rng.Intn(len(opts.Topics))]
+		sentiment := opts.Mix.pick(rng)
+
+		text := pickString(rng, phrases[topic][language][sentiment])
+		if closing := pickString(rng, closings[language][sentiment]); closing != "" && rng.Intn(2) == 0 {
+			text += " " + closing
+		}
+
+		name := pickString(rng, names[language])
+		feedback := feedbackModel.FeedbackJson{
+			Date:    opts.End.Add(-time.Duration(1+rng.Int63n(spread)) * time.Second).UTC(),
+			Channel: opts.Channels[rng.Intn(len(opts.Channels))],
+			Text:    text,
+			Customer: &customerModel.CustomerJson{
+				Name:  name,
+				Email: customerEmail(name, language),
+			},
+		}
+		if rng.Float64() < ratingShare {
+			rating := ratingFor(rng, sentiment)
+			scale := feedbackModel.RatingScaleFive
+			feedback.Rating = &rating
+			feedback.RatingScale = &scale
+		}
+		feedbacks[i] = feedback
+	}
+
+	sort.SliceStable(feedbacks, func(i, j int) bool {
+		return feedbacks[i].Date.Before(feedbacks[j].Date)
+	})
+	for i := range feedbacks {
+		feedbacks[i].ExternalID = fmt.Sprintf("synthetic-%d-%d", opts.Seed, i+1)
+	}
+	return feedbacks, nil
+}
+
+// pick draws a sentiment according to the weights of the mix
+func (m SentimentMix) pick(rng *rand.Rand) string {
+	draw := rng.Float64() * (m.Positive + m.Neutral + m.Negative)
+	switch {
+	case draw < m.Positive:
+		return SentimentPositive
+	case draw < m.Positive+m.Neutral:
+		return SentimentNeutral
+	default:
+		return SentimentNegative
+	}
+}
+
+// ratingFor returns a 1-5 rating consistent with the sentiment
+func ratingFor(rng *rand.Rand, sentiment string) float64 {
+	switch sentiment {
+	case SentimentPositive:
+		return float64(4 + rng.Intn(2))
+	case SentimentNeutral:
+		return 3
+	default:
+		return float64(1 + rng.Intn(2))
+	}
+}
+
+var emailReplacer = strings.NewReplacer(" ", ".", "é", "e", "è", "e")
+
+func customerEmail(name, language string) string {
+	return emailReplacer.Replace(strings.ToLower(name)) + "@" + emailDomains[language]
+}
+
+func pickString(rng *rand.Rand, values []string) string {
+	return values[rng.Intn(len(values))]
+}
+
+func splitList(value string) []string {
+	values := make([]string, 0)
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
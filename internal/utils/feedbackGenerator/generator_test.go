@@ -0,0 +1,135 @@
+package feedbackGenerator
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testOptions() Options {
+	return Options{
+		Count:     200,
+		Seed:      42,
+		Languages: Languages,
+		Channels:  Channels,
+		Topics:    slices.Clone(Topics),
+		Mix:       DefaultMix,
+		Days:      30,
+		End:       time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	first, err := Generate(testOptions())
+	assert.NoError(t, err)
+	second, err := Generate(testOptions())
+	assert.NoError(t, err)
+	assert.Len(t, first, 200)
+	assert.Equal(t, first, second)
+
+	opts := testOptions()
+	opts.Seed = 43
+	other, err := Generate(opts)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, other)
+}
+
+func TestGenerate_Content(t *testing.T) {
+	opts := testOptions()
+	feedbacks, err := Generate(opts)
+	assert.NoError(t, err)
+
+	start := opts.End.AddDate(0, 0, -opts.Days)
+	ids := make(map[string]bool)
+	for i, feedback := range feedbacks {
+		assert.NotEmpty(t, feedback.Text)
+		assert.Contains(t, Channels, feedback.Channel)
+		assert.True(t, feedback.Date.Before(opts.End) && !feedback.Date.Before(start), "date %s out of range", feedback.Date)
+		if i > 0 {
+			assert.False(t, feedback.Date.Before(feedbacks[i-1].Date))
+		}
+		assert.NotNil(t, feedback.Customer)
+		assert.Regexp(t, `^[a-z.]+@example\.(fr|com)$`, feedback.Customer.Email)
+		ids[feedback.ExternalID] = true
+	}
+	assert.Len(t, ids, len(feedbacks))
+	assert.Equal(t, "synthetic-42-1", feedbacks[0].ExternalID)
+}
+
+func TestGenerate_Mix(t *testing.T) {
+	opts := testOptions()
+	opts.Mix = SentimentMix{Negative: 1}
+	opts.Languages = []string{LanguageEnglish}
+	opts.Topics = []string{"performance"}
+	feedbacks, err := Generate(opts)
+	assert.NoError(t, err)
+
+	negative := phrases["Performance"][LanguageEnglish][SentimentNegative]
+	for _, feedback := range feedbacks {
+		assert.True(t, slices.ContainsFunc(negative, func(p string) bool { return len(feedback.Text) >= len(p) && feedback.Text[:len(p)] == p }), feedback.Text)
+		if feedback.Rating != nil {
+			assert.LessOrEqual(t, *feedback.Rating, 2.0)
+		}
+	}
+}
+
+func TestOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(o *Options)
+		err    string
+	}{
+		{"count too low", func(o *Options) { o.Count = 0 }, "count must be between 1 and 10000"},
+		{"count too high", func(o *Options) { o.Count = MaxCount + 1 }, "count must be between 1 and 10000"},
+		{"days", func(o *Options) { o.Days = 0 }, "days must be at least 1"},
+		{"mix", func(o *Options) { o.Mix = SentimentMix{} }, "sentiment weights must be positive and not all 0"},
+		{"language", func(o *Options) { o.Languages = []string{"de"} }, "language must be one of fr, en"},
+		{"topic", func(o *Options) { o.Topics = []string{"Weather"} }, "topic must be one of"},
+		{"channels", func(o *Options) { o.Channels = nil }, "at least one channel is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := testOptions()
+			tt.change(&opts)
+			_, err := Generate(opts)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestParseOptions(t *testing.T) {
+	values := map[string]string{
+		"count":     "10",
+		"seed":      "7",
+		"languages": "fr",
+		"channels":  "email, web",
+		"topics":    "documentation",
+		"sentiment": "positive:80,negative:20",
+		"days":      "7",
+		"end":       "2024-05-01",
+	}
+	opts, err := ParseOptions(func(key string) string { return values[key] })
+	assert.NoError(t, err)
+	assert.Equal(t, 10, opts.Count)
+	assert.Equal(t, int64(7), opts.Seed)
+	assert.Equal(t, []string{"fr"}, opts.Languages)
+	assert.Equal(t, []string{"email", "web"}, opts.Channels)
+	assert.Equal(t, []string{"Documentation"}, opts.Topics)
+	assert.Equal(t, SentimentMix{Positive: 80, Negative: 20}, opts.Mix)
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), opts.End)
+
+	opts, err = ParseOptions(func(string) string { return "" })
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultCount, opts.Count)
+	assert.Equal(t, DefaultMix, opts.Mix)
+
+	_, err = ParseOptions(func(key string) string {
+		if key == "sentiment" {
+			return "happy:10"
+		}
+		return ""
+	})
+	assert.ErrorContains(t, err, "sentiment must be one of positive, neutral or negative")
+}
@@ -0,0 +1,361 @@
+package feedbackImport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	customerModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Customer"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+)
+
+// csvDateFormats are the date layouts accepted in the date column, tried in order
+var csvDateFormats = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+	"02/01/2006",
+	"2006/01/02",
+	"02-01-2006",
+	"Jan 2, 2006",
+	"2 Jan 2006",
+}
+
+// csvColumnAliases are the header names recognized for each field when no mapping is given.
+// They must not collide with usual cell values, "email" for instance is a channel.
+var csvColumnAliases = map[string][]string{
+	"date":           {"date", "created_at", "createdat", "timestamp", "datetime"},
+	"channel":        {"channel", "source", "canal"},
+	"text":           {"text", "feedback", "message", "comment", "content", "body", "texte"},
+	"external_id":    {"external_id", "externalid", "external id", "id"},
+	"customer_id":    {"customer_id", "customerid", "customer id", "user_id", "userid"},
+	"customer_name":  {"customer_name", "customer", "author", "author_name"},
+	"customer_email": {"customer_email", "author_email", "email_address"},
+	"rating":         {"rating", "stars", "score", "note"},
+}
+
+// csvMetadataPrefix marks the header columns holding custom metadata, "metadata.plan" fills the plan key
+const csvMetadataPrefix = "metadata."
+
+// CsvOptions describes how a CSV file must be read
+type CsvOptions struct {
+	// Delimiter is the field separator, 0 to detect it from the first line
+	Delimiter rune
+	// Header is "auto", "true" or "false"
+	Header string
+	// The *Column fields are either a header name or a 0-based index
+	DateColumn          string
+	ChannelColumn       string
+	TextColumn          string
+	ExternalIDColumn    string
+	CustomerIDColumn    string
+	CustomerNameColumn  string
+	CustomerEmailColumn string
+	RatingColumn        string
+	// RatingScale is the scale of every rating of the file, nil for the default scale
+	RatingScale *int
+}
+
+// csvColumns is the resolved position of each field in a record, -1 for an absent optional field
+type csvColumns struct {
+	date, channel, text, externalID         int
+	customerID, customerName, customerEmail int
+	rating                                  int
+	// metadata maps the metadata keys to their column, only read from a header
+	metadata map[string]int
+}
+
+// ParseDelimiter converts a delimiter option to a rune, 0 meaning auto-detection
+func ParseDelimiter(delimiter string) (rune, error) {
+	switch strings.ToLower(delimiter) {
+	case "", "auto":
+		return 0, nil
+	case "tab", `\t`, "\t":
+		return '\t', nil
+	case "comma":
+		return ',', nil
+	case "semicolon":
+		return ';', nil
+	case "pipe":
+		return '|', nil
+	}
+
+	r, size := utf8.DecodeRuneInString(delimiter)
+	if size != len(delimiter) || r == '"' || r == '\r' || r == '\n' || r == utf8.RuneError {
+		return 0, fmt.Errorf("invalid delimiter %q", delimiter)
+	}
+	return r, nil
+}
+
+// detectDelimiter picks the most frequent candidate separator on the first line
+func detectDelimiter(firstLine []byte) rune {
+	best, bestCount := ',', 0
+	for _, candidate := range []rune{',', ';', '\t', '|'} {
+		if count := bytes.Count(firstLine, []byte(string(candidate))); count > bestCount {
+			best, bestCount = candidate, count
+		}
+	}
+	return best
+}
+
+// DecodeCsv reads a CSV stream record by record and hands each row to handle.
+// Rows that cannot be read are passed with their error so they end up in the report.
+func DecodeCsv(r io.Reader, opts CsvOptions, handle RowHandler) error {
+	buffered := bufio.NewReaderSize(r, 64*1024)
+	if bom, _ := buffered.Peek(3); bytes.Equal(bom, []byte("\xef\xbb\xbf")) {
+		_, _ = buffered.Discard(3)
+	}
+
+	delimiter := opts.Delimiter
+	if delimiter == 0 {
+		head, _ := buffered.Peek(buffered.Size())
+		if i := bytes.IndexByte(head, '\n'); i >= 0 {
+			head = head[:i]
+		}
+		delimiter = detectDelimiter(head)
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	rows := 0
+	var columns *csvColumns
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return err
+			}
+			rows++
+			if err := handle(parseErr.StartLine, feedbackModel.FeedbackJson{}, parseErr.Err); err != nil {
+				return err
+			}
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		if isBlankRecord(record) {
+			continue
+		}
+
+		if columns == nil {
+			resolved, isHeader, err := resolveCsvColumns(record, opts)
+			if err != nil {
+				return err
+			}
+			columns = &resolved
+			if isHeader {
+				continue
+			}
+		}
+
+		rows++
+		feedback, err := csvRecordToFeedback(record, *columns, opts.RatingScale)
+		if err := handle(line, feedback, err); err != nil {
+			return err
+		}
+	}
+
+	if rows == 0 {
+		return errors.New("no feedback data found in file")
+	}
+	return nil
+}
+
+// resolveCsvColumns finds the position of each field and tells whether the record is a header
+func resolveCsvColumns(record []string, opts CsvOptions) (csvColumns, bool, error) {
+	isHeader := opts.Header == "true"
+	if opts.Header == "auto" {
+		isHeader = looksLikeHeader(record, opts)
+	}
+
+	var columns csvColumns
+	fields := []struct {
+		name     string
+		mapping  string
+		index    *int
+		def      int
+		optional bool
+	}{
+		{"date", opts.DateColumn, &columns.date, 0, false},
+		{"channel", opts.ChannelColumn, &columns.channel, 1, false},
+		{"text", opts.TextColumn, &columns.text, 2, false},
+		{"external_id", opts.ExternalIDColumn, &columns.externalID, -1, true},
+		{"customer_id", opts.CustomerIDColumn, &columns.customerID, -1, true},
+		{"customer_name", opts.CustomerNameColumn, &columns.customerName, -1, true},
+		{"customer_email", opts.CustomerEmailColumn, &columns.customerEmail, -1, true},
+		{"rating", opts.RatingColumn, &columns.rating, -1, true},
+	}
+
+	for _, field := range fields {
+		if index, err := strconv.Atoi(field.mapping); err == nil {
+			if index < 0 {
+				return columns, false, fmt.Errorf("%s_column must be a positive index", field.name)
+			}
+			*field.index = index
+			continue
+		}
+
+		if !isHeader {
+			if field.mapping != "" {
+				return columns, false, fmt.Errorf("%s_column %q requires a header row", field.name, field.mapping)
+			}
+			*field.index = field.def
+			continue
+		}
+
+		names := csvColumnAliases[field.name]
+		if field.mapping != "" {
+			names = []string{field.mapping}
+		}
+		index := findColumn(record, names)
+		if index < 0 && (!field.optional || field.mapping != "") {
+			return columns, false, fmt.Errorf("column for %s not found in CSV header", field.name)
+		}
+		*field.index = index
+	}
+
+	if isHeader {
+		for i, cell := range record {
+			cell = strings.TrimSpace(cell)
+			if len(cell) > len(csvMetadataPrefix) && strings.EqualFold(cell[:len(csvMetadataPrefix)], csvMetadataPrefix) {
+				if columns.metadata == nil {
+					columns.metadata = make(map[string]int)
+				}
+				columns.metadata[cell[len(csvMetadataPrefix):]] = i
+			}
+		}
+	}
+
+	return columns, isHeader, nil
+}
+
+// looksLikeHeader reports whether a record names the expected columns instead of holding data
+func looksLikeHeader(record []string, opts CsvOptions) bool {
+	names := make([]string, 0)
+	mappings := []string{opts.DateColumn, opts.ChannelColumn, opts.TextColumn, opts.ExternalIDColumn,
+		opts.CustomerIDColumn, opts.CustomerNameColumn, opts.CustomerEmailColumn, opts.RatingColumn}
+	for _, mapping := range mappings {
+		if _, err := strconv.Atoi(mapping); mapping != "" && err != nil {
+			names = append(names, mapping)
+		}
+	}
+	for _, aliases := range csvColumnAliases {
+		names = append(names, aliases...)
+	}
+	return findColumn(record, names) >= 0
+}
+
+// findColumn returns the index of the first cell matching one of the names, case-insensitively
+func findColumn(record []string, names []string) int {
+	for i, cell := range record {
+		cell = strings.TrimSpace(cell)
+		for _, name := range names {
+			if strings.EqualFold(cell, name) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// csvRecordToFeedback maps a CSV record to a feedback using the resolved columns
+func csvRecordToFeedback(record []string, columns csvColumns, ratingScale *int) (feedbackModel.FeedbackJson, error) {
+	cell := func(index int) string {
+		if index < 0 || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	for _, index := range []int{columns.date, columns.channel, columns.text} {
+		if index >= len(record) {
+			return feedbackModel.FeedbackJson{}, fmt.Errorf("expected at least %d columns, got %d", index+1, len(record))
+		}
+	}
+
+	rawDate := cell(columns.date)
+	if rawDate == "" {
+		return feedbackModel.FeedbackJson{}, errors.New("missing date")
+	}
+	date, err := parseCsvDate(rawDate)
+	if err != nil {
+		return feedbackModel.FeedbackJson{}, err
+	}
+
+	channel := cell(columns.channel)
+	text := cell(columns.text)
+	if channel == "" || text == "" {
+		return feedbackModel.FeedbackJson{}, errors.New("missing required fields")
+	}
+
+	feedback := feedbackModel.FeedbackJson{
+		Date:       date,
+		Channel:    channel,
+		Text:       text,
+		ExternalID: cell(columns.externalID),
+	}
+	customer := customerModel.CustomerJson{
+		ExternalID: cell(columns.customerID),
+		Name:       cell(columns.customerName),
+		Email:      cell(columns.customerEmail),
+	}
+	if customer.ExternalID != "" || customer.Name != "" || customer.Email != "" {
+		feedback.Customer = &customer
+	}
+	if rawRating := cell(columns.rating); rawRating != "" {
+		rating, err := strconv.ParseFloat(strings.Replace(rawRating, ",", ".", 1), 64)
+		if err != nil {
+			return feedbackModel.FeedbackJson{}, fmt.Errorf("invalid rating %q", rawRating)
+		}
+		feedback.Rating = &rating
+		feedback.RatingScale = ratingScale
+	}
+	for key, index := range columns.metadata {
+		if value := cell(index); value != "" {
+			if feedback.Metadata == nil {
+				feedback.Metadata = make(feedbackModel.Metadata)
+			}
+			feedback.Metadata[key] = value
+		}
+	}
+	return feedback, nil
+}
+
+// parseCsvDate parses a date using the first matching supported layout
+func parseCsvDate(value string) (time.Time, error) {
+	for _, layout := range csvDateFormats {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// isBlankRecord reports whether every cell of a record is empty
+func isBlankRecord(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
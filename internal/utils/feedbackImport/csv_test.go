@@ -0,0 +1,145 @@
+package feedbackImport
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+)
+
+func TestDecodeFeedbackCsv_WithHeader(t *testing.T) {
+	csvData := "\xef\xbb\xbftext,channel,date\n" +
+		"\"Great app, love it\",twitter,2024-01-01T10:00:00Z\n" +
+		"Too slow,email,15/02/2024\n"
+
+	feedbacks, rowErrors, err := collectRows(func(handle RowHandler) error {
+		return DecodeCsv(strings.NewReader(csvData), CsvOptions{Header: "auto"}, handle)
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	assert.Len(t, feedbacks, 2)
+	assert.Equal(t, "Great app, love it", feedbacks[0].Text)
+	assert.Equal(t, "twitter", feedbacks[0].Channel)
+	assert.Equal(t, time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC), feedbacks[1].Date)
+}
+
+func TestDecodeFeedbackCsv_WithoutHeader(t *testing.T) {
+	csvData := "2024-01-01;email;Bonjour\n2024-01-02 08:30;web;Merci\n"
+
+	feedbacks, rowErrors, err := collectRows(func(handle RowHandler) error {
+		return DecodeCsv(strings.NewReader(csvData), CsvOptions{Header: "auto"}, handle)
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	assert.Len(t, feedbacks, 2)
+	assert.Equal(t, "web", feedbacks[1].Channel)
+	assert.Equal(t, "Merci", feedbacks[1].Text)
+}
+
+func TestDecodeFeedbackCsv_ColumnMapping(t *testing.T) {
+	csvData := "id|created|origin|body\n1|2024-03-01|app|Nice\n"
+
+	feedbacks, _, err := collectRows(func(handle RowHandler) error {
+		return DecodeCsv(strings.NewReader(csvData), CsvOptions{
+			Delimiter:     '|',
+			Header:        "true",
+			DateColumn:    "created",
+			ChannelColumn: "origin",
+			TextColumn:    "3",
+		}, handle)
+	})
+	assert.NoError(t, err)
+	assert.Len(t, feedbacks, 1)
+	assert.Equal(t, "app", feedbacks[0].Channel)
+	assert.Equal(t, "Nice", feedbacks[0].Text)
+
+	_, _, err = collectRows(func(handle RowHandler) error {
+		return DecodeCsv(strings.NewReader(csvData), CsvOptions{Delimiter: '|', Header: "true", DateColumn: "missing"}, handle)
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "column for date not found")
+}
+
+func TestDecodeFeedbackCsv_Customer(t *testing.T) {
+	csvData := "date,channel,text,customer_email,author\n2024-03-01,app,Nice,ann@example.com,Ann\n2024-03-02,web,Anonymous,,\n"
+
+	feedbacks, _, err := collectRows(func(handle RowHandler) error {
+		return DecodeCsv(strings.NewReader(csvData), CsvOptions{Header: "auto"}, handle)
+	})
+	assert.NoError(t, err)
+	assert.Len(t, feedbacks, 2)
+	assert.Equal(t, "ann@example.com", feedbacks[0].Customer.Email)
+	assert.Equal(t, "Ann", feedbacks[0].Customer.Name)
+	assert.Nil(t, feedbacks[1].Customer)
+}
+
+func TestDecodeFeedbackCsv_Metadata(t *testing.T) {
+	csvData := "date;channel;text;Metadata.plan;metadata.app_version\n2024-03-01;app;Nice;pro;2.1\n2024-03-02;web;Slow;;\n"
+
+	feedbacks, _, err := collectRows(func(handle RowHandler) error {
+		return DecodeCsv(strings.NewReader(csvData), CsvOptions{Header: "auto"}, handle)
+	})
+	assert.NoError(t, err)
+	assert.Len(t, feedbacks, 2)
+	assert.Equal(t, feedbackModel.Metadata{"plan": "pro", "app_version": "2.1"}, feedbacks[0].Metadata)
+	assert.Nil(t, feedbacks[1].Metadata)
+}
+
+func TestDecodeFeedbackCsv_Rating(t *testing.T) {
+	csvData := "date;channel;text;stars\n2024-03-01;appstore;Nice;4,5\n2024-03-02;appstore;Meh;\n2024-03-03;appstore;Bad;low\n"
+	scale := feedbackModel.RatingScaleTen
+
+	feedbacks, rowErrors, err := collectRows(func(handle RowHandler) error {
+		return DecodeCsv(strings.NewReader(csvData), CsvOptions{Header: "auto", RatingScale: &scale}, handle)
+	})
+	assert.NoError(t, err)
+	assert.Len(t, feedbacks, 2)
+	assert.Equal(t, 4.5, *feedbacks[0].Rating)
+	assert.Equal(t, 10, *feedbacks[0].RatingScale)
+	assert.Nil(t, feedbacks[1].Rating)
+	assert.Equal(t, []string{`Row 4: invalid rating "low"`}, rowErrors)
+}
+
+func TestDecodeFeedbackCsv_RowErrors(t *testing.T) {
+	csvData := "date,channel,text\n" +
+		"2024-01-01,email,ok\n" +
+		"not a date,email,bad date\n" +
+		"2024-01-01,,no channel\n" +
+		"2024-01-01,email\n" +
+		"\n"
+
+	feedbacks, rowErrors, err := collectRows(func(handle RowHandler) error {
+		return DecodeCsv(strings.NewReader(csvData), CsvOptions{Header: "auto"}, handle)
+	})
+	assert.NoError(t, err)
+	assert.Len(t, feedbacks, 1)
+	assert.Equal(t, []string{
+		`Row 3: invalid date "not a date"`,
+		"Row 4: missing required fields",
+		"Row 5: expected at least 3 columns, got 2",
+	}, rowErrors)
+}
+
+func TestDecodeFeedbackCsv_Empty(t *testing.T) {
+	_, _, err := collectRows(func(handle RowHandler) error {
+		return DecodeCsv(strings.NewReader("date,channel,text\n"), CsvOptions{Header: "auto"}, handle)
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no feedback data found")
+}
+
+func TestParseDelimiter(t *testing.T) {
+	tests := map[string]rune{"": 0, "tab": '\t', ";": ';', "semicolon": ';', ",": ','}
+	for input, expected := range tests {
+		delimiter, err := ParseDelimiter(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, delimiter, input)
+	}
+
+	_, err := ParseDelimiter(`"`)
+	assert.Error(t, err)
+	_, err = ParseDelimiter(";;")
+	assert.Error(t, err)
+}
@@ -0,0 +1,139 @@
+package feedbackImport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"unicode"
+
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+)
+
+// Supported file formats
+const (
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+)
+
+// maxNdjsonLine is the longest line accepted in a NDJSON file
+const maxNdjsonLine = 1024 * 1024
+
+// RowHandler receives each decoded row of a file, err is set when the row could not be read
+type RowHandler func(row int, feedback feedbackModel.FeedbackJson, err error) error
+
+// RowSink consumes the decoded rows of a file, implemented by Importer and DryRun
+type RowSink interface {
+	Add(row int, feedback feedbackModel.FeedbackJson) error
+	Reject(row int, err error)
+}
+
+// Decode streams the rows of a file in the given format into sink.
+// storeErr is set when the sink failed to store a row, err when the file could not be read.
+func Decode(file io.Reader, format string, opts CsvOptions, sink RowSink) (storeErr error, err error) {
+	handleRow := func(row int, feedback feedbackModel.FeedbackJson, err error) error {
+		if err != nil {
+			sink.Reject(row, err)
+			return nil
+		}
+		storeErr = sink.Add(row, feedback)
+		return storeErr
+	}
+	if format == FormatCSV {
+		err = DecodeCsv(file, opts, handleRow)
+	} else {
+		err = DecodeJson(file, handleRow)
+	}
+	if storeErr != nil {
+		return storeErr, nil
+	}
+	return nil, err
+}
+
+// DecodeJson streams a JSON array or a NDJSON file and hands each row to handle.
+// The layout is detected from the first character: '[' for an array, anything else for NDJSON.
+func DecodeJson(r io.Reader, handle RowHandler) error {
+	buffered := bufio.NewReaderSize(r, 64*1024)
+	for {
+		char, _, err := buffered.ReadRune()
+		if err == io.EOF {
+			return errors.New("no feedback data found in file")
+		}
+		if err != nil {
+			return err
+		}
+		if unicode.IsSpace(char) || char == '\uFEFF' {
+			continue
+		}
+		_ = buffered.UnreadRune()
+		if char == '[' {
+			return decodeJsonArray(buffered, handle)
+		}
+		return decodeNdjson(buffered, handle)
+	}
+}
+
+// decodeJsonArray decodes the elements of a JSON array one at a time.
+// An element with wrong field types is reported as a row error, broken JSON stops the decoding.
+func decodeJsonArray(r io.Reader, handle RowHandler) error {
+	decoder := json.NewDecoder(r)
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+
+	rows := 0
+	for decoder.More() {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return err
+		}
+		rows++
+
+		var feedback feedbackModel.FeedbackJson
+		err := json.Unmarshal(raw, &feedback)
+		if err := handle(rows, feedback, err); err != nil {
+			return err
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return errors.New("no feedback data found in file")
+	}
+	return nil
+}
+
+// decodeNdjson decodes one JSON object per line, invalid lines are reported as row errors
+func decodeNdjson(r io.Reader, handle RowHandler) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNdjsonLine)
+
+	line, rows := 0, 0
+	for scanner.Scan() {
+		line++
+		content := bytes.TrimSpace(scanner.Bytes())
+		if len(content) == 0 {
+			continue
+		}
+		rows++
+
+		var feedback feedbackModel.FeedbackJson
+		err := json.Unmarshal(content, &feedback)
+		if err := handle(line, feedback, err); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("line %d: %w", line+1, err)
+	}
+
+	if rows == 0 {
+		return errors.New("no feedback data found in file")
+	}
+	return nil
+}
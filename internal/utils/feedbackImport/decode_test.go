@@ -0,0 +1,147 @@
+package feedbackImport
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+)
+
+// collectRows runs a decoder and gathers the parsed rows and the row errors
+func collectRows(decode func(handle RowHandler) error) ([]feedbackModel.FeedbackJson, []string, error) {
+	feedbacks := make([]feedbackModel.FeedbackJson, 0)
+	rowErrors := make([]string, 0)
+	err := decode(func(row int, feedback feedbackModel.FeedbackJson, err error) error {
+		if err != nil {
+			rowErrors = append(rowErrors, fmt.Sprintf("Row %d: %v", row, err))
+			return nil
+		}
+		feedbacks = append(feedbacks, feedback)
+		return nil
+	})
+	return feedbacks, rowErrors, err
+}
+
+func decodeJsonString(data string) ([]feedbackModel.FeedbackJson, []string, error) {
+	return collectRows(func(handle RowHandler) error {
+		return DecodeJson(strings.NewReader(data), handle)
+	})
+}
+
+func TestDecodeFeedbackJson_ValidData(t *testing.T) {
+	jsonData := `[{
+		"date": "2024-01-01T00:00:00Z",
+		"channel": "test",
+		"text": "feedback"
+	}]`
+
+	feedbacks, rowErrors, err := decodeJsonString(jsonData)
+	assert.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	assert.Len(t, feedbacks, 1)
+	assert.Equal(t, "test", feedbacks[0].Channel)
+	assert.Equal(t, "feedback", feedbacks[0].Text)
+}
+
+func TestDecodeFeedbackJson_InvalidJSON(t *testing.T) {
+	_, _, err := decodeJsonString(`[{"channel": "test"}, invalid json]`)
+	assert.Error(t, err)
+}
+
+func TestDecodeFeedbackJson_EmptyArray(t *testing.T) {
+	for _, data := range []string{`[]`, ``, "  \n"} {
+		_, _, err := decodeJsonString(data)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no feedback data found")
+	}
+}
+
+func TestDecodeFeedbackJson_ManyFeedbacks(t *testing.T) {
+	// The former cap of 10 feedbacks per file no longer applies
+	var builder strings.Builder
+	builder.WriteString("[")
+	for i := 0; i < 2500; i++ {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString(`{"date": "2024-01-01T00:00:00Z", "channel": "test", "text": "feedback"}`)
+	}
+	builder.WriteString("]")
+
+	feedbacks, rowErrors, err := decodeJsonString(builder.String())
+	assert.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	assert.Len(t, feedbacks, 2500)
+}
+
+func TestDecodeFeedbackJson_RowErrors(t *testing.T) {
+	jsonData := `[{"date": "2024-01-01T00:00:00Z", "channel": "a", "text": "ok"}, {"date": "yesterday", "channel": "b", "text": "bad date"}]`
+
+	feedbacks, rowErrors, err := decodeJsonString(jsonData)
+	assert.NoError(t, err)
+	assert.Len(t, feedbacks, 1)
+	assert.Len(t, rowErrors, 1)
+	assert.True(t, strings.HasPrefix(rowErrors[0], "Row 2: "))
+}
+
+func TestDecodeFeedbackJson_Ndjson(t *testing.T) {
+	ndjson := "{\"date\": \"2024-01-01T00:00:00Z\", \"channel\": \"a\", \"text\": \"first\"}\n" +
+		"\n" +
+		"not json\n" +
+		"{\"date\": \"2024-01-02T00:00:00Z\", \"channel\": \"b\", \"text\": \"second\"}\n"
+
+	feedbacks, rowErrors, err := decodeJsonString(ndjson)
+	assert.NoError(t, err)
+	assert.Len(t, feedbacks, 2)
+	assert.Equal(t, "second", feedbacks[1].Text)
+	assert.Len(t, rowErrors, 1)
+	assert.True(t, strings.HasPrefix(rowErrors[0], "Row 3: "))
+}
+
+func TestDecodeFeedbackJson_StopsOnHandlerError(t *testing.T) {
+	calls := 0
+	err := DecodeJson(strings.NewReader(`[{"channel": "a", "text": "1"}, {"channel": "b", "text": "2"}]`),
+		func(row int, feedback feedbackModel.FeedbackJson, err error) error {
+			calls++
+			return errors.New("database down")
+		})
+	assert.EqualError(t, err, "database down")
+	assert.Equal(t, 1, calls)
+}
+
+// recordingSink is a RowSink keeping what it receives
+type recordingSink struct {
+	added    []int
+	rejected []int
+	addErr   error
+}
+
+func (s *recordingSink) Add(row int, feedback feedbackModel.FeedbackJson) error {
+	s.added = append(s.added, row)
+	return s.addErr
+}
+
+func (s *recordingSink) Reject(row int, err error) {
+	s.rejected = append(s.rejected, row)
+}
+
+func TestDecode(t *testing.T) {
+	sink := &recordingSink{}
+	storeErr, err := Decode(strings.NewReader("date,channel,text\n2024-01-01,email,ok\nbad,email,ko\n"), FormatCSV, CsvOptions{Header: "auto"}, sink)
+	assert.NoError(t, storeErr)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, sink.added)
+	assert.Equal(t, []int{3}, sink.rejected)
+
+	// A sink failure is reported apart from parse errors
+	sink = &recordingSink{addErr: errors.New("database down")}
+	storeErr, err = Decode(strings.NewReader(`[{"channel": "a", "text": "1"}]`), FormatJSON, CsvOptions{}, sink)
+	assert.EqualError(t, storeErr, "database down")
+	assert.NoError(t, err)
+
+	_, err = Decode(strings.NewReader(`[{"channel": `), FormatJSON, CsvOptions{}, &recordingSink{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,196 @@
+package feedbackImport
+
+import (
+	"fmt"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+)
+
+// Outcomes of a previewed row
+const (
+	PreviewNew       = "new"
+	PreviewUpdate    = "update"
+	PreviewDuplicate = "duplicate"
+	PreviewInvalid   = "invalid"
+)
+
+// PreviewRow is a parsed row along with what an import would do with it
+type PreviewRow struct {
+	Row      int                        `json:"row"`
+	Feedback feedbackModel.FeedbackJson `json:"feedback"`
+	Outcome  string                     `json:"outcome"`
+	Error    string                     `json:"error,omitempty"`
+}
+
+// Preview is the result of a dry run, the counts cover the whole file while Rows only holds its first rows
+type Preview struct {
+	TotalRows      int          `json:"total"`
+	ValidCount     int          `json:"valid_count"`
+	NewCount       int          `json:"new_count"`
+	UpdatedCount   int          `json:"updated_count"`
+	DuplicateCount int          `json:"skipped_count"`
+	ErrorCount     int          `json:"error_count"`
+	Errors         []string     `json:"errors"`
+	Rows           []PreviewRow `json:"preview"`
+}
+
+// DryRun goes through the same validation and deduplication as Importer without writing anything
+// nor calling the analyzer.
+type DryRun struct {
+	boardID     int
+	previewSize int
+	preview     Preview
+	// batch holds the queued rows in file order, invalid ones included so the preview keeps that order,
+	// feedbacks holds the valid ones to classify
+	batch     []PreviewRow
+	feedbacks []feedbackModel.Feedback
+	// seenHashes and seenExternalIDs hold the new rows of previous batches,
+	// which are not in the database since nothing is written
+	seenHashes      map[string]bool
+	seenExternalIDs map[string]bool
+	metadata        metadataValidator
+}
+
+// NewDryRun prepares a dry run on a board, keeping the first previewSize rows in the preview
+func NewDryRun(boardID, previewSize int) *DryRun {
+	return &DryRun{
+		boardID:     boardID,
+		previewSize: previewSize,
+		preview: Preview{
+			Errors: []string{},
+			Rows:   make([]PreviewRow, 0, previewSize),
+		},
+		batch:           make([]PreviewRow, 0, BatchSize),
+		feedbacks:       make([]feedbackModel.Feedback, 0, BatchSize),
+		seenHashes:      make(map[string]bool),
+		seenExternalIDs: make(map[string]bool),
+		metadata:        metadataValidator{boardID: boardID},
+	}
+}
+
+// Add validates a parsed row and queues it for classification
+func (d *DryRun) Add(row int, feedbackJson feedbackModel.FeedbackJson) error {
+	d.preview.TotalRows++
+	if feedbackJson.Channel == "" || feedbackJson.Text == "" {
+		d.invalid(PreviewRow{Row: row, Feedback: feedbackJson}, "missing required fields")
+		return nil
+	}
+	metadata, err := d.metadata.validate(feedbackJson.Metadata)
+	if err != nil {
+		d.invalid(PreviewRow{Row: row, Feedback: feedbackJson}, err.Error())
+		return nil
+	}
+	feedbackJson.Metadata = metadata
+	ratingScale, err := feedbackModel.ValidateRating(feedbackJson.Rating, feedbackJson.RatingScale)
+	if err != nil {
+		d.invalid(PreviewRow{Row: row, Feedback: feedbackJson}, err.Error())
+		return nil
+	}
+	feedbackJson.RatingScale = ratingScale
+
+	feedback := feedbackModel.Feedback{
+		Date:        feedbackJson.Date,
+		Channel:     feedbackJson.Channel,
+		Text:        feedbackJson.Text,
+		BoardID:     d.boardID,
+		Metadata:    metadata,
+		Rating:      feedbackJson.Rating,
+		RatingScale: ratingScale,
+	}
+	if feedbackJson.ExternalID != "" {
+		externalID := feedbackJson.ExternalID
+		feedback.ExternalID = &externalID
+	}
+
+	d.batch = append(d.batch, PreviewRow{Row: row, Feedback: feedbackJson})
+	d.feedbacks = append(d.feedbacks, feedback)
+	if len(d.feedbacks) >= BatchSize {
+		return d.flush()
+	}
+	return nil
+}
+
+// Reject records a row that could not be parsed
+func (d *DryRun) Reject(row int, err error) {
+	d.preview.TotalRows++
+	d.invalid(PreviewRow{Row: row}, err.Error())
+}
+
+// Finish classifies the remaining rows and returns the preview
+func (d *DryRun) Finish() (Preview, error) {
+	if err := d.flush(); err != nil {
+		return d.preview, err
+	}
+	return d.preview, nil
+}
+
+// flush classifies the queued rows against the stored feedbacks and the rows already seen
+func (d *DryRun) flush() error {
+	if len(d.batch) == 0 {
+		return nil
+	}
+
+	// ClassifyFeedbacks does not query the database for an empty slice
+	outcomes, err := feedbackDB.ClassifyFeedbacks(database.DB, d.feedbacks)
+	if err != nil {
+		return fmt.Errorf("failed to check duplicates: %w", err)
+	}
+	j := 0
+	for _, row := range d.batch {
+		if row.Outcome == PreviewInvalid {
+			d.keep(row)
+			continue
+		}
+		feedback := d.feedbacks[j]
+		outcome := outcomes[j]
+		j++
+		switch {
+		case outcome == feedbackDB.OutcomeSkipped,
+			d.seenHashes[*feedback.ContentHash],
+			feedback.ExternalID != nil && d.seenExternalIDs[*feedback.ExternalID]:
+			row.Outcome = PreviewDuplicate
+			d.preview.DuplicateCount++
+		case outcome == feedbackDB.OutcomeUpdated:
+			row.Outcome = PreviewUpdate
+			d.preview.UpdatedCount++
+		default:
+			row.Outcome = PreviewNew
+			d.preview.NewCount++
+		}
+		if row.Outcome != PreviewDuplicate {
+			d.preview.ValidCount++
+			d.seenHashes[*feedback.ContentHash] = true
+			if feedback.ExternalID != nil {
+				d.seenExternalIDs[*feedback.ExternalID] = true
+			}
+		}
+		d.keep(row)
+	}
+
+	d.batch = make([]PreviewRow, 0, BatchSize)
+	d.feedbacks = make([]feedbackModel.Feedback, 0, BatchSize)
+	return nil
+}
+
+// invalid records a row error, in the report and in the preview
+func (d *DryRun) invalid(row PreviewRow, message string) {
+	d.preview.ErrorCount++
+	if len(d.preview.Errors) < maxReportedErrors {
+		d.preview.Errors = append(d.preview.Errors, fmt.Sprintf("Row %d: %s", row.Row, message))
+	}
+	row.Outcome = PreviewInvalid
+	row.Error = message
+	// Only queue the invalid rows that may still end up in the preview
+	if len(d.preview.Rows)+len(d.batch) < d.previewSize {
+		d.batch = append(d.batch, row)
+	}
+}
+
+// keep adds a row to the preview until it is full
+func (d *DryRun) keep(row PreviewRow) {
+	if len(d.preview.Rows) < d.previewSize {
+		d.preview.Rows = append(d.preview.Rows, row)
+	}
+}
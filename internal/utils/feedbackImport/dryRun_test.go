@@ -0,0 +1,103 @@
+package feedbackImport
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+)
+
+func TestDryRun_Preview(t *testing.T) {
+	mock := setupMockDB(t)
+
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	stored := feedbackModel.FeedbackJson{Date: date, Channel: "email", Text: "Already there"}
+	changed := feedbackModel.FeedbackJson{Date: date, Channel: "app", Text: "Edited text", ExternalID: "ext-1"}
+	fresh := feedbackModel.FeedbackJson{Date: date, Channel: "email", Text: "Brand new"}
+	hash := feedbackDB.ContentHash(feedbackModel.Feedback{Date: date, Channel: "email", Text: "Already there"})
+
+	// Only reads are expected, nothing is inserted
+	mock.ExpectQuery(`SELECT "id","text","external_id","content_hash","deleted_at" FROM "feedbacks" WHERE board_id = \$1 AND \(content_hash IN`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "text", "external_id", "content_hash"}).
+			AddRow(9, "Already there", nil, hash).
+			AddRow(10, "Original text", "ext-1", "other"))
+
+	dryRun := NewDryRun(1, 4)
+	assert.NoError(t, dryRun.Add(1, fresh))
+	dryRun.Reject(2, errors.New("invalid date"))
+	assert.NoError(t, dryRun.Add(3, stored))
+	assert.NoError(t, dryRun.Add(4, changed))
+	assert.NoError(t, dryRun.Add(5, fresh))
+
+	preview, err := dryRun.Finish()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, preview.TotalRows)
+	assert.Equal(t, 2, preview.ValidCount)
+	assert.Equal(t, 1, preview.NewCount)
+	assert.Equal(t, 1, preview.UpdatedCount)
+	assert.Equal(t, 2, preview.DuplicateCount)
+	assert.Equal(t, 1, preview.ErrorCount)
+	assert.Equal(t, []string{"Row 2: invalid date"}, preview.Errors)
+
+	// The preview keeps the file order and stops at its size
+	assert.Len(t, preview.Rows, 4)
+	outcomes := make([]string, len(preview.Rows))
+	for i, row := range preview.Rows {
+		outcomes[i] = row.Outcome
+	}
+	assert.Equal(t, []string{PreviewNew, PreviewInvalid, PreviewDuplicate, PreviewUpdate}, outcomes)
+	assert.Equal(t, "invalid date", preview.Rows[1].Error)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDryRun_DuplicatesAcrossBatches(t *testing.T) {
+	mock := setupMockDB(t)
+
+	for i := 0; i < 2; i++ {
+		mock.ExpectQuery(`SELECT (.+) FROM "feedbacks" WHERE board_id = \$1 AND content_hash IN`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	}
+
+	// The same row fills two batches, only its first occurrence would be inserted
+	dryRun := NewDryRun(1, 0)
+	row := feedbackModel.FeedbackJson{Date: time.Now(), Channel: "email", Text: "Same text"}
+	for i := 1; i <= BatchSize+1; i++ {
+		assert.NoError(t, dryRun.Add(i, row))
+	}
+
+	preview, err := dryRun.Finish()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, preview.NewCount)
+	assert.Equal(t, BatchSize, preview.DuplicateCount)
+	assert.Empty(t, preview.Rows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDryRun_Metadata(t *testing.T) {
+	mock := setupMockDB(t)
+
+	// The schema is loaded once, on the first row having metadata
+	mock.ExpectQuery(`SELECT \* FROM "metadata_fields" WHERE board_id = \$1`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "key", "type", "allowed_values"}).
+			AddRow(1, 1, "seats", "number", nil))
+	mock.ExpectQuery(`SELECT (.+) FROM "feedbacks" WHERE board_id = \$1 AND content_hash IN`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	dryRun := NewDryRun(1, 10)
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, dryRun.Add(1, feedbackModel.FeedbackJson{Date: date, Channel: "app", Text: "Plain"}))
+	assert.NoError(t, dryRun.Add(2, feedbackModel.FeedbackJson{Date: date, Channel: "app", Text: "Ten seats", Metadata: feedbackModel.Metadata{"seats": "10"}}))
+	assert.NoError(t, dryRun.Add(3, feedbackModel.FeedbackJson{Date: date, Channel: "app", Text: "Many seats", Metadata: feedbackModel.Metadata{"seats": "many"}}))
+
+	preview, err := dryRun.Finish()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, preview.NewCount)
+	assert.Equal(t, []string{`Row 3: metadata "seats" must be a number`}, preview.Errors)
+	assert.Equal(t, float64(10), preview.Rows[1].Feedback.Metadata["seats"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,363 @@
+package feedbackImport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	boardDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
+	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	importJobDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/ImportJob"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/logging"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/metrics"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	importJobModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/ImportJob"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/background"
+	"gorm.io/gorm"
+)
+
+const (
+	// BatchSize is the number of feedbacks inserted per statement
+	BatchSize = 500
+	// maxReportedErrors caps the per-row errors kept on a job, ErrorCount still counts all of them
+	maxReportedErrors = 10000
+	// progressInterval is the number of analyses between two progress saves
+	progressInterval = 25
+)
+
+var ErrNoValidFeedback = errors.New("no valid feedback data found after validation")
+
+// importedRow is a saved feedback along with its row in the file
+type importedRow struct {
+	row      int
+	feedback feedbackModel.Feedback
+}
+
+// Importer persists the rows of an uploaded file in batches within a single transaction, skipping duplicates,
+// then analyzes the saved feedbacks in the background while the job records the progress.
+type Importer struct {
+	// ctx outlives the request which started the import, it carries its request ID to the analysis
+	ctx       context.Context
+	job       importJobModel.ImportJob
+	tx        *gorm.DB
+	userEmail string
+	batch     []importedRow
+	imported  []importedRow
+	metadata  metadataValidator
+	// replaced are the IDs of the stored feedbacks updated by the import, their cached copies are dropped on commit
+	replaced []int
+	// analyzed is closed once the background analysis has saved the final state of the job in completed
+	analyzed  chan struct{}
+	completed importJobModel.ImportJob
+}
+
+// Start creates the import job and opens the transaction holding the inserted rows.
+// A non-empty idempotency key is recorded on the job, see importJobDB.GetImportJobByIdempotencyKey.
+func Start(ctx context.Context, boardID int, filename, format, userEmail, idempotencyKey string) (*Importer, error) {
+	job := importJobModel.ImportJob{
+		BoardID:   boardID,
+		Filename:  filename,
+		Format:    format,
+		Status:    importJobModel.StatusImporting,
+		Errors:    []string{},
+		StartedAt: time.Now().UTC(),
+	}
+	if idempotencyKey != "" {
+		job.IdempotencyKey = &idempotencyKey
+	}
+	job, err := importJobDB.CreateImportJob(job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	tx := database.DB.Begin()
+	if tx.Error != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+
+	return &Importer{
+		ctx:       ctx,
+		job:       job,
+		tx:        tx,
+		userEmail: userEmail,
+		batch:     make([]importedRow, 0, BatchSize),
+		metadata:  metadataValidator{boardID: boardID},
+	}, nil
+}
+
+// Job returns the current state of the import job
+func (i *Importer) Job() importJobModel.ImportJob {
+	return i.job
+}
+
+// Add validates a parsed row and queues it for insertion
+func (i *Importer) Add(row int, feedbackJson feedbackModel.FeedbackJson) error {
+	i.job.TotalRows++
+	if feedbackJson.Channel == "" || feedbackJson.Text == "" {
+		i.addError(row, "missing required fields")
+		return nil
+	}
+	metadata, err := i.metadata.validate(feedbackJson.Metadata)
+	if err != nil {
+		i.addError(row, err.Error())
+		return nil
+	}
+	ratingScale, err := feedbackModel.ValidateRating(feedbackJson.Rating, feedbackJson.RatingScale)
+	if err != nil {
+		i.addError(row, err.Error())
+		return nil
+	}
+
+	feedback := feedbackModel.Feedback{
+		Date:        feedbackJson.Date,
+		Channel:     feedbackJson.Channel,
+		Text:        feedbackJson.Text,
+		BoardID:     i.job.BoardID,
+		Customer:    feedbackJson.Customer,
+		Metadata:    metadata,
+		Rating:      feedbackJson.Rating,
+		RatingScale: ratingScale,
+	}
+	if feedbackJson.ExternalID != "" {
+		externalID := feedbackJson.ExternalID
+		feedback.ExternalID = &externalID
+	}
+
+	i.batch = append(i.batch, importedRow{row: row, feedback: feedback})
+	if len(i.batch) >= BatchSize {
+		return i.flush()
+	}
+	return nil
+}
+
+// Reject records a row that could not be parsed
+func (i *Importer) Reject(row int, err error) {
+	i.job.TotalRows++
+	i.addError(row, err.Error())
+}
+
+// Commit inserts the remaining rows, commits the transaction and starts the analysis.
+// When no row was valid, nothing is saved and ErrNoValidFeedback is returned.
+func (i *Importer) Commit() (importJobModel.ImportJob, error) {
+	if err := i.flush(); err != nil {
+		i.Abort(err)
+		return i.job, err
+	}
+	if len(i.imported) == 0 && i.job.SkippedCount == 0 {
+		i.Abort(ErrNoValidFeedback)
+		return i.job, ErrNoValidFeedback
+	}
+
+	i.job.ImportedCount = len(i.imported)
+	i.job.Status = importJobModel.StatusAnalyzing
+	if len(i.imported) == 0 {
+		// Every row was a duplicate, there is nothing to analyze
+		finishedAt := time.Now().UTC()
+		i.job.Status = importJobModel.StatusCompleted
+		i.job.FinishedAt = &finishedAt
+	}
+	if err := i.tx.Save(&i.job).Error; err != nil {
+		i.Abort(err)
+		return i.job, err
+	}
+	if err := i.tx.Commit().Error; err != nil {
+		i.Abort(err)
+		return i.job, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	feedbackDB.InvalidateFeedbackCache(i.replaced)
+
+	if len(i.imported) > 0 {
+		i.analyzed = make(chan struct{})
+		// Shutdown drains the analysis, or interrupts it past its timeout, see background
+		ctx, cancel := background.WithStop(i.ctx)
+		background.Go(func() {
+			defer close(i.analyzed)
+			defer cancel()
+			i.completed = analyze(ctx, i.job, i.imported, i.userEmail)
+		})
+	}
+
+	return i.job, nil
+}
+
+// Wait blocks until the analysis started by Commit is over and returns the final state of the job.
+// Processes exiting right after an import, such as the CLI, use it not to cut the analysis short.
+func (i *Importer) Wait() importJobModel.ImportJob {
+	if i.analyzed == nil {
+		return i.job
+	}
+	<-i.analyzed
+	return i.completed
+}
+
+// Abort rolls back the inserted rows and marks the job as failed
+func (i *Importer) Abort(cause error) {
+	i.tx.Rollback()
+
+	finishedAt := time.Now().UTC()
+	i.job.Status = importJobModel.StatusFailed
+	i.job.ImportedCount = 0
+	i.job.SkippedCount = 0
+	i.job.FinishedAt = &finishedAt
+	// A failed import can be retried with the same idempotency key
+	i.job.IdempotencyKey = nil
+	if !errors.Is(cause, ErrNoValidFeedback) {
+		i.job.Errors = append(i.job.Errors, cause.Error())
+	}
+	if err := importJobDB.SaveImportJob(i.job); err != nil {
+		captureSaveError(i.ctx, i.job, err)
+	}
+}
+
+// flush saves the queued rows, skipping the ones already stored on the board
+func (i *Importer) flush() error {
+	if len(i.batch) == 0 {
+		return nil
+	}
+
+	feedbacks := make([]feedbackModel.Feedback, len(i.batch))
+	for j, row := range i.batch {
+		feedbacks[j] = row.feedback
+	}
+	outcomes, err := feedbackDB.UpsertFeedbacks(i.tx, feedbacks)
+	if err != nil {
+		return fmt.Errorf("failed to insert feedbacks: %w", err)
+	}
+	i.replaced = append(i.replaced, feedbackDB.ReplacedIDs(feedbacks, outcomes)...)
+	for j, row := range i.batch {
+		if outcomes[j] == feedbackDB.OutcomeSkipped {
+			i.job.SkippedCount++
+			continue
+		}
+		row.feedback = feedbacks[j]
+		i.imported = append(i.imported, row)
+	}
+
+	i.batch = make([]importedRow, 0, BatchSize)
+	return nil
+}
+
+// addError records a row error on the job
+func (i *Importer) addError(row int, message string) {
+	addJobError(&i.job, row, message)
+}
+
+// addJobError records a row error, only the first maxReportedErrors are kept
+func addJobError(job *importJobModel.ImportJob, row int, message string) {
+	recordJobError(job, fmt.Sprintf("Row %d: %s", row, message))
+}
+
+// recordJobError counts an error of a job, only the first maxReportedErrors are kept
+func recordJobError(job *importJobModel.ImportJob, message string) {
+	job.ErrorCount++
+	if len(job.Errors) < maxReportedErrors {
+		job.Errors = append(job.Errors, message)
+	}
+}
+
+// Resume analyzes in the background the feedbacks left without analysis by the import jobs a shutdown interrupted.
+// Each job is claimed first, a single replica resumes it.
+func Resume(ctx context.Context) error {
+	jobs, err := importJobDB.GetImportJobsByStatus(importJobModel.StatusInterrupted)
+	if err != nil {
+		return fmt.Errorf("failed to list the interrupted import jobs: %w", err)
+	}
+	for _, job := range jobs {
+		claimed, err := importJobDB.ClaimImportJob(job.Id, importJobModel.StatusInterrupted, importJobModel.StatusAnalyzing)
+		if err != nil {
+			return fmt.Errorf("failed to claim import job %d: %w", job.Id, err)
+		}
+		if !claimed {
+			continue
+		}
+		job.Status = importJobModel.StatusAnalyzing
+
+		// The imported feedbacks were inserted or replaced once the job started
+		feedbacks, err := feedbackDB.GetUnanalyzedFeedbacks(job.BoardID, job.StartedAt)
+		if err != nil {
+			job.Status = importJobModel.StatusInterrupted
+			if saveErr := importJobDB.SaveImportJob(job); saveErr != nil {
+				captureSaveError(ctx, job, saveErr)
+			}
+			return fmt.Errorf("failed to list the feedbacks of import job %d: %w", job.Id, err)
+		}
+		// The rows of the file are not known anymore, the errors refer to the feedbacks
+		rows := make([]importedRow, len(feedbacks))
+		for n, feedback := range feedbacks {
+			rows[n] = importedRow{feedback: feedback}
+		}
+		userEmail, _ := boardDB.GetBoardNotificationEmail(job.BoardID)
+
+		slog.InfoContext(ctx, "Resuming import job", "job_id", job.Id, "board_id", job.BoardID, "feedbacks", len(rows))
+		jobCtx, cancel := background.WithStop(ctx)
+		background.Go(func() {
+			defer cancel()
+			analyze(jobCtx, job, rows, userEmail)
+		})
+	}
+	return nil
+}
+
+// analyze runs the sentiment analysis of every imported feedback, saves the progress and returns the completed job.
+// When ctx is done, the job is saved as interrupted with the remaining feedbacks, see Resume.
+func analyze(ctx context.Context, job importJobModel.ImportJob, rows []importedRow, userEmail string) importJobModel.ImportJob {
+	metrics.QueueAnalyses(len(rows))
+	for n, row := range rows {
+		err := ctx.Err()
+		if err == nil {
+			err = feedbackDB.AnalyzeFeedback(ctx, row.feedback, userEmail)
+		}
+		if ctx.Err() != nil {
+			metrics.QueueAnalyses(n - len(rows))
+			job.Status = importJobModel.StatusInterrupted
+			if err := importJobDB.SaveImportJob(job); err != nil {
+				captureSaveError(ctx, job, err)
+			}
+			return job
+		}
+		metrics.QueueAnalyses(-1)
+		switch {
+		case err == nil:
+			job.AnalyzedCount++
+		case row.row > 0:
+			addJobError(&job, row.row, "analysis failed: "+err.Error())
+		default:
+			recordJobError(&job, fmt.Sprintf("Feedback %d: analysis failed: %s", row.feedback.Id, err))
+		}
+
+		if (n+1)%progressInterval == 0 && n+1 < len(rows) {
+			if err := importJobDB.SaveImportJob(job); err != nil {
+				captureSaveError(ctx, job, err)
+			}
+		}
+	}
+
+	finishedAt := time.Now().UTC()
+	job.Status = importJobModel.StatusCompleted
+	job.FinishedAt = &finishedAt
+	if err := importJobDB.SaveImportJob(job); err != nil {
+		captureSaveError(ctx, job, err)
+	}
+	return job
+}
+
+// captureSaveError reports a failure to persist the state of a job
+func captureSaveError(ctx context.Context, job importJobModel.ImportJob, err error) {
+	sentry.CaptureEvent(&sentry.Event{
+		Message: fmt.Sprintf("Failed to save import job %d: %v", job.Id, err),
+		Level:   sentry.LevelError,
+		Extra: map[string]interface{}{
+			"board_id": job.BoardID,
+			"status":   job.Status,
+		},
+		Tags: map[string]string{
+			"component":  "feedbackImport",
+			"action":     "save_import_job",
+			"request_id": logging.RequestID(ctx),
+		},
+	})
+}
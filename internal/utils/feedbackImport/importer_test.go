@@ -0,0 +1,198 @@
+package feedbackImport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	importJobModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/ImportJob"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/utils/background"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) sqlmock.Sqlmock {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if err != nil {
+		t.Fatalf("Failed to create mock database: %v", err)
+	}
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn:                 db,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	original := database.DB
+	database.DB = gormDB
+	t.Cleanup(func() {
+		database.DB = original
+		db.Close()
+	})
+	return mock
+}
+
+func TestImporter_NoValidFeedback(t *testing.T) {
+	mock := setupMockDB(t)
+
+	mock.ExpectQuery(`INSERT INTO "import_jobs"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	mock.ExpectExec(`UPDATE "import_jobs"`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	importer, err := Start(context.Background(), 1, "feedbacks.json", "json", "owner@example.com", "")
+	assert.NoError(t, err)
+
+	assert.NoError(t, importer.Add(1, feedbackModel.FeedbackJson{Channel: "email"}))
+	importer.Reject(2, errors.New("invalid date"))
+
+	job, err := importer.Commit()
+	assert.ErrorIs(t, err, ErrNoValidFeedback)
+	assert.Equal(t, 4, job.Id)
+	assert.Equal(t, importJobModel.StatusFailed, job.Status)
+	assert.Equal(t, 2, job.TotalRows)
+	assert.Equal(t, 2, job.ErrorCount)
+	assert.Equal(t, []string{"Row 1: missing required fields", "Row 2: invalid date"}, job.Errors)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImporter_FlushesFullBatches(t *testing.T) {
+	mock := setupMockDB(t)
+
+	mock.ExpectQuery(`INSERT INTO "import_jobs"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectBegin()
+	rows := sqlmock.NewRows([]string{"id"})
+	for i := 1; i <= BatchSize; i++ {
+		rows.AddRow(i)
+	}
+	mock.ExpectQuery(`SELECT "id","text","external_id","content_hash","deleted_at" FROM "feedbacks" WHERE board_id = \$1 AND content_hash IN`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(`INSERT INTO "feedbacks"`).WillReturnRows(rows)
+
+	importer, err := Start(context.Background(), 1, "feedbacks.ndjson", "ndjson", "owner@example.com", "")
+	assert.NoError(t, err)
+
+	for i := 1; i <= BatchSize+1; i++ {
+		err := importer.Add(i, feedbackModel.FeedbackJson{Date: time.Now(), Channel: "email", Text: fmt.Sprintf("text %d", i)})
+		assert.NoError(t, err)
+	}
+
+	// One batch was inserted, the last row is still queued
+	assert.Len(t, importer.imported, BatchSize)
+	assert.Len(t, importer.batch, 1)
+	assert.Equal(t, BatchSize, importer.imported[BatchSize-1].feedback.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddJobError_Capped(t *testing.T) {
+	job := importJobModel.ImportJob{Errors: []string{}}
+	for i := 0; i < maxReportedErrors+5; i++ {
+		addJobError(&job, i+1, "bad row")
+	}
+	assert.Equal(t, maxReportedErrors+5, job.ErrorCount)
+	assert.Len(t, job.Errors, maxReportedErrors)
+}
+
+func TestImportJobProgress(t *testing.T) {
+	assert.Equal(t, float64(0), importJobModel.ImportJob{Status: importJobModel.StatusImporting}.Progress())
+	assert.Equal(t, float64(50), importJobModel.ImportJob{Status: importJobModel.StatusAnalyzing, ImportedCount: 10, AnalyzedCount: 5}.Progress())
+	assert.Equal(t, float64(100), importJobModel.ImportJob{Status: importJobModel.StatusCompleted}.Progress())
+}
+
+func TestImporter_SkipsDuplicates(t *testing.T) {
+	mock := setupMockDB(t)
+
+	mock.ExpectQuery(`INSERT INTO "import_jobs"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectBegin()
+
+	importer, err := Start(context.Background(), 1, "feedbacks.json", "json", "owner@example.com", "retry-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "retry-1", *importer.Job().IdempotencyKey)
+
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	stored := feedbackModel.FeedbackJson{Date: date, Channel: "email", Text: "Already there"}
+	hash := feedbackDB.ContentHash(feedbackModel.Feedback{Date: date, Channel: "email", Text: "Already there"})
+
+	// Every row is already stored, so the job completes without inserting nor analyzing
+	mock.ExpectQuery(`SELECT (.+) FROM "feedbacks" WHERE board_id = \$1`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "text", "content_hash"}).AddRow(9, "Already there", hash))
+	mock.ExpectExec(`UPDATE "import_jobs"`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, importer.Add(1, stored))
+	assert.NoError(t, importer.Add(2, stored))
+
+	job, err := importer.Commit()
+	assert.NoError(t, err)
+	assert.Equal(t, importJobModel.StatusCompleted, job.Status)
+	assert.Equal(t, 2, job.SkippedCount)
+	assert.Equal(t, 0, job.ImportedCount)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAnalyze_Interrupted(t *testing.T) {
+	mock := setupMockDB(t)
+
+	mock.ExpectExec(`UPDATE "import_jobs" SET (.+)"status"=\$[0-9]+`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), importJobModel.StatusInterrupted,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// The shutdown stopped the analysis before its first feedback
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	job := importJobModel.ImportJob{Status: importJobModel.StatusAnalyzing, ImportedCount: 2}
+	job.Id = 5
+	rows := []importedRow{{row: 1, feedback: feedbackModel.Feedback{Text: "Slow"}}, {row: 2, feedback: feedbackModel.Feedback{Text: "Great"}}}
+
+	job = analyze(ctx, job, rows, "owner@example.com")
+	assert.Equal(t, importJobModel.StatusInterrupted, job.Status)
+	assert.Equal(t, 0, job.AnalyzedCount)
+	assert.Equal(t, 0, job.ErrorCount)
+	assert.Nil(t, job.FinishedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResume(t *testing.T) {
+	mock := setupMockDB(t)
+
+	startedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT \* FROM "import_jobs" WHERE status = \$1`).
+		WithArgs(importJobModel.StatusInterrupted).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "board_id", "status", "imported_count", "analyzed_count", "started_at"}).
+			AddRow(3, 1, importJobModel.StatusInterrupted, 2, 2, startedAt).
+			AddRow(4, 1, importJobModel.StatusInterrupted, 1, 0, startedAt))
+	// Another replica resumed the first job
+	mock.ExpectExec(`UPDATE "import_jobs" SET "status"=\$1,"updated_at"=\$2 WHERE id = \$3 AND status = \$4`).
+		WithArgs(importJobModel.StatusAnalyzing, sqlmock.AnyArg(), 3, importJobModel.StatusInterrupted).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE "import_jobs" SET "status"=\$1,"updated_at"=\$2 WHERE id = \$3 AND status = \$4`).
+		WithArgs(importJobModel.StatusAnalyzing, sqlmock.AnyArg(), 4, importJobModel.StatusInterrupted).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// Every feedback was analyzed before the shutdown saved the job
+	mock.ExpectQuery(`SELECT \* FROM "feedbacks" WHERE \(board_id = \$1 AND updated_at >= \$2\) AND NOT EXISTS \(SELECT 1 FROM analyses WHERE analyses.feedback_id = feedbacks.id\)`).
+		WithArgs(1, startedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(`SELECT \* FROM "boards" WHERE id = \$1`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec(`UPDATE "import_jobs" SET (.+)"status"=\$[0-9]+`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, Resume(context.Background()))
+	assert.NoError(t, background.Drain(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
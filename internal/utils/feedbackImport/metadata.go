@@ -0,0 +1,29 @@
+package feedbackImport
+
+import (
+	boardDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
+	boardModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Board"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+)
+
+// metadataValidator checks the metadata of the rows against the board's schema, loaded on the first row having metadata
+type metadataValidator struct {
+	boardID int
+	schema  boardModel.MetadataSchema
+	loaded  bool
+}
+
+func (v *metadataValidator) validate(metadata feedbackModel.Metadata) (feedbackModel.Metadata, error) {
+	if len(metadata) == 0 {
+		return metadata, nil
+	}
+	if !v.loaded {
+		schema, err := boardDB.GetMetadataSchema(v.boardID)
+		if err != nil {
+			return nil, err
+		}
+		v.schema = schema
+		v.loaded = true
+	}
+	return v.schema.Validate(metadata)
+}
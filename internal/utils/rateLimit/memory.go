@@ -0,0 +1,75 @@
+package rateLimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is the number of requests between two removals of the idle keys
+const sweepInterval = 1000
+
+// MemoryStore keeps the time of the requests of each key in the window, for a single replica
+type MemoryStore struct {
+	mu      sync.Mutex
+	windows map[string]*memoryWindow
+	calls   int
+}
+
+type memoryWindow struct {
+	hits   []time.Time
+	length time.Duration
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{windows: make(map[string]*memoryWindow)}
+}
+
+func (s *MemoryStore) Allow(_ context.Context, key string, rule Rule, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if s.calls%sweepInterval == 0 {
+		s.sweep(now)
+	}
+
+	w, ok := s.windows[key]
+	if !ok {
+		w = &memoryWindow{}
+		s.windows[key] = w
+	}
+	w.length = rule.Window
+	hits := inWindow(w.hits, now, rule.Window)
+	allowed := len(hits) < rule.Limit
+	if allowed {
+		hits = append(hits, now)
+	}
+	w.hits = hits
+	return Result{
+		Allowed:   allowed,
+		Limit:     rule.Limit,
+		Remaining: rule.Limit - len(hits),
+		Reset:     hits[0].Add(rule.Window).Sub(now),
+	}, nil
+}
+
+// sweep removes the keys whose requests are all older than their window
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, w := range s.windows {
+		if len(inWindow(w.hits, now, w.length)) == 0 {
+			delete(s.windows, key)
+		}
+	}
+}
+
+// inWindow drops the requests older than the window, hits being sorted
+func inWindow(hits []time.Time, now time.Time, window time.Duration) []time.Time {
+	start := now.Add(-window)
+	for i, hit := range hits {
+		if hit.After(start) {
+			return hits[i:]
+		}
+	}
+	return hits[:0]
+}
@@ -0,0 +1,164 @@
+package rateLimit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+)
+
+// keyPrefix namespaces the sliding windows in Redis
+const keyPrefix = "rateLimit:"
+
+// redisTimeout bounds the wait on Redis before falling back to memory
+const redisTimeout = 250 * time.Millisecond
+
+// Rule allows Limit requests per sliding Window, the zero Rule allows every request
+type Rule struct {
+	Limit  int
+	Window time.Duration
+}
+
+// Kinds of keys a Policy can limit differently, a key is its kind followed by a colon and the client
+const (
+	KindIP     = "ip"
+	KindUser   = "user"
+	KindAPIKey = "apikey"
+	KindBoard  = "board"
+)
+
+var kinds = []string{KindIP, KindUser, KindAPIKey, KindBoard}
+
+// Policy is the Rule of a route group, Kinds overriding it for some kinds of keys
+type Policy struct {
+	Default Rule
+	Kinds   map[string]Rule
+}
+
+// Result is the decision for a request along with the state of its window
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the oldest request of the window leaves it, freeing a slot
+	Reset time.Duration
+}
+
+// Store counts the requests of a key in a sliding window, the rule being enabled
+type Store interface {
+	Allow(ctx context.Context, key string, rule Rule, now time.Time) (Result, error)
+}
+
+// fallback is used without Redis, or when it fails, the windows are then per replica
+var fallback = NewMemoryStore()
+
+// ParseRule reads a rule such as 10/1m, off disabling the limit
+func ParseRule(value string) (Rule, error) {
+	if value == "off" {
+		return Rule{}, nil
+	}
+	limit, window, ok := strings.Cut(value, "/")
+	n, err := strconv.Atoi(limit)
+	if !ok || err != nil || n <= 0 {
+		return Rule{}, fmt.Errorf("invalid rate limit %q: expected a limit and a window such as 10/1m, or off", value)
+	}
+	d, err := time.ParseDuration(window)
+	if err != nil || d < time.Second {
+		return Rule{}, fmt.Errorf("invalid rate limit %q: the window must be a duration of at least 1s", value)
+	}
+	return Rule{Limit: n, Window: d}, nil
+}
+
+// Enabled reports whether the rule limits the requests
+func (r Rule) Enabled() bool {
+	return r.Limit > 0
+}
+
+func (r Rule) String() string {
+	if !r.Enabled() {
+		return "off"
+	}
+	return strconv.Itoa(r.Limit) + "/" + r.Window.String()
+}
+
+// ParsePolicy reads a default rule followed by rules for some kinds of keys, such as 20/1h,apikey:200/1h,ip:off
+func ParsePolicy(value string) (Policy, error) {
+	parts := strings.Split(value, ",")
+	def, err := ParseRule(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return Policy{}, err
+	}
+	policy := Policy{Default: def}
+	for _, part := range parts[1:] {
+		kind, value, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok || !slices.Contains(kinds, kind) {
+			return Policy{}, fmt.Errorf("invalid rate limit %q: expected kind:rule with a kind among %s", part, strings.Join(kinds, ", "))
+		}
+		rule, err := ParseRule(value)
+		if err != nil {
+			return Policy{}, err
+		}
+		if policy.Kinds == nil {
+			policy.Kinds = map[string]Rule{}
+		}
+		policy.Kinds[kind] = rule
+	}
+	return policy, nil
+}
+
+// Rule returns the rule of a key, the rule of its kind if the policy has one
+func (p Policy) Rule(key string) Rule {
+	kind, _, _ := strings.Cut(key, ":")
+	if rule, ok := p.Kinds[kind]; ok {
+		return rule
+	}
+	return p.Default
+}
+
+// Enabled reports whether the policy limits the requests of some kind of keys
+func (p Policy) Enabled() bool {
+	if p.Default.Enabled() {
+		return true
+	}
+	for _, rule := range p.Kinds {
+		if rule.Enabled() {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Policy) String() string {
+	parts := []string{p.Default.String()}
+	for _, kind := range kinds {
+		if rule, ok := p.Kinds[kind]; ok {
+			parts = append(parts, kind+":"+rule.String())
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// Allow records a request of the key if the rule lets it through.
+// The windows are shared by the replicas in Redis, the in-memory store takes over when Redis is not configured or fails.
+func Allow(ctx context.Context, key string, rule Rule) Result {
+	if !rule.Enabled() {
+		return Result{Allowed: true}
+	}
+	now := time.Now()
+	if database.RedisClient != nil {
+		redisCtx, cancel := context.WithTimeout(ctx, redisTimeout)
+		result, err := NewRedisStore(database.RedisClient).Allow(redisCtx, key, rule, now)
+		cancel()
+		if err == nil {
+			return result
+		}
+		slog.WarnContext(ctx, "Rate limiter falling back to memory", "error", err)
+	}
+	result, _ := fallback.Allow(ctx, key, rule, now)
+	return result
+}
@@ -0,0 +1,85 @@
+package rateLimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database"
+)
+
+func TestParseRule(t *testing.T) {
+	rule, err := ParseRule("10/1m")
+	assert.NoError(t, err)
+	assert.Equal(t, Rule{Limit: 10, Window: time.Minute}, rule)
+	assert.Equal(t, "10/1m0s", rule.String())
+
+	rule, err = ParseRule("off")
+	assert.NoError(t, err)
+	assert.False(t, rule.Enabled())
+
+	for _, value := range []string{"10", "0/1m", "ten/1m", "10/soon", "10/500ms"} {
+		_, err := ParseRule(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := ParsePolicy("20/1h, apikey:200/1h,ip:off")
+	assert.NoError(t, err)
+	assert.Equal(t, Rule{Limit: 20, Window: time.Hour}, policy.Rule("user:uuid"))
+	assert.Equal(t, Rule{Limit: 200, Window: time.Hour}, policy.Rule("apikey:4"))
+	assert.False(t, policy.Rule("ip:1.2.3.4").Enabled())
+	assert.True(t, policy.Enabled())
+	assert.Equal(t, "20/1h0m0s,ip:off,apikey:200/1h0m0s", policy.String())
+
+	policy, err = ParsePolicy("off,board:5/1m")
+	assert.NoError(t, err)
+	assert.True(t, policy.Enabled())
+	assert.False(t, policy.Rule("user:uuid").Enabled())
+
+	for _, value := range []string{"", "10", "20/1h,5/1m", "20/1h,token:5/1m", "20/1h,ip:0/1m"} {
+		_, err := ParsePolicy(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+	rule := Rule{Limit: 2, Window: time.Minute}
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	result, _ := store.Allow(context.Background(), "ip:1", rule, start)
+	assert.Equal(t, Result{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Minute}, result)
+	result, _ = store.Allow(context.Background(), "ip:1", rule, start.Add(20*time.Second))
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	// The window slides, the first request frees its slot a minute after it was made
+	result, _ = store.Allow(context.Background(), "ip:1", rule, start.Add(30*time.Second))
+	assert.Equal(t, Result{Allowed: false, Limit: 2, Remaining: 0, Reset: 30 * time.Second}, result)
+	result, _ = store.Allow(context.Background(), "ip:1", rule, start.Add(61*time.Second))
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 19*time.Second, result.Reset)
+
+	// Keys are counted apart
+	result, _ = store.Allow(context.Background(), "ip:2", rule, start.Add(30*time.Second))
+	assert.True(t, result.Allowed)
+
+	store.sweep(start.Add(time.Hour))
+	assert.Empty(t, store.windows)
+}
+
+func TestAllow_FallsBackToMemory(t *testing.T) {
+	previous := database.RedisClient
+	t.Cleanup(func() { database.RedisClient = previous })
+	// Nothing listens on the port, every Redis call fails
+	database.RedisClient = redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+
+	rule := Rule{Limit: 1, Window: time.Minute}
+	assert.True(t, Allow(context.Background(), "test:fallback", rule).Allowed)
+	assert.False(t, Allow(context.Background(), "test:fallback", rule).Allowed)
+	assert.True(t, Allow(context.Background(), "test:fallback", Rule{}).Allowed)
+}
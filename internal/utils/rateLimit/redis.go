@@ -0,0 +1,53 @@
+package rateLimit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// slidingWindow trims the sorted set of the key to the window, adds the request when there is room,
+// then returns whether it was added, the count in the window and the milliseconds until the oldest leaves it
+var slidingWindow = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[4])
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', KEYS[1], window)
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+return {allowed, count, tonumber(oldest[2]) + window - now}
+`)
+
+// RedisStore keeps the requests of each key in a sorted set, shared by the replicas
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) Allow(ctx context.Context, key string, rule Rule, now time.Time) (Result, error) {
+	values, err := slidingWindow.Run(ctx, s.client, []string{keyPrefix + key},
+		now.UnixMilli(), rule.Window.Milliseconds(), rule.Limit, strconv.FormatInt(now.UnixMilli(), 10)+"-"+uuid.NewString(),
+	).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{
+		Allowed:   values[0] == 1,
+		Limit:     rule.Limit,
+		Remaining: rule.Limit - int(values[1]),
+		Reset:     time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
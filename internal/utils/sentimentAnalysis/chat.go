@@ -0,0 +1,71 @@
+package sentimentAnalysis
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gage-technologies/mistral-go"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/logging"
+)
+
+// chatEndpoint is the chat completions endpoint of Mistral, replaced by the tests
+var chatEndpoint = mistral.Endpoint + "/v1/chat/completions"
+
+// httpClient sends the request ID of the context along with the chat calls
+var httpClient = &http.Client{
+	Timeout:   mistral.DefaultTimeout,
+	Transport: logging.NewTransport(nil),
+}
+
+// chat runs a single chat completion, the retries are left to the caller.
+// mistral-go builds its requests without a context, so the calls carrying the request ID are made here.
+func chat(ctx context.Context, model string, messages []mistral.ChatMessage, params *mistral.ChatRequestParams) (*mistral.ChatCompletionResponse, error) {
+	if apiKey == "" {
+		return nil, ErrNotInitialized
+	}
+	payload := map[string]interface{}{
+		"model":       model,
+		"messages":    messages,
+		"temperature": params.Temperature,
+		"max_tokens":  params.MaxTokens,
+		"top_p":       params.TopP,
+		"random_seed": params.RandomSeed,
+		"safe_prompt": params.SafePrompt,
+	}
+	if params.ResponseFormat != "" {
+		payload["response_format"] = map[string]interface{}{"type": params.ResponseFormat}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, chatEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("(HTTP Error %d) %s", resp.StatusCode, message)
+	}
+	var response mistral.ChatCompletionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	if len(response.Choices) == 0 {
+		return nil, fmt.Errorf("no choice in the response of Mistral")
+	}
+	return &response, nil
+}
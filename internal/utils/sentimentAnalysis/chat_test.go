@@ -0,0 +1,55 @@
+package sentimentAnalysis
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gage-technologies/mistral-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/logging"
+)
+
+func setupChat(t *testing.T, handler http.HandlerFunc) {
+	server := httptest.NewServer(handler)
+	previousEndpoint, previousKey := chatEndpoint, apiKey
+	chatEndpoint, apiKey = server.URL, "test-key"
+	t.Cleanup(func() {
+		server.Close()
+		chatEndpoint, apiKey = previousEndpoint, previousKey
+	})
+}
+
+func TestChat(t *testing.T) {
+	setupChat(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+		assert.Equal(t, "req-42", r.Header.Get(logging.HeaderRequestID))
+		var payload map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "mistral-large-latest", payload["model"])
+		assert.Equal(t, map[string]interface{}{"type": "json_object"}, payload["response_format"])
+
+		w.Write([]byte(`{"id":"1","choices":[{"index":0,"message":{"role":"assistant","content":"{\"topic\":\"Performance\",\"sentiment_score\":0.5}"}}]}`))
+	})
+
+	ctx := logging.WithRequestID(context.Background(), "req-42")
+	response, err := chat(ctx, "mistral-large-latest", []mistral.ChatMessage{{Role: mistral.RoleUser, Content: "Fast"}},
+		&mistral.ChatRequestParams{ResponseFormat: mistral.ResponseFormatJsonObject})
+	assert.NoError(t, err)
+	assert.Contains(t, response.Choices[0].Message.Content, "Performance")
+}
+
+func TestChat_Errors(t *testing.T) {
+	setupChat(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte("rate limited"))
+	})
+	_, err := chat(context.Background(), "mistral-large-latest", nil, &mistral.ChatRequestParams{})
+	assert.EqualError(t, err, "(HTTP Error 429) rate limited")
+
+	apiKey = ""
+	_, err = chat(context.Background(), "mistral-large-latest", nil, &mistral.ChatRequestParams{})
+	assert.ErrorIs(t, err, ErrNotInitialized)
+}
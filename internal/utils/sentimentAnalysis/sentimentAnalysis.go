@@ -1,25 +1,43 @@
 package sentimentAnalysis
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/gage-technologies/mistral-go"
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/metrics"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Analysis"
 	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
 	gomail "gopkg.in/mail.v2"
 )
 
 var client *mistral.MistralClient
+var apiKey string
 var dialer *gomail.Dialer
 
-func InitSentimentAnalysis(apiKey string, emailPass string) {
-	client = mistral.NewMistralClientDefault(apiKey)
+var ErrNotInitialized = errors.New("sentiment analysis is not initialized")
+
+func InitSentimentAnalysis(mistralAPIKey string, emailPass string) {
+	apiKey = mistralAPIKey
+	client = mistral.NewMistralClientDefault(mistralAPIKey)
 	dialer = gomail.NewDialer("mail.lucamorgado.com", 465, "noreply-feedpulse@lucamorgado.com", emailPass)
 }
 
-func SentimentAnalysis(feedback Feedback.Feedback, userEmail string) (Analysis.Analysis, error) {
+// Ping checks that the Mistral API answers with the configured key, without running an analysis
+func Ping() error {
+	if client == nil {
+		return ErrNotInitialized
+	}
+	_, err := client.ListModels()
+	return err
+}
+
+// SentimentAnalysis scores and classifies the feedback, the request ID of ctx is sent along with the Mistral calls
+func SentimentAnalysis(ctx context.Context, feedback Feedback.Feedback, userEmail string) (Analysis.Analysis, error) {
 	// Example: Using Chat Completions
 	maxRetries := 10
 	retryCount := 0
@@ -29,7 +47,8 @@ func SentimentAnalysis(feedback Feedback.Feedback, userEmail string) (Analysis.A
 		retryCount++
 		retry = false
 		var err error
-		chatRes, err = client.Chat("mistral-large-latest", []mistral.ChatMessage{
+		start := time.Now()
+		chatRes, err = chat(ctx, "mistral-large-latest", []mistral.ChatMessage{
 			{Content: "I will give you in input sentence, you need to analyse this sentence, give it a score between -1 and +1 (negative,neutral and positive), also give it a topic in french among thoese : 'Support Client,Tarifs et Valeur,Interface Utilisateur,Bugs et Problèmes Techniques,Documentation,Performance,Personnalisation,Processus d’Inscription,Fonctionnalités Avancées,Expérience Utilisateur Générale' and output this in the json format like : \n{\n    \"topic\":\"the theme of the sentence\",\n    \"sentiment_score\":0\n}", Role: mistral.RoleSystem},
 			{Content: "Sure, please provide the sentence you'd like me to analyze.", Role: mistral.RoleAssistant},
 			{Content: feedback.Text, Role: mistral.RoleUser},
@@ -42,8 +61,16 @@ func SentimentAnalysis(feedback Feedback.Feedback, userEmail string) (Analysis.A
 				SafePrompt:     false,
 				ResponseFormat: mistral.ResponseFormatJsonObject,
 			})
+		metrics.ObserveAnalyzerCall(time.Since(start), err)
+		if errors.Is(err, ErrNotInitialized) {
+			return Analysis.Analysis{}, err
+		}
 		if err != nil {
+			slog.WarnContext(ctx, "Mistral call failed", "feedback_id", feedback.Id, "attempt", retryCount, "error", err)
 			retry = true
+			if retryCount < maxRetries {
+				metrics.AnalyzerRetry()
+			}
 			// Tempo to avoid hitting the API too quickly
 			time.Sleep(time.Second * 5)
 		}
@@ -63,16 +90,25 @@ func SentimentAnalysis(feedback Feedback.Feedback, userEmail string) (Analysis.A
 	// if the score is below -0,5 send an email
 	if analysis.SentimentScore <= -0.5 {
 		// Send an email to the user
-		m := gomail.NewMessage()
-		m.SetHeader("From", "noreply-feedpulse@lucamorgado.com")
-		m.SetHeader("To", userEmail)
-		m.SetHeader("Subject", "Negative Feedback Alert")
-		m.SetBody("text/plain", fmt.Sprintf("Dear User,\n\nWe noticed that one of your submitted feedback has a negative sentiment score of %.2f.\n\nFeedback: %s\n\nPlease take a moment to review it.\n\nBest regards,\nFeedPulse Team", analysis.SentimentScore, feedback.Text))
-
-		// Send the email
-		if err := dialer.DialAndSend(m); err != nil {
-			fmt.Printf("Failed to send email: %v\n", err)
+		body := fmt.Sprintf("Dear User,\n\nWe noticed that one of your submitted feedback has a negative sentiment score of %.2f.\n\nFeedback: %s\n\nPlease take a moment to review it.\n\nBest regards,\nFeedPulse Team", analysis.SentimentScore, feedback.Text)
+		if err := SendEmail(userEmail, "Negative Feedback Alert", body); err != nil {
+			slog.WarnContext(ctx, "Failed to send the negative feedback alert", "feedback_id", feedback.Id, "error", err)
 		}
 	}
 	return analysis, nil
 }
+
+// SendEmail sends a plain text email from the FeedPulse address
+func SendEmail(to, subject, body string) error {
+	if dialer == nil {
+		return errors.New("email dialer is not initialized")
+	}
+	m := gomail.NewMessage()
+	m.SetHeader("From", "noreply-feedpulse@lucamorgado.com")
+	m.SetHeader("To", to)
+	m.SetHeader("Subject", subject)
+	m.SetBody("text/plain", body)
+	err := dialer.DialAndSend(m)
+	metrics.ObserveEmail(err)
+	return err
+}
@@ -0,0 +1,183 @@
+package sourceSync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/logging"
+	customerModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Customer"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	sourceModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Source"
+)
+
+// Item is a feedback read from a source along with its identifier in that source
+type Item struct {
+	ExternalID string
+	Feedback   feedbackModel.Feedback
+}
+
+// comment represents the JSONPlaceholder comment structure
+type comment struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Body  string `json:"body"`
+}
+
+// httpClient sends the request ID of the context along with the calls to the sources
+var httpClient = &http.Client{
+	Timeout:   15 * time.Second,
+	Transport: logging.NewTransport(nil),
+}
+
+// FetchItems reads every item currently exposed by the source
+func FetchItems(ctx context.Context, source sourceModel.Source) ([]Item, error) {
+	switch source.Type {
+	case sourceModel.TypeJSONPlaceholder:
+		return fetchJSONPlaceholder(ctx, source)
+	case sourceModel.TypeJSON:
+		return fetchJSON(ctx, source)
+	default:
+		return nil, fmt.Errorf("unsupported source type %q", source.Type)
+	}
+}
+
+// IsSupportedType reports whether FetchItems knows how to read the given source type
+func IsSupportedType(sourceType string) bool {
+	return sourceType == sourceModel.TypeJSONPlaceholder || sourceType == sourceModel.TypeJSON
+}
+
+func fetchBody(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to source: %v", err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to source: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("source returned error: %d %s", resp.StatusCode, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func fetchJSONPlaceholder(ctx context.Context, source sourceModel.Source) ([]Item, error) {
+	body, err := fetchBody(ctx, source.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []comment
+	if err := json.Unmarshal(body, &comments); err != nil {
+		return nil, fmt.Errorf("invalid JSON format in source response: %v", err)
+	}
+
+	now := time.Now().UTC()
+	items := make([]Item, len(comments))
+	for i, c := range comments {
+		externalID := strconv.Itoa(c.ID)
+		items[i] = Item{
+			ExternalID: externalID,
+			Feedback: feedbackModel.Feedback{
+				Date:       now,
+				Channel:    "web",
+				Text:       c.Body,
+				BoardID:    source.BoardID,
+				ExternalID: &externalID,
+				Customer:   &customerModel.CustomerJson{Name: c.Name, Email: c.Email},
+			},
+		}
+	}
+	return items, nil
+}
+
+func fetchJSON(ctx context.Context, source sourceModel.Source) ([]Item, error) {
+	body, err := fetchBody(ctx, source.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	var feedbacksJson []feedbackModel.FeedbackJson
+	if err := json.Unmarshal(body, &feedbacksJson); err != nil {
+		return nil, fmt.Errorf("invalid JSON format in source response: %v", err)
+	}
+
+	items := make([]Item, len(feedbacksJson))
+	for i, f := range feedbacksJson {
+		items[i] = Item{
+			Feedback: feedbackModel.Feedback{
+				Date:        f.Date,
+				Channel:     f.Channel,
+				Text:        f.Text,
+				BoardID:     source.BoardID,
+				Customer:    f.Customer,
+				Metadata:    f.Metadata,
+				Rating:      f.Rating,
+				RatingScale: f.RatingScale,
+			},
+		}
+		if f.ExternalID != "" {
+			externalID := f.ExternalID
+			items[i].Feedback.ExternalID = &externalID
+		}
+	}
+	return items, nil
+}
+
+// compareExternalID orders external IDs numerically when both are numbers, lexically otherwise
+func compareExternalID(a, b string) int {
+	ai, errA := strconv.ParseInt(a, 10, 64)
+	bi, errB := strconv.ParseInt(b, 10, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case ai < bi:
+			return -1
+		case ai > bi:
+			return 1
+		}
+		return 0
+	}
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// filterNewItems keeps the items past the source cursor, sorted oldest first.
+// Items carrying an external ID are compared to CursorExternalID, the others to CursorDate.
+func filterNewItems(items []Item, source sourceModel.Source) []Item {
+	newItems := make([]Item, 0, len(items))
+	for _, item := range items {
+		if item.ExternalID != "" {
+			if source.CursorExternalID == "" || compareExternalID(item.ExternalID, source.CursorExternalID) > 0 {
+				newItems = append(newItems, item)
+			}
+			continue
+		}
+		if source.CursorDate == nil || item.Feedback.Date.After(*source.CursorDate) {
+			newItems = append(newItems, item)
+		}
+	}
+
+	sort.SliceStable(newItems, func(i, j int) bool {
+		a, b := newItems[i], newItems[j]
+		if a.ExternalID != "" && b.ExternalID != "" {
+			return compareExternalID(a.ExternalID, b.ExternalID) < 0
+		}
+		return a.Feedback.Date.Before(b.Feedback.Date)
+	})
+	return newItems
+}
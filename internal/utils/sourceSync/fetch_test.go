@@ -0,0 +1,98 @@
+package sourceSync
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	feedbackModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Feedback"
+	sourceModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Source"
+)
+
+func TestFetchItems_JSONPlaceholder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"postId":1,"id":1,"name":"a","email":"a@b.c","body":"first"},{"postId":1,"id":2,"name":"b","email":"b@b.c","body":"second"}]`))
+	}))
+	defer server.Close()
+
+	items, err := FetchItems(context.Background(), sourceModel.Source{Type: sourceModel.TypeJSONPlaceholder, URL: server.URL, BoardID: 3})
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.Equal(t, "1", items[0].ExternalID)
+	assert.Equal(t, "first", items[0].Feedback.Text)
+	assert.Equal(t, 3, items[0].Feedback.BoardID)
+}
+
+func TestFetchItems_JSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"date":"2024-01-02T00:00:00Z","channel":"email","text":"hello"}]`))
+	}))
+	defer server.Close()
+
+	items, err := FetchItems(context.Background(), sourceModel.Source{Type: sourceModel.TypeJSON, URL: server.URL, BoardID: 1})
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Empty(t, items[0].ExternalID)
+	assert.Equal(t, "email", items[0].Feedback.Channel)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), items[0].Feedback.Date)
+}
+
+func TestFetchItems_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, err := FetchItems(context.Background(), sourceModel.Source{Type: sourceModel.TypeJSON, URL: server.URL})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "source returned error")
+
+	_, err = FetchItems(context.Background(), sourceModel.Source{Type: "ftp", URL: server.URL})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported source type")
+}
+
+func TestFilterNewItems_ExternalIDCursor(t *testing.T) {
+	items := []Item{
+		{ExternalID: "10"},
+		{ExternalID: "2"},
+		{ExternalID: "9"},
+		{ExternalID: "11"},
+	}
+
+	newItems := filterNewItems(items, sourceModel.Source{CursorExternalID: "9"})
+	assert.Len(t, newItems, 2)
+	assert.Equal(t, "10", newItems[0].ExternalID)
+	assert.Equal(t, "11", newItems[1].ExternalID)
+
+	// Without a cursor everything is new and sorted numerically
+	newItems = filterNewItems(items, sourceModel.Source{})
+	assert.Len(t, newItems, 4)
+	assert.Equal(t, "2", newItems[0].ExternalID)
+	assert.Equal(t, "11", newItems[3].ExternalID)
+}
+
+func TestFilterNewItems_DateCursor(t *testing.T) {
+	cursor := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	items := []Item{
+		{Feedback: feedbackModel.Feedback{Date: cursor.Add(48 * time.Hour)}},
+		{Feedback: feedbackModel.Feedback{Date: cursor}},
+		{Feedback: feedbackModel.Feedback{Date: cursor.Add(-time.Hour)}},
+		{Feedback: feedbackModel.Feedback{Date: cursor.Add(time.Hour)}},
+	}
+
+	newItems := filterNewItems(items, sourceModel.Source{CursorDate: &cursor})
+	assert.Len(t, newItems, 2)
+	assert.Equal(t, cursor.Add(time.Hour), newItems[0].Feedback.Date)
+	assert.Equal(t, cursor.Add(48*time.Hour), newItems[1].Feedback.Date)
+}
+
+func TestCompareExternalID(t *testing.T) {
+	assert.Equal(t, -1, compareExternalID("9", "10"))
+	assert.Equal(t, 1, compareExternalID("b", "a"))
+	assert.Equal(t, 0, compareExternalID("42", "42"))
+}
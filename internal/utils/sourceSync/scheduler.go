@@ -0,0 +1,124 @@
+package sourceSync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/robfig/cron/v3"
+	sourceDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Source"
+	sourceModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Source"
+)
+
+var Instance *Scheduler
+
+// Scheduler polls every enabled source on its cron expression
+type Scheduler struct {
+	cron    *cron.Cron
+	mu      sync.Mutex
+	entries map[int]cron.EntryID
+}
+
+// InitScheduler creates the scheduler, registers the scheduled sources and starts it
+func InitScheduler() error {
+	Instance = NewScheduler()
+	if err := Instance.Reload(); err != nil {
+		return err
+	}
+	Instance.cron.Start()
+	return nil
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		cron:    cron.New(),
+		entries: make(map[int]cron.EntryID),
+	}
+}
+
+// ValidateSchedule checks a standard 5 fields cron expression or a descriptor such as @hourly
+func ValidateSchedule(schedule string) error {
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		return fmt.Errorf("invalid schedule %q: %v", schedule, err)
+	}
+	return nil
+}
+
+// Reload replaces the registered jobs with the sources currently scheduled in the database
+func (s *Scheduler) Reload() error {
+	sources, err := sourceDB.GetScheduledSources()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, entryID := range s.entries {
+		s.cron.Remove(entryID)
+		delete(s.entries, id)
+	}
+
+	for _, source := range sources {
+		sourceID := source.Id
+		entryID, err := s.cron.AddFunc(source.Schedule, func() { runScheduled(sourceID) })
+		if err != nil {
+			slog.Warn("Skipping source with an invalid schedule", "source_id", sourceID, "schedule", source.Schedule, "error", err)
+			continue
+		}
+		s.entries[sourceID] = entryID
+	}
+	return nil
+}
+
+// Stop stops the scheduler, running jobs are not interrupted.
+// The returned context is done once they have returned.
+func (s *Scheduler) Stop() context.Context {
+	return s.cron.Stop()
+}
+
+// ReloadScheduler reloads the global scheduler if it has been started
+func ReloadScheduler() {
+	if Instance == nil {
+		return
+	}
+	if err := Instance.Reload(); err != nil {
+		slog.Error("Failed to reload source scheduler", "error", err)
+	}
+}
+
+func runScheduled(sourceID int) {
+	// Reload the source to get the cursor saved by the previous run
+	source, err := sourceDB.GetSourceByID(sourceID)
+	if err != nil {
+		slog.Error("Scheduled sync failed", "source_id", sourceID, "error", err)
+		return
+	}
+	if !source.Enabled {
+		return
+	}
+
+	run, err := SyncSource(context.Background(), source, TriggerSchedule)
+	if err != nil && !errors.Is(err, ErrSyncInProgress) {
+		sentry.CaptureEvent(&sentry.Event{
+			Message: fmt.Sprintf("Scheduled sync of source %d failed: %v", sourceID, err),
+			Level:   sentry.LevelError,
+			Extra: map[string]interface{}{
+				"source_id": sourceID,
+				"board_id":  source.BoardID,
+				"run_id":    run.Id,
+			},
+			Tags: map[string]string{
+				"job":    "sourceSync",
+				"action": "scheduled_sync",
+			},
+		})
+		return
+	}
+	if run.Status == sourceModel.SyncStatusPartial {
+		slog.Warn("Scheduled sync finished with errors", "source_id", sourceID, "run_id", run.Id, "errors", run.ErrorCount)
+	}
+}
@@ -0,0 +1,24 @@
+package sourceSync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSchedule(t *testing.T) {
+	assert.NoError(t, ValidateSchedule("*/15 * * * *"))
+	assert.NoError(t, ValidateSchedule("@hourly"))
+	assert.NoError(t, ValidateSchedule("@every 30m"))
+	assert.Error(t, ValidateSchedule("every minute"))
+	assert.Error(t, ValidateSchedule("* * *"))
+}
+
+func TestReloadScheduler_NotStarted(t *testing.T) {
+	original := Instance
+	Instance = nil
+	defer func() { Instance = original }()
+
+	// Must be a no-op when the scheduler has not been started
+	assert.NotPanics(t, ReloadScheduler)
+}
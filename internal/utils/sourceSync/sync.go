@@ -0,0 +1,113 @@
+package sourceSync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Board"
+	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+	sourceDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Source"
+	sourceModel "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/models/Source"
+)
+
+const (
+	// TriggerSchedule marks runs started by the scheduler
+	TriggerSchedule = "schedule"
+	// TriggerManual marks runs started through the API
+	TriggerManual = "manual"
+)
+
+var ErrSyncInProgress = errors.New("a sync is already running for this source")
+
+// sourceLocks prevents two runs of the same source from overlapping
+var sourceLocks sync.Map
+
+// SyncSource imports the items of a source that are past its cursor and records the run.
+// Items are saved oldest first and the cursor only moves up to the last item saved,
+// so a failure stops the run and the remaining items are retried on the next one.
+func SyncSource(ctx context.Context, source sourceModel.Source, trigger string) (sourceModel.SyncRun, error) {
+	lock, _ := sourceLocks.LoadOrStore(source.Id, &sync.Mutex{})
+	mu := lock.(*sync.Mutex)
+	if !mu.TryLock() {
+		return sourceModel.SyncRun{}, ErrSyncInProgress
+	}
+	defer mu.Unlock()
+
+	run, err := sourceDB.CreateSyncRun(sourceModel.SyncRun{
+		SourceID:  source.Id,
+		Trigger:   trigger,
+		Status:    sourceModel.SyncStatusRunning,
+		StartedAt: time.Now().UTC(),
+		Errors:    []string{},
+	})
+	if err != nil {
+		return sourceModel.SyncRun{}, fmt.Errorf("failed to record sync run: %w", err)
+	}
+
+	syncErr := runSync(ctx, source, &run)
+
+	finishedAt := time.Now().UTC()
+	run.FinishedAt = &finishedAt
+	run.ErrorCount = len(run.Errors)
+	switch {
+	case syncErr != nil:
+		run.Status = sourceModel.SyncStatusFailed
+	case run.ErrorCount > 0:
+		run.Status = sourceModel.SyncStatusPartial
+	default:
+		run.Status = sourceModel.SyncStatusSuccess
+	}
+	if err := sourceDB.SaveSyncRun(run); err != nil {
+		return run, fmt.Errorf("failed to save sync run: %w", err)
+	}
+	return run, syncErr
+}
+
+func runSync(ctx context.Context, source sourceModel.Source, run *sourceModel.SyncRun) error {
+	items, err := FetchItems(ctx, source)
+	if err != nil {
+		run.Errors = append(run.Errors, err.Error())
+		return err
+	}
+	run.FetchedCount = len(items)
+
+	newItems := filterNewItems(items, source)
+	run.SkippedCount = len(items) - len(newItems)
+	if len(newItems) == 0 {
+		return sourceDB.UpdateSourceCursor(source.Id, source.CursorDate, source.CursorExternalID, time.Now().UTC())
+	}
+
+	userEmail, err := Board.GetBoardNotificationEmail(source.BoardID)
+	if err != nil {
+		run.Errors = append(run.Errors, err.Error())
+		return err
+	}
+
+	cursorDate := source.CursorDate
+	cursorExternalID := source.CursorExternalID
+	for i, item := range newItems {
+		if item.Feedback.Channel == "" || item.Feedback.Text == "" {
+			run.SkippedCount++
+			run.Errors = append(run.Errors, fmt.Sprintf("Item #%d missing required fields", i+1))
+		} else if _, _, err := feedbackDB.CreateFeedback(ctx, item.Feedback, userEmail); errors.Is(err, feedbackDB.ErrDuplicateFeedback) {
+			run.SkippedCount++
+		} else if err != nil {
+			run.Errors = append(run.Errors, fmt.Sprintf("Item #%d: %s", i+1, err.Error()))
+			break
+		} else {
+			run.ImportedCount++
+		}
+
+		if item.ExternalID != "" {
+			cursorExternalID = item.ExternalID
+		} else {
+			date := item.Feedback.Date
+			cursorDate = &date
+		}
+	}
+
+	return sourceDB.UpdateSourceCursor(source.Id, cursorDate, cursorExternalID, time.Now().UTC())
+}
@@ -0,0 +1,76 @@
+package trashPurge
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/robfig/cron/v3"
+	feedbackDB "github.com/ynov-2025-m1-team6/Feed-Pulse-Back/internal/database/Feedback"
+)
+
+// DefaultRetention is how long deleted boards and feedbacks stay in the trash when no retention is configured
+const DefaultRetention = 30 * 24 * time.Hour
+
+// purgeSchedule is the cron expression of the purge job
+const purgeSchedule = "@hourly"
+
+// Retention is how long deleted boards and feedbacks stay in the trash before being purged
+var Retention = DefaultRetention
+
+var scheduler *cron.Cron
+
+// InitPurger sets the retention of the trash and starts purging it every hour
+func InitPurger(retention time.Duration) error {
+	if retention <= 0 {
+		return fmt.Errorf("invalid trash retention %s: must be positive", retention)
+	}
+	Retention = retention
+	scheduler = cron.New()
+	if _, err := scheduler.AddFunc(purgeSchedule, func() { Purge(time.Now()) }); err != nil {
+		return err
+	}
+	scheduler.Start()
+	return nil
+}
+
+// Stop stops the purge job, a running purge is not interrupted.
+// The returned context is done once it has returned.
+func Stop() context.Context {
+	if scheduler == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		return ctx
+	}
+	return scheduler.Stop()
+}
+
+// PurgeAt returns when an item deleted at the given time gets purged
+func PurgeAt(deletedAt time.Time) time.Time {
+	return deletedAt.Add(Retention)
+}
+
+// Purge permanently deletes what has been in the trash for longer than the retention
+func Purge(now time.Time) {
+	purged, err := feedbackDB.PurgeDeleted(now.Add(-Retention))
+	if err != nil {
+		sentry.CaptureEvent(&sentry.Event{
+			Message: fmt.Sprintf("Trash purge failed: %v", err),
+			Level:   sentry.LevelError,
+			Extra: map[string]interface{}{
+				"boards":    purged.Boards,
+				"feedbacks": purged.Feedbacks,
+			},
+			Tags: map[string]string{
+				"job":    "trashPurge",
+				"action": "purge_trash",
+			},
+		})
+		return
+	}
+	if purged.Boards > 0 || purged.Feedbacks > 0 {
+		slog.Info("Trash purged", "boards", purged.Boards, "feedbacks", purged.Feedbacks)
+	}
+}
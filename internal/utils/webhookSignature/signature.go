@@ -0,0 +1,61 @@
+package webhookSignature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// TimestampHeader carries the unix time at which the payload was signed
+	TimestampHeader = "X-FeedPulse-Timestamp"
+	// SignatureHeader carries the signature in the "sha256=<hex>" format
+	SignatureHeader = "X-FeedPulse-Signature"
+	// DefaultTolerance is the maximum accepted clock difference with the sender
+	DefaultTolerance = 5 * time.Minute
+)
+
+var (
+	ErrMissingSignature = errors.New("missing signature or timestamp header")
+	ErrInvalidTimestamp = errors.New("invalid timestamp")
+	ErrExpiredTimestamp = errors.New("timestamp outside of the tolerance window")
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// Sign returns the signature of a payload, an HMAC-SHA256 of "<timestamp>.<body>"
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a payload and that its timestamp is within tolerance of now
+func Verify(secret, timestamp, signature string, body []byte, now time.Time, tolerance time.Duration) error {
+	if timestamp == "" || signature == "" {
+		return ErrMissingSignature
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	signedAt := time.Unix(unix, 0)
+	if now.Sub(signedAt) > tolerance || signedAt.Sub(now) > tolerance {
+		return ErrExpiredTimestamp
+	}
+
+	if !strings.HasPrefix(signature, "sha256=") {
+		return ErrInvalidSignature
+	}
+	expected := Sign(secret, timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
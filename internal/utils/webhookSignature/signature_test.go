@@ -0,0 +1,36 @@
+package webhookSignature
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	// Reference value computed with: printf '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t,
+		"sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163",
+		Sign("secret", "1700000000", []byte("{}")))
+	assert.NotEqual(t, Sign("secret", "1700000000", []byte("{}")), Sign("other", "1700000000", []byte("{}")))
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"channel":"app","text":"hello"}`)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := Sign("secret", timestamp, body)
+
+	assert.NoError(t, Verify("secret", timestamp, signature, body, now, DefaultTolerance))
+	assert.NoError(t, Verify("secret", timestamp, signature, body, now.Add(4*time.Minute), DefaultTolerance))
+
+	assert.ErrorIs(t, Verify("secret", "", signature, body, now, DefaultTolerance), ErrMissingSignature)
+	assert.ErrorIs(t, Verify("secret", timestamp, "", body, now, DefaultTolerance), ErrMissingSignature)
+	assert.ErrorIs(t, Verify("secret", "yesterday", signature, body, now, DefaultTolerance), ErrInvalidTimestamp)
+	assert.ErrorIs(t, Verify("secret", timestamp, signature, body, now.Add(6*time.Minute), DefaultTolerance), ErrExpiredTimestamp)
+	assert.ErrorIs(t, Verify("secret", timestamp, signature, body, now.Add(-6*time.Minute), DefaultTolerance), ErrExpiredTimestamp)
+	assert.ErrorIs(t, Verify("wrong", timestamp, signature, body, now, DefaultTolerance), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify("secret", timestamp, signature, []byte(`{}`), now, DefaultTolerance), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify("secret", timestamp, signature[7:], body, now, DefaultTolerance), ErrInvalidSignature)
+}
@@ -70,24 +70,16 @@ function testFeedbackUpload(token) {
 
   const url = `${baseUrl}/api/feedbacks/upload`;
 
-  // Create a mock CSV content
-  const csvContent = `[
-    {
-      "id": "fb_001",
-      "date": "2025-04-14T10:30:00Z",
-      "channel": "twitter",
-      "text": "Le support client a été très réactif et efficace."
-    },
-    {
-      "id": "fb_002",
-      "date": "2025-04-14T11:00:00Z",
-      "channel": "facebook",
-      "text": "Je trouve les tarifs un peu élevés pour les fonctionnalités proposées."
-    }
-    ]`;
+  // Realistic feedbacks from the built-in generator, a new seed per iteration avoids uploading duplicates
+  const seed = __VU * 100000 + __ITER;
+  const generated = http.get(`${baseUrl}/api/feedbacks/generate?count=20&seed=${seed}`, {
+    headers: { 'Authorization': token },
+  });
+  if (generated.status !== 200) return false;
+  const jsonContent = JSON.stringify(JSON.parse(generated.body).data);
 
   const formData = {
-    file: http.file(csvContent, 'feedback.json', 'application/json'),
+    file: http.file(jsonContent, 'feedback.json', 'application/json'),
   };
 
   const params = {
@@ -115,28 +107,23 @@ function testFeedbackUpload(token) {
 function testFeedbackFetch(token) {
   if (!token) return false;
 
-  const url = `${baseUrl}/api/feedbacks/fetch`;
-
-  const payload = JSON.stringify({
-    limit: 10,
-    offset: 0
-  });
+  // Synthetic feedbacks are generated server side, the seed makes every iteration save new ones
+  const url = `${baseUrl}/api/feedbacks/fetch?count=10&seed=${1000000000 + __VU * 100000 + __ITER}`;
 
   const params = {
     headers: {
       'Authorization': token,
-      'Content-Type': 'application/json',
     },
   };
 
-  const response = http.post(url, payload, params);
+  const response = http.post(url, null, params);
 
   return check(response, {
     'fetch status is 200': (r) => r.status === 200,
-    'fetch response has data': (r) => {
+    'fetch response has counts': (r) => {
       try {
         const body = JSON.parse(r.body);
-        return body.data !== undefined;
+        return body.success_count !== undefined;
       } catch (e) {
         return false;
       }
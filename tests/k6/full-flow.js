@@ -105,12 +105,8 @@ function getUserInfo(token) {
 function fetchFeedbacks(token) {
   if (!token) return false;
 
-  const url = `${baseUrl}/api/feedbacks/fetch`;
-
-  const payload = JSON.stringify({
-    limit: 5,
-    offset: 0
-  });
+  // Synthetic feedbacks from the built-in generator, one seed per VU iteration
+  const url = `${baseUrl}/api/feedbacks/fetch?count=5&seed=${__VU * 100000 + __ITER}`;
 
   const params = {
     headers: {
@@ -119,7 +115,7 @@ function fetchFeedbacks(token) {
     },
   };
 
-  const response = http.post(url, payload, params);
+  const response = http.post(url, null, params);
 
   return check(response, {
     'fetch feedbacks status is 200': (r) => r.status === 200,
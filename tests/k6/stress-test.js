@@ -166,11 +166,8 @@ function testFeedbackEndpoint() {
     return;
   }
 
-  const url = `${baseUrl}/api/feedbacks/fetch`;
-  const payload = JSON.stringify({
-    limit: 10,
-    offset: Math.floor(Math.random() * 50) // Random offset for variety
-  });
+  // Synthetic feedbacks from the built-in generator, one seed per VU iteration
+  const url = `${baseUrl}/api/feedbacks/fetch?count=10&seed=${__VU * 100000 + __ITER}`;
 
   const params = {
     headers: {
@@ -179,7 +176,7 @@ function testFeedbackEndpoint() {
     },
   };
 
-  const response = http.post(url, payload, params);
+  const response = http.post(url, null, params);
 
   const success = check(response, {
     'feedback fetch status is 200': (r) => r.status === 200,